@@ -0,0 +1,45 @@
+package tasker
+
+import (
+	"strings"
+)
+
+//AsSystem returns a copy of the task running as NT AUTHORITY\SYSTEM.
+//Password and NoPassword are cleared since the service accounts never
+//take a password, which would otherwise make schtasks prompt for one.
+func (taskcreate TaskCreate) AsSystem() TaskCreate {
+	return taskcreate.asAccount(Accounts.SYSTEM)
+}
+
+//AsLocalService returns a copy of the task running as
+//NT AUTHORITY\LOCALSERVICE, see AsSystem.
+func (taskcreate TaskCreate) AsLocalService() TaskCreate {
+	return taskcreate.asAccount(Accounts.LOCALSERVICE)
+}
+
+//AsNetworkService returns a copy of the task running as
+//NT AUTHORITY\NETWORKSERVICE, see AsSystem.
+func (taskcreate TaskCreate) AsNetworkService() TaskCreate {
+	return taskcreate.asAccount(Accounts.NETWORKSERVICE)
+}
+
+func (taskcreate TaskCreate) asAccount(account string) TaskCreate {
+	taskcreate.Username = account
+	taskcreate.Password = ""
+	taskcreate.NoPassword = false
+	return taskcreate
+}
+
+//isServiceAccount reports whether user is one of the built-in service
+//accounts, by name or well known SID. An empty user is not considered
+//one since schtasks then runs the task as the caller.
+func isServiceAccount(user string) bool {
+	user = strings.ToUpper(strings.TrimSpace(user))
+	user = strings.TrimPrefix(user, "NT AUTHORITY\\")
+	switch user {
+	case "SYSTEM", "LOCALSERVICE", "LOCAL SERVICE", "NETWORKSERVICE", "NETWORK SERVICE",
+		"S-1-5-18", "S-1-5-19", "S-1-5-20":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package tasker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAsSystem(t *testing.T) {
+	tc := TaskCreate{
+		Taskname:   taskName,
+		Taskrun:    executable,
+		Schedule:   Schedules.ONSTART,
+		Password:   "secret",
+		NoPassword: true,
+	}.AsSystem()
+
+	cmds := strings.Join(tasker.TaskMake(tc, _Create.Command, true), " ")
+	if !strings.Contains(cmds, "/RU "+Accounts.SYSTEM) {
+		t.Errorf("missing run as user: %s", cmds)
+	}
+	if strings.Contains(cmds, _Create.password) || strings.Contains(cmds, _Create.noPassword) {
+		t.Errorf("service account must not pass a password: %s", cmds)
+	}
+}
+
+func TestInteractive(t *testing.T) {
+	tc := TaskCreate{
+		Taskname:    taskName,
+		Taskrun:     executable,
+		Schedule:    Schedules.ONLOGON,
+		Username:    "user",
+		Interactive: true,
+	}
+
+	cmds := strings.Join(tasker.TaskMake(tc, _Create.Command, true), " ")
+	if !strings.Contains(cmds, _Create.interactive) {
+		t.Errorf("missing interactive switch: %s", cmds)
+	}
+
+	cmds = strings.Join(tasker.TaskMake(tc.AsSystem(), _Create.Command, true), " ")
+	if strings.Contains(cmds, _Create.interactive) {
+		t.Errorf("service account can't run interactively: %s", cmds)
+	}
+}
@@ -0,0 +1,84 @@
+package tasker
+
+import "fmt"
+
+//Action a single action of a task definition, only the field of its
+//Kind is set
+type Action struct {
+	//Kind element name of the action: Exec, ComHandler, SendEmail or
+	//ShowMessage.
+	Kind string
+
+	Exec        *ExecAction
+	ComHandler  *ComHandlerAction
+	SendEmail   *EmailAction
+	ShowMessage *MessageAction
+}
+
+//decodeAction reads an action element of the task XML
+func decodeAction(node *xmlNode) (Action, error) {
+	action := Action{Kind: node.XMLName.Local}
+	var v interface{}
+	switch action.Kind {
+	case "Exec":
+		action.Exec = &ExecAction{}
+		v = action.Exec
+	case "ComHandler":
+		action.ComHandler = &ComHandlerAction{}
+		v = action.ComHandler
+	case "SendEmail":
+		action.SendEmail = &EmailAction{}
+		v = action.SendEmail
+	case "ShowMessage":
+		action.ShowMessage = &MessageAction{}
+		v = action.ShowMessage
+	default:
+		return action, nil
+	}
+	return action, node.decode(v)
+}
+
+//RemoveAction removes the actions of the registered task match reports
+//true for, editing its definition like EnsureTrigger, which /CHANGE can't
+//do. A task needs an action, removing all of them fails with
+//ErrInvalidValue. removed counts the actions removed, the task is left
+//alone when none matched.
+func (task SchTask) RemoveAction(name string, own bool, match func(Action) bool) (removed int, err error) {
+	if task.debugging() {
+		return 0, nil
+	}
+
+	var patchErr error
+	_, err = task.updateDefinition(TaskCreate{Taskname: name}, own, func(root *xmlNode) bool {
+		actions := root.child("Actions")
+		if actions == nil {
+			return false
+		}
+		kept := []*xmlNode{}
+		for _, node := range actions.Nodes {
+			action, err := decodeAction(node)
+			if err != nil {
+				patchErr = err
+				return false
+			}
+			if match(action) {
+				removed++
+				continue
+			}
+			kept = append(kept, node)
+		}
+		if removed > 0 && len(kept) == 0 {
+			patchErr = fmt.Errorf("%w: removing every action of %s", ErrInvalidValue, name)
+			return false
+		}
+		actions.Nodes = kept
+		return removed > 0
+	})
+	if err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
@@ -0,0 +1,53 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRemoveAction(t *testing.T) {
+	registered := strings.Replace(calendarXML, "</Task>", `<Actions Context="Author">
+    <Exec><Command>notepad.exe</Command></Exec>
+    <ShowMessage><Title>Hello</Title><Body>World</Body></ShowMessage>
+  </Actions>
+</Task>`, 1)
+	creates := 0
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch args[0] {
+		case _Query.Command:
+			return []byte(registered), nil
+		case _Create.Command:
+			data, err := os.ReadFile(args[4])
+			if err != nil {
+				t.Fatal(err)
+			}
+			registered = decodeUTF16(data)
+			creates++
+		}
+		return nil, nil
+	}))
+
+	removed, err := task.RemoveAction(taskName, true, func(action Action) bool {
+		return action.ShowMessage != nil && action.ShowMessage.Title == "Hello"
+	})
+	if removed != 1 || err != nil {
+		t.Errorf("RemoveAction() = %d, %v", removed, err)
+	}
+	def, err := ParseDefinition([]byte(registered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(def.Actions.ShowMessage) != 0 || len(def.Actions.Exec) != 1 || def.Actions.Context != "Author" {
+		t.Errorf("Actions = %+v", def.Actions)
+	}
+
+	exec := func(action Action) bool {
+		return action.Kind == "Exec" && action.Exec.Command == executable
+	}
+	if _, err := task.RemoveAction(taskName, true, exec); !errors.Is(err, ErrInvalidValue) || creates != 1 {
+		t.Errorf("RemoveAction() last = %v, %d registrations, want ErrInvalidValue", err, creates)
+	}
+}
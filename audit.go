@@ -0,0 +1,155 @@
+package tasker
+
+import (
+	"strings"
+)
+
+//Difference a field whose registered value differs from the requested one
+type Difference struct {
+	Field string
+	Old   string
+	New   string
+}
+
+//AuditReport result of comparing the registered tasks with the desired
+//definitions
+type AuditReport struct {
+	//Missing desired tasks which are not registered
+	Missing []string
+
+	//Extra owned tasks which are not desired
+	Extra []string
+
+	//Drift per task differences, Old holds the registered value
+	Drift map[string][]Difference
+}
+
+//Clean reports whether the registered tasks match the desired ones
+func (report AuditReport) Clean() bool {
+	return len(report.Missing) == 0 && len(report.Extra) == 0 && len(report.Drift) == 0
+}
+
+//Audit compares the owned registered tasks with the desired definitions,
+//reporting missing and extra tasks and the drifted schedule, action and
+//run level of the others.
+func (task SchTask) Audit(desired []TaskCreate) (AuditReport, error) {
+	report := AuditReport{
+		Missing: []string{},
+		Extra:   []string{},
+		Drift:   map[string][]Difference{},
+	}
+
+	owned, err := task.owned()
+	if err != nil {
+		return report, err
+	}
+
+	live := map[string]string{}
+	for _, t := range owned {
+		live[strings.ToLower(taskPath(t.name))] = t.name
+	}
+
+	for _, taskcreate := range desired {
+		name := task.fullName(taskcreate.Taskname, true)
+		key := strings.ToLower(taskPath(name))
+		registered, ok := live[key]
+		if !ok {
+			report.Missing = append(report.Missing, name)
+			continue
+		}
+		delete(live, key)
+
+		def, err := task.GetTask(registered, false)
+		if err != nil {
+			return report, err
+		}
+		if diffs := compareDefinition(def, taskcreate); len(diffs) > 0 {
+			report.Drift[name] = diffs
+		}
+	}
+
+	for _, name := range live {
+		report.Extra = append(report.Extra, name)
+	}
+
+	return report, nil
+}
+
+//compareDefinition lists the differences of the schedule, action and
+//run level between a registered definition and a requested task, an
+//empty requested schedule is not compared
+func compareDefinition(def TaskDefinition, taskcreate TaskCreate) []Difference {
+	diffs := []Difference{}
+
+	if taskcreate.Schedule != "" {
+		schedule := scheduleOf(def)
+		if !strings.EqualFold(schedule, taskcreate.Schedule) {
+			diffs = append(diffs, Difference{"Schedule", schedule, strings.ToUpper(taskcreate.Schedule)})
+		}
+	}
+
+	run, args := taskcreate.action()
+	command, arguments := "", ""
+	if len(def.Actions.Exec) > 0 {
+		command = strings.Trim(def.Actions.Exec[0].Command, "\"")
+		arguments = strings.TrimSpace(def.Actions.Exec[0].Arguments)
+	}
+	if len(def.Actions.Exec) != 1 || !strings.EqualFold(command, run) {
+		diffs = append(diffs, Difference{"Taskrun", command, run})
+	}
+	if arguments != args {
+		diffs = append(diffs, Difference{"Arguments", arguments, args})
+	}
+
+	level := Level.LIMITED
+	if def.Principal().RunLevel == "HighestAvailable" {
+		level = Level.HIGHEST
+	}
+	want := Level.LIMITED
+	if strings.EqualFold(taskcreate.Level, Level.HIGHEST) {
+		want = Level.HIGHEST
+	}
+	if level != want {
+		diffs = append(diffs, Difference{"Level", level, want})
+	}
+
+	return diffs
+}
+
+//scheduleOf maps the first trigger of a definition to its /SC schedule
+func scheduleOf(def TaskDefinition) string {
+	if len(def.Triggers.Items) == 0 {
+		return ""
+	}
+
+	trigger := def.Triggers.Items[0]
+	switch trigger.Kind() {
+	case "TimeTrigger":
+		if trigger.Repetition != nil && strings.HasSuffix(trigger.Repetition.Interval, "H") {
+			return Schedules.HOURLY
+		}
+		if trigger.Repetition != nil && strings.HasSuffix(trigger.Repetition.Interval, "M") {
+			return Schedules.MINUTE
+		}
+		return Schedules.ONCE
+	case "CalendarTrigger":
+		switch {
+		case trigger.ScheduleByDay != nil:
+			return Schedules.DAILY
+		case trigger.ScheduleByWeek != nil:
+			return Schedules.WEEKLY
+		default:
+			return Schedules.MONTHLY
+		}
+	case "BootTrigger":
+		return Schedules.ONSTART
+	case "LogonTrigger":
+		return Schedules.ONLOGON
+	case "IdleTrigger":
+		return Schedules.ONIDLE
+	case "EventTrigger":
+		return Schedules.ONEVENT
+	}
+
+	return trigger.Kind()
+}
@@ -0,0 +1,26 @@
+package tasker
+
+import (
+	"testing"
+)
+
+func TestCompareDefinition(t *testing.T) {
+	def, err := ParseDefinition([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diffs := compareDefinition(def, TaskCreate{
+		Taskname:  taskName,
+		Taskrun:   executable,
+		Arguments: []string{"a", "b c"},
+		Schedule:  Schedules.DAILY,
+		Level:     Level.HIGHEST,
+	})
+	if len(diffs) != 2 {
+		t.Fatalf("expected schedule and level differences, got %+v", diffs)
+	}
+	if diffs[0] != (Difference{"Schedule", "", Schedules.DAILY}) || diffs[1] != (Difference{"Level", Level.LIMITED, Level.HIGHEST}) {
+		t.Errorf("unexpected differences %+v", diffs)
+	}
+}
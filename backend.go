@@ -0,0 +1,82 @@
+package tasker
+
+import (
+	"fmt"
+	"io"
+)
+
+//Backend executes schtasks command lines. The default one runs the
+//schtasks process, replacements serve tests on machines without a Task
+//Scheduler, see the taskertest package. Failures should carry the exit
+//code through an ExitCode() int method like *exec.ExitError does.
+type Backend interface {
+	Execute(args []string, stdin io.Reader) ([]byte, error)
+}
+
+//EventBackend is implemented by backends which also read the Task
+//Scheduler operational event log, args are wevtutil arguments. History
+//is not supported by backends without it.
+type EventBackend interface {
+	QueryEvents(args []string) ([]byte, error)
+}
+
+//WithBackend returns a copy of the tasker executing its commands through
+//backend.
+func (task SchTask) WithBackend(backend Backend) SchTask {
+	task.backend = backend
+	return task
+}
+
+//Backend returns the backend executing the commands of the tasker
+func (task SchTask) Backend() Backend {
+	if task.backend != nil {
+		return task.backend
+	}
+	return processBackend{task}
+}
+
+//processBackend runs the schtasks executable of a tasker
+type processBackend struct {
+	task SchTask
+}
+
+//Execute implements Backend
+func (b processBackend) Execute(args []string, stdin io.Reader) ([]byte, error) {
+	if !supported {
+		return unsupported()
+	}
+
+	cmd := b.task.command(args...)
+	cmd.Stdin = stdin
+
+	var output []byte
+	var err error
+	if limit := b.task.outputLimit; limit > 0 {
+		capped := &cappedWriter{limit: limit}
+		cmd.Stdout, cmd.Stderr = capped, capped
+		err = cmd.Run()
+		output = capped.output()
+	} else {
+		output, err = cmd.CombinedOutput()
+		output = decodeOutput(output)
+	}
+	if isMissingBinary(err) {
+		return output, fmt.Errorf("%w: %s", ErrBinaryNotFound, b.task.bin)
+	}
+	return output, err
+}
+
+//QueryEvents implements EventBackend
+func (b processBackend) QueryEvents(args []string) ([]byte, error) {
+	if !supported {
+		return unsupported()
+	}
+
+	cmd := newCommand(systemBinary(eventFile), args...)
+	output, err := cmd.CombinedOutput()
+	output = decodeOutput(output)
+	if isMissingBinary(err) {
+		return output, fmt.Errorf("%w: %s", ErrBinaryNotFound, cmd.Path)
+	}
+	return output, err
+}
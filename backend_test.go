@@ -0,0 +1,144 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+//backendFunc adapts a function to the Backend interface
+type backendFunc func(args []string, stdin io.Reader) ([]byte, error)
+
+func (f backendFunc) Execute(args []string, stdin io.Reader) ([]byte, error) {
+	return f(args, stdin)
+}
+
+//eventBackend adds an event log to a backendFunc
+type eventBackend struct {
+	backendFunc
+	events func(args []string) ([]byte, error)
+}
+
+func (b eventBackend) QueryEvents(args []string) ([]byte, error) {
+	return b.events(args)
+}
+
+//exitError failure carrying an exit code like *exec.ExitError
+type exitError int
+
+func (e exitError) Error() string {
+	return "exit status"
+}
+
+func (e exitError) ExitCode() int {
+	return int(e)
+}
+
+func TestDeleteIfExists(t *testing.T) {
+	var calls [][]string
+	missing := false
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[1] == helpSwitch {
+			return nil, exitError(1)
+		}
+		calls = append(calls, args)
+		if missing {
+			return []byte("ERROR: The system cannot find the file specified.\r\n"), exitError(0x80070002)
+		}
+		return []byte("SUCCESS: The scheduled task \"go-wintask-Test\" was successfully deleted.\r\n"), nil
+	}))
+
+	existed, err := task.DeleteIfExists(taskName, true)
+	if !existed || err != nil {
+		t.Errorf("DeleteIfExists() = %v, %v, want true", existed, err)
+	}
+	if want := []string{_Delete.Command, _Delete.taskname, "\\go-wintask-Test", _Delete.force, hresultSwitch}; !reflect.DeepEqual(calls[0], want) {
+		t.Errorf("DeleteIfExists() ran %v, want %v", calls[0], want)
+	}
+
+	missing = true
+	if existed, err := task.DeleteIfExists(taskName, true); existed || err != nil {
+		t.Errorf("DeleteIfExists() missing = %v, %v, want false", existed, err)
+	}
+
+	//the text alone no longer tells a missing task apart
+	failed := task.WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		return []byte("ERROR: The system cannot find the file specified.\r\n"), exitError(1)
+	}))
+	if _, err := failed.DeleteIfExists(taskName, true); !errors.As(err, new(*Error)) {
+		t.Errorf("DeleteIfExists() exit 1 = %v, want *Error", err)
+	}
+}
+
+func TestDeleteIfExistsCompatibility(t *testing.T) {
+	var calls [][]string
+	task := New(true).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		calls = append(calls, args)
+		return []byte(summaryCSV), nil
+	}))
+
+	if existed, err := task.DeleteIfExists("Missing", true); existed || err != nil {
+		t.Errorf("DeleteIfExists() missing = %v, %v, want false", existed, err)
+	}
+	for _, call := range calls {
+		if call[0] == _Delete.Command {
+			t.Errorf("DeleteIfExists() missing ran %v", call)
+		}
+	}
+}
+
+func TestQueryExact(t *testing.T) {
+	var calls [][]string
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[1] == helpSwitch {
+			return nil, exitError(1)
+		}
+		calls = append(calls, args)
+		if argValue(args, _Query.taskname) == "\\go-wintask-Missing" {
+			return []byte("ERROR: The system cannot find the file specified.\r\n"), exitError(0x80070002)
+		}
+		return []byte("\"\\go-wintask-Test\",\"N/A\",\"Ready\"\r\n"), nil
+	}))
+
+	if tasks := task.Query(taskName, true); len(tasks) != 1 || tasks[0].Name() != "\\go-wintask-Test" {
+		t.Errorf("Query() = %+v", tasks)
+	}
+	want := []string{_Query.Command, _Query.taskname, "\\go-wintask-Test", _Query.format, _Query.formatCSV, _Query.noHeader, hresultSwitch}
+	if !reflect.DeepEqual(calls[0], want) {
+		t.Errorf("Query() ran %v, want %v", calls[0], want)
+	}
+
+	if tasks := task.Query("Missing", true); len(tasks) != 0 {
+		t.Errorf("Query() missing = %+v", tasks)
+	}
+
+	calls = nil
+	task.Query("Te*", true)
+	if argValue(calls[0], _Query.taskname) != "" {
+		t.Errorf("Query() pattern ran %v, want an enumeration", calls[0])
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	task := New(false)
+	for _, test := range []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"", "\\Backup", true},
+		{"*", "\\Backup", true},
+		{"backup", "\\Backup", true},
+		{"back", "\\Backup", false},
+		{"back*", "\\Tools\\Backup", true},
+		{"b?ckup", "\\Backup", true},
+		{"\\Tools\\*", "\\Tools\\Backup", true},
+		{"\\Tools\\*", "\\Backup", false},
+		{"tools/backup", "\\Tools\\Backup", true},
+		{"a.c*", "\\abc", false},
+	} {
+		if got := task.match(Filter{test.pattern, false}, test.name); got != test.want {
+			t.Errorf("match(%q, %q) = %v, want %v", test.pattern, test.name, got, test.want)
+		}
+	}
+}
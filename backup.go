@@ -0,0 +1,79 @@
+package tasker
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	backupExt = ".xml"
+)
+
+//Backup exports the definition of every task selected by filter as an
+//XML file below dir, task folders become sub directories. Returns the
+//written files.
+func (task SchTask) Backup(dir string, filter Filter) ([]string, error) {
+	all, err := task.list()
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, t := range all {
+		if !task.match(filter, t.name) {
+			continue
+		}
+
+		definition, err := task.ExportXML(t.name, false)
+		if err != nil {
+			return files, err
+		}
+
+		parts := strings.Split(strings.TrimPrefix(t.name, "\\"), "\\")
+		file := filepath.Join(append([]string{dir}, parts...)...) + backupExt
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return files, err
+		}
+		if err := os.WriteFile(file, encodeUTF16(definition), 0644); err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+//encodeUTF16 encodes s as UTF-16LE with a byte order mark, the encoding
+//schtasks declares and expects for task XML files
+func encodeUTF16(s string) []byte {
+	chars := utf16.Encode([]rune(s))
+	data := make([]byte, 2, 2+2*len(chars))
+	binary.LittleEndian.PutUint16(data, 0xFEFF)
+	for _, c := range chars {
+		data = binary.LittleEndian.AppendUint16(data, c)
+	}
+	return data
+}
+
+//decodeUTF16 decodes UTF-16 data with a byte order mark, other data is
+//returned as is
+func decodeUTF16(data []byte) string {
+	var order binary.ByteOrder
+	switch {
+	case len(data) >= 2 && data[0] == 0xFF && data[1] == 0xFE:
+		order = binary.LittleEndian
+	case len(data) >= 2 && data[0] == 0xFE && data[1] == 0xFF:
+		order = binary.BigEndian
+	default:
+		return string(data)
+	}
+
+	chars := make([]uint16, (len(data)-2)/2)
+	for i := range chars {
+		chars[i] = order.Uint16(data[2+2*i:])
+	}
+	return string(utf16.Decode(chars))
+}
@@ -0,0 +1,19 @@
+package tasker
+
+import (
+	"testing"
+)
+
+func TestUTF16RoundTrip(t *testing.T) {
+	s := `<?xml version="1.0" encoding="UTF-16"?><Task>Zeitplan – ü</Task>`
+	data := encodeUTF16(s)
+	if data[0] != 0xFF || data[1] != 0xFE {
+		t.Errorf("missing byte order mark % x", data[:2])
+	}
+	if got := decodeUTF16(data); got != s {
+		t.Errorf("got %q, want %q", got, s)
+	}
+	if got := decodeUTF16([]byte(s)); got != s {
+		t.Errorf("plain data changed: %q", got)
+	}
+}
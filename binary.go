@@ -0,0 +1,33 @@
+package tasker
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const (
+	defaultSystemRoot = `C:\Windows`
+)
+
+//WithBinary returns a copy of the tasker running the schtasks executable
+//at the given full path instead of the System32 one.
+func (task SchTask) WithBinary(bin string) SchTask {
+	task.bin = bin
+	task.caps = &capsProbe{}
+	return task
+}
+
+//Binary full path of the schtasks executable in use
+func (task SchTask) Binary() string {
+	return task.bin
+}
+
+//systemBinary resolves an executable of %SystemRoot%\System32 by its full
+//path, so a same named binary earlier in PATH can't hijack the calls
+func systemBinary(name string) string {
+	root := os.Getenv("SystemRoot")
+	if root == "" {
+		root = defaultSystemRoot
+	}
+	return filepath.Join(root, "System32", name)
+}
@@ -0,0 +1,116 @@
+package tasker
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+const (
+	//maxDelaytime longest wait /DELAY accepts, 9999:59
+	maxDelaytime = 9999*time.Minute + 59*time.Second
+)
+
+//BootTrigger runs the task when the system starts, an ONSTART schedule
+//with a typed delay.
+type BootTrigger struct {
+	//Delay wait time after the system started, at most 9999:59 minutes
+	//with a second resolution.
+	Delay time.Duration
+
+	//RandomDelay upper bound of a random wait added to Delay. The boot
+	//trigger of the Task Scheduler has no random delay, it is drawn once
+	//when the command is made, so machines booting together start the
+	//task at different times.
+	RandomDelay time.Duration
+}
+
+//delay returns the wait time of the trigger, Delay plus the random delay
+func (boot BootTrigger) delay() time.Duration {
+	delay := boot.Delay
+	if boot.RandomDelay > 0 {
+		delay += time.Duration(rand.Int63n(int64(boot.RandomDelay) + 1))
+	}
+	return delay
+}
+
+//check validates the delays, /DELAY takes at most 9999:59 minutes
+func (boot BootTrigger) check() error {
+	if boot.Delay < 0 || boot.RandomDelay < 0 {
+		return fmt.Errorf("%w: negative boot delay", ErrInvalidValue)
+	}
+	if boot.Delay+boot.RandomDelay > maxDelaytime {
+		return fmt.Errorf("%w: boot delay %v exceeds %s", ErrInvalidValue, boot.Delay+boot.RandomDelay,
+			formatDelaytime(maxDelaytime))
+	}
+	return nil
+}
+
+//withBoot sets the ONSTART schedule and the /DELAY of the boot trigger,
+//an explicit Delay takes precedence
+func (taskcreate TaskCreate) withBoot() TaskCreate {
+	if taskcreate.Boot == nil {
+		return taskcreate
+	}
+	if taskcreate.Schedule == "" {
+		taskcreate.Schedule = Schedules.ONSTART
+	}
+	if taskcreate.Delay == 0 {
+		taskcreate.Delay = taskcreate.Boot.delay()
+	}
+	return taskcreate
+}
+
+//checkDelay validates the boot trigger and that a delay is only given
+//for the ONSTART, ONLOGON and ONEVENT schedules as documented for /DELAY
+func (taskcreate TaskCreate) checkDelay() error {
+	if boot := taskcreate.Boot; boot != nil {
+		if taskcreate.Schedule != "" && !strings.EqualFold(taskcreate.Schedule, Schedules.ONSTART) {
+			return fmt.Errorf("%w: boot trigger with schedule %s", ErrInvalidValue, taskcreate.Schedule)
+		}
+		if err := boot.check(); err != nil {
+			return err
+		}
+		taskcreate = taskcreate.withBoot()
+	}
+	if taskcreate.Delay == 0 {
+		return nil
+	}
+
+	if taskcreate.Delay < 0 || taskcreate.Delay > maxDelaytime {
+		return fmt.Errorf("%w: delay %v, /DELAY takes 0000:00 to %s", ErrInvalidValue, taskcreate.Delay,
+			formatDelaytime(maxDelaytime))
+	}
+	switch strings.ToUpper(taskcreate.Schedule) {
+	case Schedules.ONSTART, Schedules.ONLOGON, Schedules.ONEVENT:
+		return nil
+	}
+	return fmt.Errorf("%w: delay with schedule %s, only ONSTART, ONLOGON and ONEVENT support it",
+		ErrInvalidValue, taskcreate.Schedule)
+}
+
+//formatDelaytime formats d as the mmmm:ss value of /DELAY, truncated to
+//the second
+func formatDelaytime(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+	return fmt.Sprintf("%04d:%02d", minutes, seconds)
+}
+
+//Boot returns the boot trigger of a registered BootTrigger definition,
+//reading its XML duration.
+func (trigger Trigger) Boot() (BootTrigger, bool) {
+	if trigger.XMLName.Local != "BootTrigger" {
+		return BootTrigger{}, false
+	}
+	boot := BootTrigger{}
+	if trigger.Delay != "" {
+		delay, err := parseXMLDuration(trigger.Delay)
+		if err != nil {
+			return BootTrigger{}, false
+		}
+		boot.Delay = delay
+	}
+	return boot, true
+}
@@ -0,0 +1,78 @@
+package tasker
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBootTrigger(t *testing.T) {
+	taskcreate := TaskCreate{Taskname: taskName, Taskrun: executable,
+		Boot: &BootTrigger{Delay: 90 * time.Second}}
+	cmds := tasker.TaskMake(taskcreate, _Create.Command, true)
+	if !containsPair(cmds, _Create.schedule, Schedules.ONSTART) || !containsPair(cmds, _Create.delaytime, "0001:30") {
+		t.Errorf("TaskMake() = %v", cmds)
+	}
+
+	taskcreate.Boot = &BootTrigger{Delay: time.Minute, RandomDelay: time.Minute}
+	for i := 0; i < 20; i++ {
+		delay := taskcreate.Boot.delay()
+		if delay < time.Minute || delay > 2*time.Minute {
+			t.Fatalf("delay() = %v, want within [1m, 2m]", delay)
+		}
+	}
+
+	if got := formatDelaytime(maxDelaytime + 500*time.Millisecond); got != "9999:59" {
+		t.Errorf("formatDelaytime() = %s", got)
+	}
+}
+
+func TestCheckDelay(t *testing.T) {
+	cases := []struct {
+		taskcreate TaskCreate
+		ok         bool
+	}{
+		{TaskCreate{Schedule: Schedules.ONSTART, Delay: 10 * time.Minute}, true},
+		{TaskCreate{Schedule: "onlogon", Delay: 30 * time.Second}, true},
+		{TaskCreate{Schedule: Schedules.ONEVENT, Delay: maxDelaytime}, true},
+		{TaskCreate{Schedule: Schedules.DAILY, Delay: 10 * time.Minute}, false},
+		{TaskCreate{Schedule: Schedules.ONSTART, Delay: -time.Minute}, false},
+		{TaskCreate{Schedule: Schedules.ONSTART, Delay: maxDelaytime + time.Second}, false},
+		{TaskCreate{Boot: &BootTrigger{Delay: time.Hour}}, true},
+		{TaskCreate{Schedule: Schedules.ONSTART, Boot: &BootTrigger{}}, true},
+		{TaskCreate{Schedule: Schedules.ONLOGON, Boot: &BootTrigger{Delay: time.Hour}}, false},
+		{TaskCreate{Boot: &BootTrigger{Delay: -time.Second}}, false},
+		{TaskCreate{Boot: &BootTrigger{Delay: maxDelaytime, RandomDelay: time.Second}}, false},
+		{TaskCreate{Schedule: Schedules.DAILY}, true},
+	}
+	for _, c := range cases {
+		err := c.taskcreate.checkDelay()
+		if c.ok && err != nil || !c.ok && !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("checkDelay(%+v) = %v", c.taskcreate, err)
+		}
+	}
+}
+
+func TestTriggerBoot(t *testing.T) {
+	trigger := Trigger{}
+	if err := xml.Unmarshal([]byte(`<BootTrigger><Delay>PT1M30S</Delay></BootTrigger>`), &trigger); err != nil {
+		t.Fatal(err)
+	}
+	if boot, ok := trigger.Boot(); !ok || boot.Delay != 90*time.Second {
+		t.Errorf("Boot() = %+v, %v", boot, ok)
+	}
+	if _, ok := (Trigger{XMLName: xml.Name{Local: "LogonTrigger"}}).Boot(); ok {
+		t.Error("Boot() of a logon trigger")
+	}
+}
+
+//containsPair reports whether the switch is followed by value in cmds
+func containsPair(cmds []string, option, value string) bool {
+	for i := 0; i+1 < len(cmds); i++ {
+		if cmds[i] == option && cmds[i+1] == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package tasker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+const (
+	//boundaryLayout xs:dateTime of the trigger boundaries, local times
+	//leave out the offset
+	boundaryLayout = "2006-01-02T15:04:05"
+)
+
+//formatBoundary formats t as a trigger boundary. Times of time.Local are
+//written without offset and follow the clock of the machine, across
+//time zones and daylight saving time, others keep their UTC offset.
+func formatBoundary(t time.Time) string {
+	if t.Location() == time.Local {
+		return t.Format(boundaryLayout)
+	}
+	return t.Format(boundaryLayout + "Z07:00")
+}
+
+//checkBoundaries validates the activation and expiration timestamps,
+//which replace the /SD, /ST and /ED switches
+func (taskcreate TaskCreate) checkBoundaries() error {
+	start, end := taskcreate.StartBoundary, taskcreate.EndBoundary
+	switch {
+	case !start.IsZero() && (taskcreate.Startdate != "" || taskcreate.Starttime != ""):
+		return fmt.Errorf("%w: StartBoundary combined with a start date or time", ErrInvalidValue)
+	case !end.IsZero() && taskcreate.Enddate != "":
+		return fmt.Errorf("%w: EndBoundary combined with an end date", ErrInvalidValue)
+	case !start.IsZero() && !end.IsZero() && !end.After(start):
+		return fmt.Errorf("%w: EndBoundary %s not after StartBoundary %s", ErrInvalidValue,
+			formatBoundary(end), formatBoundary(start))
+	}
+	return nil
+}
+
+//patchBoundaries sets the activation and expiration timestamps of every
+//trigger, reports whether anything changed
+func (taskcreate TaskCreate) patchBoundaries(root *xmlNode) bool {
+	start, end := taskcreate.StartBoundary, taskcreate.EndBoundary
+	if start.IsZero() && end.IsZero() {
+		return false
+	}
+
+	changed := false
+	for _, trigger := range root.ensure("Triggers").Nodes {
+		if !start.IsZero() {
+			node := trigger.child("StartBoundary")
+			if node == nil {
+				//the first element of every trigger
+				node = &xmlNode{XMLName: xml.Name{Local: "StartBoundary"}}
+				trigger.Nodes = append([]*xmlNode{node}, trigger.Nodes...)
+			}
+			node.Text = formatBoundary(start)
+		}
+		if !end.IsZero() {
+			trigger.insert("EndBoundary", "Enabled", "Repetition", "ExecutionTimeLimit", "Delay",
+				"RandomDelay", "Subscription", "ValueQueries", "UserId", "StateChange",
+				"ScheduleByDay", "ScheduleByWeek", "ScheduleByMonth", "ScheduleByMonthDayOfWeek").Text = formatBoundary(end)
+		}
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,62 @@
+package tasker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPatchBoundaries(t *testing.T) {
+	cest := time.FixedZone("CEST", 2*60*60)
+	tc := TaskCreate{Taskname: taskName, Schedule: Schedules.DAILY,
+		StartBoundary: time.Date(2024, 6, 1, 22, 30, 0, 0, cest),
+		EndBoundary:   time.Date(2024, 9, 1, 6, 0, 0, 0, time.UTC)}
+	if !tc.needsPatch() {
+		t.Fatal("needsPatch is false with boundaries")
+	}
+	if err := tc.checkBoundaries(); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := parseNode([]byte(calendarXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tc.patchDefinition(root) {
+		t.Fatal("patchDefinition reported no change")
+	}
+	for _, trigger := range root.child("Triggers").Nodes {
+		if trigger.Nodes[0].XMLName.Local != "StartBoundary" || trigger.Nodes[1].XMLName.Local != "EndBoundary" {
+			t.Errorf("%s order = %v %v", trigger.XMLName.Local, trigger.Nodes[0].XMLName, trigger.Nodes[1].XMLName)
+		}
+		if start := trigger.get("StartBoundary"); start != "2024-06-01T22:30:00+02:00" {
+			t.Errorf("StartBoundary = %s", start)
+		}
+		if end := trigger.get("EndBoundary"); end != "2024-09-01T06:00:00Z" {
+			t.Errorf("EndBoundary = %s", end)
+		}
+	}
+
+	local := time.Date(2024, 6, 1, 22, 30, 0, 0, time.Local)
+	if got := formatBoundary(local); got != "2024-06-01T22:30:00" {
+		t.Errorf("formatBoundary(local) = %s", got)
+	}
+}
+
+func TestCheckBoundaries(t *testing.T) {
+	start := time.Date(2024, 6, 1, 22, 30, 0, 0, time.UTC)
+	for _, tc := range []TaskCreate{
+		{StartBoundary: start, EndBoundary: start},
+		{StartBoundary: start, EndBoundary: start.Add(-time.Hour)},
+		{StartBoundary: start, Startdate: "06/01/2024"},
+		{StartBoundary: start, Starttime: "22:30"},
+		{EndBoundary: start, Enddate: "09/01/2024"},
+	} {
+		if err := tc.checkBoundaries(); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("checkBoundaries(%+v) = %v, want ErrInvalidValue", tc, err)
+		}
+	}
+	if err := (TaskCreate{EndBoundary: start, Starttime: "22:30"}).checkBoundaries(); err != nil {
+		t.Errorf("checkBoundaries() end only = %v", err)
+	}
+}
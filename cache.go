@@ -0,0 +1,61 @@
+package tasker
+
+import (
+	"sync"
+	"time"
+)
+
+//queryCache task enumeration shared by the copies of a SchTask
+type queryCache struct {
+	sync.Mutex
+	ttl   time.Duration
+	at    time.Time
+	tasks []Task
+}
+
+//WithCache returns a copy of the tasker reusing a task enumeration for
+//ttl, so hot paths like status polling don't spawn schtasks on every
+//call. Operations other than queries invalidate the cache.
+func (task SchTask) WithCache(ttl time.Duration) SchTask {
+	task.cache = &queryCache{ttl: ttl}
+	return task
+}
+
+//Invalidate drops the cached enumeration, use it after tasks were
+//changed by other means than this tasker.
+func (task SchTask) Invalidate() {
+	task.snapshot.invalidate()
+	if task.cache == nil {
+		return
+	}
+
+	task.cache.Lock()
+	defer task.cache.Unlock()
+	task.cache.tasks = nil
+}
+
+//get returns the cached enumeration when still fresh
+func (cache *queryCache) get() ([]Task, bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+	if cache.tasks == nil || time.Since(cache.at) > cache.ttl {
+		return nil, false
+	}
+	return cache.tasks, true
+}
+
+//set stores an enumeration
+func (cache *queryCache) set(tasks []Task) {
+	if cache == nil {
+		return
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+	cache.tasks = tasks
+	cache.at = time.Now()
+}
@@ -0,0 +1,23 @@
+package tasker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	cached := New(false).WithCache(time.Minute)
+	cached.cache.set([]Task{{name: "\\go-wintask-Test", datetime: "N/A", status: "Ready"}})
+
+	if tasks, ok := cached.cache.get(); !ok || len(tasks) != 1 {
+		t.Fatalf("expected cached enumeration, got %v", tasks)
+	}
+	if tasks := cached.Query(taskName, true); len(tasks) != 1 {
+		t.Errorf("query did not use the cache, got %v", tasks)
+	}
+
+	cached.Invalidate()
+	if _, ok := cached.cache.get(); ok {
+		t.Error("expected invalidated cache")
+	}
+}
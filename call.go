@@ -0,0 +1,29 @@
+package tasker
+
+//CallOption adjusts the tasker for a single call, e.g.
+//	task.Create(taskcreate, OnHost("srv01"))
+//so one tasker value serves many systems.
+type CallOption func(SchTask) SchTask
+
+//OnHost performs the call on the remote system host with the
+//credentials of the current user, see WithRemote.
+func OnHost(host string) CallOption {
+	return OnTarget(Target{Host: host})
+}
+
+//OnTarget performs the call on target, see WithTarget.
+func OnTarget(target Target) CallOption {
+	return func(task SchTask) SchTask {
+		return task.WithTarget(target)
+	}
+}
+
+//with returns the tasker adjusted by the call options
+func (task SchTask) with(opts []CallOption) SchTask {
+	for _, opt := range opts {
+		if opt != nil {
+			task = opt(task)
+		}
+	}
+	return task
+}
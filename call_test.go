@@ -0,0 +1,31 @@
+package tasker
+
+import (
+	"io"
+	"testing"
+)
+
+func TestCallOptions(t *testing.T) {
+	var hosts []string
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[1] != helpSwitch {
+			hosts = append(hosts, argValue(args, hostSwitch))
+		}
+		return []byte(summaryCSV), nil
+	}))
+
+	task.RunTask(taskName, true, OnHost("srv01"))
+	task.Query("*", true, OnTarget(Target{Host: "srv02"}))
+	task.EndTask(taskName, true)
+	task.Exists(taskName, true, nil)
+
+	want := []string{"srv01", "srv02", "", ""}
+	if len(hosts) != len(want) {
+		t.Fatalf("ran on %q, want %q", hosts, want)
+	}
+	for i := range want {
+		if hosts[i] != want[i] {
+			t.Errorf("call %d ran on %q, want %q", i, hosts[i], want[i])
+		}
+	}
+}
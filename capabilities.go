@@ -0,0 +1,123 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	//ErrNotSupported the option is not available on this OS
+	ErrNotSupported = errors.New("tasker: not supported on this OS")
+)
+
+//Capabilities features of the installed Task Scheduler, detected from the
+//documented schtasks switches. Their names are not localized.
+type Capabilities struct {
+	//Detected false when the help could not be read or is not the one of
+	//schtasks, every feature is then assumed available
+	Detected bool
+
+	//OSVersion e.g. "10.0.19045"
+	OSVersion string
+
+	Interval bool // /RI
+	Delay    bool // /DELAY
+	RunLevel bool // /RL
+	XML      bool // /XML
+	NoHeader bool // /NH
+	HRESULT  bool // /HRESULT
+}
+
+//capsProbe capabilities detected once and shared by the copies of a
+//SchTask
+type capsProbe struct {
+	once sync.Once
+	caps Capabilities
+}
+
+//Capabilities returns the features of the installed schtasks, detected
+//on first use
+func (task SchTask) Capabilities() Capabilities {
+	if task.caps == nil {
+		return task.detectCapabilities()
+	}
+
+	task.caps.once.Do(func() {
+		task.caps.caps = task.detectCapabilities()
+	})
+	return task.caps.caps
+}
+
+//detectCapabilities reads the /CREATE and /QUERY help
+func (task SchTask) detectCapabilities() Capabilities {
+	caps := Capabilities{
+		OSVersion: osVersion(),
+		Interval:  true,
+		Delay:     true,
+		RunLevel:  true,
+		XML:       true,
+		NoHeader:  true,
+		HRESULT:   true,
+	}
+	if task.debugging() {
+		return caps
+	}
+
+	create, err := task.execute(_Create.Command, helpSwitch)
+	if err != nil {
+		return caps
+	}
+	query, err := task.execute(_Query.Command, helpSwitch)
+	if err != nil {
+		return caps
+	}
+
+	documented := func(help []byte, option string) bool {
+		for _, field := range strings.Fields(strings.ToUpper(string(help))) {
+			if strings.Trim(field, "[]|") == option {
+				return true
+			}
+		}
+		return false
+	}
+
+	//help of a replacement backend, e.g. one answering every command
+	//with success, documents nothing
+	if !documented(create, _Create.taskname) || !documented(query, _Query.taskname) {
+		return caps
+	}
+
+	caps.Detected = true
+	caps.Interval = documented(create, _Create.interval)
+	caps.Delay = documented(create, _Create.delaytime)
+	caps.RunLevel = documented(create, _Create.level)
+	caps.XML = documented(create, _Create.xml)
+	caps.NoHeader = documented(query, _Query.noHeader)
+	caps.HRESULT = documented(query, hresultSwitch)
+	return caps
+}
+
+//Validate checks taskcreate only uses options the installed schtasks
+//supports
+func (caps Capabilities) Validate(taskcreate TaskCreate) error {
+	var option string
+	switch {
+	case taskcreate.Interval != "" && !caps.Interval:
+		option = _Create.interval
+	case taskcreate.Delay > 0 && !caps.Delay:
+		option = _Create.delaytime
+	case taskcreate.Level != "" && !caps.RunLevel:
+		option = _Create.level
+	case taskcreate.needsPatch() && !caps.XML:
+		option = _Create.xml
+	default:
+		return nil
+	}
+
+	if caps.OSVersion != "" {
+		return fmt.Errorf("%w: %s (windows %s)", ErrNotSupported, option, caps.OSVersion)
+	}
+	return fmt.Errorf("%w: %s", ErrNotSupported, option)
+}
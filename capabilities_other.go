@@ -0,0 +1,8 @@
+//go:build !windows
+
+package tasker
+
+//osVersion returns the windows version, "" outside windows
+func osVersion() string {
+	return ""
+}
@@ -0,0 +1,39 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCapabilities(t *testing.T) {
+	caps := tasker.WithDebug(true).Capabilities()
+	if caps.Detected {
+		t.Error("debug tasker must not run schtasks to detect capabilities")
+	}
+	if err := caps.Validate(TaskCreate{Level: Level.HIGHEST, Delay: time.Minute}); err != nil {
+		t.Errorf("undetected capabilities must allow every option, got %v", err)
+	}
+
+	//a backend answering the help with success but no schtasks help
+	silent := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		return nil, nil
+	}))
+	if caps := silent.Capabilities(); caps.Detected || !caps.RunLevel || !caps.XML {
+		t.Errorf("Capabilities() from an empty help = %+v, want the undetected defaults", caps)
+	}
+	if _, err := silent.CreateTask(TaskCreate{Taskname: taskName, Taskrun: executable, Level: Level.HIGHEST, Description: "d"}); errors.Is(err, ErrNotSupported) {
+		t.Errorf("CreateTask() through a silent backend = %v", err)
+	}
+
+	caps = Capabilities{Detected: true, OSVersion: "5.1.2600", NoHeader: true}
+	err := caps.Validate(TaskCreate{Level: Level.HIGHEST})
+	if !errors.Is(err, ErrNotSupported) || !strings.Contains(err.Error(), _Create.level) {
+		t.Errorf("Validate() = %v, want ErrNotSupported for /RL", err)
+	}
+	if err := caps.Validate(TaskCreate{Taskname: taskName, Taskrun: executable}); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+//go:build windows
+
+package tasker
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	ntdll             = syscall.NewLazyDLL("ntdll.dll")
+	procRtlGetVersion = ntdll.NewProc("RtlGetVersion")
+)
+
+//osVersionInfo RTL_OSVERSIONINFOW
+type osVersionInfo struct {
+	size        uint32
+	major       uint32
+	minor       uint32
+	build       uint32
+	platformID  uint32
+	servicePack [128]uint16
+}
+
+//osVersion returns the windows version, RtlGetVersion isn't subject to
+//the manifest based version lie of GetVersionEx
+func osVersion() string {
+	info := osVersionInfo{}
+	info.size = uint32(unsafe.Sizeof(info))
+	if ret, _, _ := procRtlGetVersion.Call(uintptr(unsafe.Pointer(&info))); ret != 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d.%d", info.major, info.minor, info.build)
+}
@@ -0,0 +1,34 @@
+package tasker
+
+import "fmt"
+
+//Chain registers taskcreate to run every time the task after completes,
+//through an ONEVENT trigger on the Task Scheduler completed event (102).
+//after is resolved like any other task name, own selects whether it is
+//one of the library's tasks. The operational event log must be enabled
+//(wevtutil sl Microsoft-Windows-TaskScheduler/Operational /e:true).
+func (task SchTask) Chain(after string, own bool, taskcreate TaskCreate) (string, error) {
+	name, err := task.resolveName(after, own)
+	if err != nil {
+		return "", err
+	}
+	taskcreate, err = chainTrigger(name, taskcreate)
+	if err != nil {
+		return "", err
+	}
+	return task.CreateTask(taskcreate)
+}
+
+//chainTrigger sets an ONEVENT trigger firing when the task registered as
+//name completes
+func chainTrigger(name string, taskcreate TaskCreate) (TaskCreate, error) {
+	if err := checkXPathLiteral(name); err != nil {
+		return taskcreate, err
+	}
+
+	taskcreate.Schedule = Schedules.ONEVENT
+	taskcreate.ChannelName = eventChannel
+	taskcreate.Modifier = fmt.Sprintf("*[System[EventID=%d] and EventData[Data[@Name='TaskName']='%s']]",
+		eventTaskCompleted, name)
+	return taskcreate, nil
+}
@@ -0,0 +1,27 @@
+package tasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	tc, err := chainTrigger(`\go-wintask-A`, TaskCreate{Taskname: "B", Taskrun: "b.exe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds := strings.Join(tasker.TaskMake(tc, _Create.Command, true), " ")
+	want := `/SC ONEVENT /MO *[System[EventID=102] and EventData[Data[@Name='TaskName']='\go-wintask-A']] ` +
+		`/EC Microsoft-Windows-TaskScheduler/Operational`
+	if !strings.Contains(cmds, want) {
+		t.Errorf("TaskMake() = %s, want %s", cmds, want)
+	}
+
+	if _, err := chainTrigger(`\it's`, tc); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("chainTrigger() with a quote = %v, want ErrInvalidName", err)
+	}
+	if _, err := tasker.WithDebug(true).Chain("A", true, TaskCreate{Taskname: "B"}); err != nil {
+		t.Errorf("Chain() = %v", err)
+	}
+}
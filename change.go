@@ -0,0 +1,128 @@
+package tasker
+
+import (
+	"fmt"
+	"strings"
+)
+
+//ChangeCredentials changes only the "run as" user and password of the
+//task, leaving its schedule and action alone. The password is ignored
+//for the service accounts.
+func (task SchTask) ChangeCredentials(name, user, password string, own bool) (string, error) {
+	return task.change(TaskCreate{Taskname: name, Username: user, Password: password}, own, func(taskcreate TaskCreate) (string, error) {
+		if task.debugging() {
+			return dbgMessage, nil
+		}
+
+		name, err := task.resolveName(taskcreate.Taskname, own)
+		if err != nil {
+			return "", err
+		}
+
+		cmds := []string{_Change.Command, _Change.taskname, name, _Change.username, taskcreate.Username}
+		if taskcreate.Password != "" && !isServiceAccount(taskcreate.Username) {
+			cmds = append(cmds, _Change.password, taskcreate.Password)
+		}
+
+		output, err := task.execute(cmds...)
+		return string(output), err
+	})
+}
+
+//ChangeAction changes only the program the task runs and its arguments,
+//keeping the triggers and settings, e.g. after an upgrade moved the
+//binaries. Command lines longer than /TR accepts need ChangeTask with
+//ActionXML.
+func (task SchTask) ChangeAction(name, taskrun string, args []string, own bool) (string, error) {
+	return task.changeAction(TaskCreate{Taskname: name, Taskrun: taskrun, Arguments: args}, own)
+}
+
+//changeAction performs ChangeAction with the action of taskcreate
+func (task SchTask) changeAction(taskcreate TaskCreate, own bool) (string, error) {
+	return task.change(taskcreate, own, func(taskcreate TaskCreate) (string, error) {
+		run, arguments := taskcreate.action()
+		run = strings.TrimSpace("\"" + run + "\" " + arguments)
+		if len(run) > maxTaskrun {
+			return "", fmt.Errorf("%w: the action exceeds %d characters", ErrInvalidValue, maxTaskrun)
+		}
+		if task.debugging() {
+			return dbgMessage, nil
+		}
+
+		name, err := task.resolveName(taskcreate.Taskname, own)
+		if err != nil {
+			return "", err
+		}
+
+		output, err := task.execute(_Change.Command, _Change.taskname, name, _Change.taskrun, run)
+		return string(output), err
+	})
+}
+
+//EnableTask enables the task so its triggers fire again
+func (task SchTask) EnableTask(name string, own bool) (string, error) {
+	return task.changeState(name, own, _Change.enable)
+}
+
+//DisableTask disables the task, its triggers no longer fire until it is
+//enabled again
+func (task SchTask) DisableTask(name string, own bool) (string, error) {
+	return task.changeState(name, own, _Change.disable)
+}
+
+func (task SchTask) changeState(name string, own bool, state string) (string, error) {
+	return task.change(TaskCreate{Taskname: name}, own, func(taskcreate TaskCreate) (string, error) {
+		if task.debugging() {
+			return dbgMessage, nil
+		}
+
+		name, err := task.resolveName(taskcreate.Taskname, own)
+		if err != nil {
+			return "", err
+		}
+
+		output, err := task.execute(_Change.Command, _Change.taskname, name, state)
+		return string(output), err
+	})
+}
+
+//ChangeReport outcome of ChangeWithReport, the fields of the task
+//definition the change modified, Old holding the value before.
+type ChangeReport struct {
+	Name    string
+	Output  string
+	Changes []Difference
+}
+
+//Changed reports whether the change modified the task definition
+func (report ChangeReport) Changed() bool {
+	return len(report.Changes) > 0
+}
+
+//ChangeWithReport is ChangeTask reporting the modified fields, compared
+//between the task XML read before and after the change, see Diff.
+func (task SchTask) ChangeWithReport(taskcreate TaskCreate, own bool) (ChangeReport, error) {
+	report := ChangeReport{Name: task.fullName(taskcreate.Taskname, own), Changes: []Difference{}}
+
+	if task.debugging() {
+		output, err := task.ChangeTask(taskcreate, own)
+		report.Output = output
+		return report, err
+	}
+
+	before, err := task.GetTask(taskcreate.Taskname, own)
+	if err != nil {
+		return report, err
+	}
+	report.Output, err = task.ChangeTask(taskcreate, own)
+	if err != nil {
+		return report, err
+	}
+	after, err := task.GetTask(taskcreate.Taskname, own)
+	if err != nil {
+		return report, err
+	}
+
+	report.Changes = Diff(before, after)
+	return report, nil
+}
@@ -0,0 +1,101 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChangeCredentials(t *testing.T) {
+	var got []string
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		got = args
+		return []byte("SUCCESS: The parameters of scheduled task \"\\go-wintask-Test\" have been changed.\r\n"), nil
+	}))
+
+	if _, err := task.ChangeCredentials(taskName, "bob", "secret", true); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/CHANGE", "/TN", "\\go-wintask-Test", "/RU", "bob", "/RP", "secret"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangeCredentials() ran %v, want %v", got, want)
+	}
+
+	if _, err := task.ChangeCredentials(taskName, Accounts.SYSTEM, "secret", true); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"/CHANGE", "/TN", "\\go-wintask-Test", "/RU", Accounts.SYSTEM}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangeCredentials() service account ran %v, want %v", got, want)
+	}
+}
+
+func TestChangeAction(t *testing.T) {
+	var got []string
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		got = args
+		return nil, nil
+	}))
+
+	if _, err := task.ChangeAction(taskName, `C:\Program Files\app\app.exe`, []string{"--mode", "a b"}, true); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/CHANGE", "/TN", "\\go-wintask-Test", "/TR", `"C:\Program Files\app\app.exe" --mode "a b"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangeAction() ran %v, want %v", got, want)
+	}
+
+	long := []string{strings.Repeat("x", maxTaskrun)}
+	if _, err := task.ChangeAction(taskName, "app.exe", long, true); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("ChangeAction() too long = %v, want ErrInvalidValue", err)
+	}
+
+	var changed []string
+	hooked := task.WithHooks(Hooks{
+		OnBeforeChange: func(taskcreate *TaskCreate, own bool) error {
+			taskcreate.Taskrun = strings.Replace(taskcreate.Taskrun, "old", "new", 1)
+			return nil
+		},
+		OnAfterChange: func(taskcreate TaskCreate, own bool, output string, err error) {
+			changed = append(changed, taskcreate.Taskname+" "+taskcreate.Taskrun)
+		},
+	})
+	if _, err := hooked.ChangeAction(taskName, `C:\old\app.exe`, nil, true); err != nil || got[4] != `"C:\new\app.exe"` {
+		t.Errorf("hooked ChangeAction() ran %v, %v", got, err)
+	}
+	if _, err := hooked.DisableTask(taskName, true); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{`Test C:\new\app.exe`, "Test "}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("change hooks saw %q, want %q", changed, want)
+	}
+}
+
+func TestChangeWithReport(t *testing.T) {
+	registered := singletonXML
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch args[0] {
+		case _Query.Command:
+			return []byte(registered), nil
+		case _Change.Command:
+			registered = strings.Replace(registered, "\"notepad.exe\"", "\"calc.exe\"", 1)
+		}
+		return []byte("SUCCESS: The parameters of scheduled task \"\\go-wintask-Test\" have been changed.\r\n"), nil
+	}))
+
+	report, err := task.ChangeWithReport(TaskCreate{Taskname: taskName, Taskrun: "calc.exe"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Difference{{"Actions.Exec[0].Command", "\"notepad.exe\"", "\"calc.exe\""}}
+	if !report.Changed() || !reflect.DeepEqual(report.Changes, want) || report.Name != "\\go-wintask-Test" {
+		t.Errorf("ChangeWithReport() = %+v, want %v", report, want)
+	}
+
+	report, err = task.ChangeWithReport(TaskCreate{Taskname: taskName, Taskrun: "calc.exe"}, true)
+	if err != nil || report.Changed() {
+		t.Errorf("ChangeWithReport() unchanged = %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,126 @@
+package tasker
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	powershellFile = "WindowsPowerShell\\v1.0\\powershell.exe"
+
+	//cleanupScript deletes the empty folders below and including the
+	//root folder, deepest first, through the Task Scheduler COM API as
+	//schtasks can't delete folders. Deleted folders are printed one per
+	//line, a missing root folder is not an error.
+	cleanupScript = `$ErrorActionPreference = 'Stop'
+$service = New-Object -ComObject Schedule.Service
+$service.Connect(%s)
+function Clean($folder) {
+	$empty = $true
+	foreach ($sub in @($folder.GetFolders(0))) {
+		if (-not (Clean $sub)) { $empty = $false }
+	}
+	if (-not $empty -or $folder.GetTasks(1).Count -gt 0) { return $false }
+	$service.GetFolder((Split-Path $folder.Path -Parent)).DeleteFolder((Split-Path $folder.Path -Leaf), 0)
+	[Console]::Out.WriteLine($folder.Path)
+	return $true
+}
+try { $root = $service.GetFolder(%s) } catch {
+	if ($_.Exception.HResult -eq 0x80070002) { exit 0 }
+	throw
+}
+[void](Clean $root)
+`
+)
+
+//WithFolderCleanup returns a copy of the tasker deleting the folders of
+//the namespace once deleting an owned task left them empty, so uninstalls
+//don't leave an empty \MyApp folder behind, see CleanupEmptyFolders.
+func (task SchTask) WithFolderCleanup(enabled bool) SchTask {
+	task.cleanupFolders = enabled
+	return task
+}
+
+//CleanupEmptyFolders deletes the folder of the namespace and its sub
+//folders when they hold no task, returning the deleted folders. Nothing
+//is deleted for namespaces without a folder, see Folder. The folders
+//above the namespace folder are left alone as other applications may
+//share them.
+func (task SchTask) CleanupEmptyFolders() ([]string, error) {
+	folder := task.folder()
+	if folder == "" || task.debugging() {
+		return []string{}, nil
+	}
+	if !supported {
+		return nil, ErrUnsupportedPlatform
+	}
+
+	output, err := powershell(task.cleanupScript(folder))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFolders(output), nil
+}
+
+//powershell runs a PowerShell script, failures carry its output
+func powershell(script string) ([]byte, error) {
+	cmd := newCommand(systemBinary(powershellFile), "-NoProfile", "-NonInteractive", "-Command", script)
+	output, err := cmd.CombinedOutput()
+	output = decodeOutput(output)
+	if isMissingBinary(err) {
+		return nil, fmt.Errorf("%w: %s", ErrBinaryNotFound, cmd.Path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return output, nil
+}
+
+//cleanupScript returns the script cleaning folder up, on the remote host
+//when set with the credentials of the current user
+func (task SchTask) cleanupScript(folder string) string {
+	host := ""
+	if task.host != "" {
+		host = powershellQuote(task.host)
+	}
+	return fmt.Sprintf(cleanupScript, host, powershellQuote(folder))
+}
+
+//cleanupAfterDelete deletes the folders emptied by deleting the owned
+//task path, the folders are only inspected once no owned task is left in
+//the folder of the task
+func (task SchTask) cleanupAfterDelete(path string) error {
+	owned, err := task.owned()
+	if err != nil {
+		return err
+	}
+	folder := strings.ToLower(ParseTaskPath(path).Folder)
+	for _, t := range owned {
+		if strings.HasPrefix(strings.ToLower(t.Path().Folder)+"\\", folder+"\\") {
+			return nil
+		}
+	}
+
+	_, err = task.CleanupEmptyFolders()
+	return err
+}
+
+//parseFolders reads the folder paths printed one per line
+func parseFolders(output []byte) []string {
+	folders := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "\\") {
+			folders = append(folders, line)
+		}
+	}
+	return folders
+}
+
+//powershellQuote quotes s as a single-quoted PowerShell string literal
+func powershellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,53 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCleanupEmptyFolders(t *testing.T) {
+	if folders, err := tasker.CleanupEmptyFolders(); err != nil || len(folders) != 0 {
+		t.Errorf("CleanupEmptyFolders() prefix = %v, %v, want none", folders, err)
+	}
+
+	script := tasker.WithFolder("\\Bob's App").cleanupScript("\\Bob's App")
+	if !strings.Contains(script, "GetFolder('\\Bob''s App')") || !strings.Contains(script, "Connect()") {
+		t.Errorf("cleanupScript() = %s", script)
+	}
+	if script := tasker.WithRemote("srv01", "").cleanupScript("\\MyApp"); !strings.Contains(script, "Connect('srv01')") {
+		t.Errorf("cleanupScript() remote = %s", script)
+	}
+
+	output := "\\MyApp\\Sub\r\n\\MyApp\r\n"
+	if folders := parseFolders([]byte(output)); !reflect.DeepEqual(folders, []string{"\\MyApp\\Sub", "\\MyApp"}) {
+		t.Errorf("parseFolders() = %v", folders)
+	}
+}
+
+func TestWithFolderCleanup(t *testing.T) {
+	remaining := summaryCSV
+	task := New(false).WithFolder("MyApp").WithFolderCleanup(true).WithBackend(
+		backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+			if args[0] == _Query.Command {
+				return []byte(remaining), nil
+			}
+			return nil, nil
+		}))
+
+	//\MyApp\Sub\Clean is left in the folder, nothing to clean up
+	if _, err := task.DeleteTask("Sub\\Other", true, true); err != nil {
+		t.Errorf("DeleteTask() = %v", err)
+	}
+
+	if supported {
+		return
+	}
+	//the last task of the folder is gone, the folders get inspected
+	remaining = `"TaskName","Next Run Time","Status"` + "\n"
+	if _, err := task.DeleteTask("Sub\\Clean", true, true); !errors.Is(err, ErrUnsupportedPlatform) {
+		t.Errorf("DeleteTask() last = %v, want the cleanup attempted", err)
+	}
+}
@@ -0,0 +1,12 @@
+package tasker
+
+//isASCII reports whether data is plain ASCII, which reads the same in
+//every codepage
+func isASCII(data []byte) bool {
+	for _, b := range data {
+		if b >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
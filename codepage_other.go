@@ -0,0 +1,14 @@
+//go:build !windows
+
+package tasker
+
+//decodeOutput transcodes console output to UTF-8, a no-op outside
+//windows
+func decodeOutput(output []byte) []byte {
+	return output
+}
+
+//consoleCodepage codepage console programs write in, 0 outside windows
+func consoleCodepage() uint32 {
+	return 0
+}
@@ -0,0 +1,54 @@
+//go:build windows
+
+package tasker
+
+import (
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+const (
+	codepageUTF8 = 65001
+)
+
+var (
+	kernel32                = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleOutputCP  = kernel32.NewProc("GetConsoleOutputCP")
+	procGetOEMCP            = kernel32.NewProc("GetOEMCP")
+	procMultiByteToWideChar = kernel32.NewProc("MultiByteToWideChar")
+)
+
+//consoleCodepage codepage console programs write in, the OEM codepage
+//when the process has no console
+func consoleCodepage() uint32 {
+	cp, _, _ := procGetConsoleOutputCP.Call()
+	if cp == 0 {
+		cp, _, _ = procGetOEMCP.Call()
+	}
+	return uint32(cp)
+}
+
+//decodeOutput transcodes console output, e.g. CP850 or CP932, to UTF-8
+func decodeOutput(output []byte) []byte {
+	cp := consoleCodepage()
+	if len(output) == 0 || cp == codepageUTF8 || isASCII(output) {
+		return output
+	}
+
+	n, _, _ := procMultiByteToWideChar.Call(uintptr(cp), 0,
+		uintptr(unsafe.Pointer(&output[0])), uintptr(len(output)), 0, 0)
+	if n == 0 {
+		return output
+	}
+
+	chars := make([]uint16, n)
+	n, _, _ = procMultiByteToWideChar.Call(uintptr(cp), 0,
+		uintptr(unsafe.Pointer(&output[0])), uintptr(len(output)),
+		uintptr(unsafe.Pointer(&chars[0])), n)
+	if n == 0 {
+		return output
+	}
+
+	return []byte(string(utf16.Decode(chars[:n])))
+}
@@ -0,0 +1,22 @@
+package tasker
+
+const (
+	//comPlaceholder stands in for /TR when the task only runs a COM
+	//handler, the Exec action is dropped again by patchDefinition
+	comPlaceholder = "cmd.exe"
+)
+
+//ComHandlerAction runs the COM component registered under ClassID, which
+//has to implement ITaskHandler. Data is passed to its Start method.
+type ComHandlerAction struct {
+	ClassID string `xml:"ClassId"`
+	Data    string `xml:"Data,omitempty"`
+}
+
+//patch writes the action into its ComHandler element
+func (handler ComHandlerAction) patch(node *xmlNode) {
+	node.set(handler.ClassID, "ClassId")
+	if handler.Data != "" {
+		node.set(handler.Data, "Data")
+	}
+}
@@ -0,0 +1,42 @@
+package tasker
+
+import "testing"
+
+func TestComHandler(t *testing.T) {
+	handler := &ComHandlerAction{ClassID: "{E4A5E1A5-8F2B-4C1A-9D0E-3C2B1A0F9E8D}", Data: "<run mode=\"full\"/>"}
+	tc := TaskCreate{Taskname: taskName, ComHandler: handler}
+	cmds := tasker.TaskMake(tc, _Create.Command, true)
+	if run := cmds[len(cmds)-1]; run != `"`+comPlaceholder+`"` {
+		t.Errorf("/TR = %s, want the placeholder", run)
+	}
+
+	root, err := parseNode([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tc.patchDefinition(root) {
+		t.Fatal("patchDefinition reported no change")
+	}
+	data, err := root.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := ParseDefinition([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(def.Actions.Exec) != 0 {
+		t.Errorf("placeholder Exec action kept: %+v", def.Actions.Exec)
+	}
+	if len(def.Actions.ComHandler) != 1 || def.Actions.ComHandler[0] != *handler {
+		t.Errorf("ComHandler = %+v, want %+v", def.Actions.ComHandler, *handler)
+	}
+
+	//with a program both actions are registered
+	tc.Taskrun = "notepad.exe"
+	root, _ = parseNode([]byte(singletonXML))
+	tc.patchDefinition(root)
+	if actions := root.child("Actions"); actions.child("Exec") == nil || actions.child("ComHandler") == nil {
+		t.Errorf("Exec or ComHandler missing: %+v", actions.Nodes)
+	}
+}
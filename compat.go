@@ -0,0 +1,35 @@
+package tasker
+
+//WithCompatibility returns a copy of the tasker for older schtasks
+//versions: no /NH nor /HRESULT switches are passed and header rows are
+//skipped while parsing instead.
+func (task SchTask) WithCompatibility(com bool) SchTask {
+	task.compatibility = com
+	return task
+}
+
+//Compatibility reports whether compatibility mode is enabled
+func (task SchTask) Compatibility() bool {
+	return task.compatibility
+}
+
+//DetectCompatibility returns a copy of the tasker with compatibility mode
+//enabled when the installed schtasks does not document the /NH switch,
+//see Capabilities. The tasker is returned unchanged when the help can't
+//be read.
+func (task SchTask) DetectCompatibility() SchTask {
+	caps := task.Capabilities()
+	if !caps.Detected {
+		return task
+	}
+	return task.WithCompatibility(!caps.NoHeader)
+}
+
+//queryArgs appends the no header switch to a /QUERY command line unless
+//in compatibility mode
+func (task SchTask) queryArgs(args ...string) []string {
+	if !task.compatibility {
+		args = append(args, _Query.noHeader)
+	}
+	return args
+}
@@ -0,0 +1,28 @@
+package tasker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithCompatibility(t *testing.T) {
+	modern := New(false)
+	legacy := modern.WithCompatibility(true)
+	if modern.Compatibility() || !legacy.Compatibility() {
+		t.Fatal("WithCompatibility must return a configured copy")
+	}
+
+	args := strings.Join(modern.queryArgs(_Query.Command), " ")
+	if !strings.Contains(args, _Query.noHeader) {
+		t.Errorf("missing /NH: %s", args)
+	}
+	args = strings.Join(legacy.withHRESULT(legacy.queryArgs(_Query.Command)), " ")
+	if strings.Contains(args, _Query.noHeader) || strings.Contains(args, hresultSwitch) {
+		t.Errorf("compatibility mode must not pass /NH nor /HRESULT: %s", args)
+	}
+
+	header := `"TaskName","Next Run Time","Status"`
+	if _, ok := legacy.parseLine(header); ok {
+		t.Error("compatibility mode must skip the header row")
+	}
+}
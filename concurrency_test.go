@@ -0,0 +1,85 @@
+package tasker
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+//TestConcurrentUse run with -race
+func TestConcurrentUse(t *testing.T) {
+	mu := sync.Mutex{}
+	registered := map[string]bool{}
+	calls := map[string]int{}
+	shared := New(false).WithCache(time.Second).WithRateLimit(time.Microsecond).WithBackend(backendFunc(
+		func(args []string, stdin io.Reader) ([]byte, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if args[1] == helpSwitch {
+				return nil, exitError(1)
+			}
+			calls[args[0]]++
+			name := argValue(args, _Create.taskname)
+			switch args[0] {
+			case _Create.Command:
+				registered[name] = true
+			case _Delete.Command:
+				delete(registered, name)
+			case _Query.Command:
+				list := ""
+				for name := range registered {
+					list += fmt.Sprintf("\"%s\",\"N/A\",\"Ready\"\n", name)
+				}
+				return []byte(list), nil
+			}
+			return nil, nil
+		}))
+
+	const workers, rounds = 16, 20
+	errs := make(chan error, workers)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("%s%d", taskName, i)
+			for j := 0; j < rounds; j++ {
+				if _, err := shared.CreateTask(TaskCreate{Taskname: name, Taskrun: executable, Schedule: Schedules.ONLOGON}); err != nil {
+					errs <- err
+					return
+				}
+				for _, task := range shared.Query(name, true) {
+					if task.Name() != "\\go-wintask-"+name {
+						errs <- fmt.Errorf("Query(%s) returned %s", name, task.Name())
+						return
+					}
+				}
+				if _, err := shared.DeleteTask(name, true, true); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if calls[_Create.Command] != workers*rounds || calls[_Delete.Command] != workers*rounds {
+		t.Errorf("backend ran %v, want %d creates and deletes", calls, workers*rounds)
+	}
+	if len(registered) != 0 {
+		t.Errorf("tasks left registered: %v", registered)
+	}
+	shared.Invalidate()
+	if tasks := shared.Query("*", true); len(tasks) != 0 {
+		t.Errorf("Query() after the deletes = %v", tasks)
+	}
+	if calls[_Query.Command] == 0 {
+		t.Errorf("queries never reached the backend: %v", calls)
+	}
+}
@@ -0,0 +1,73 @@
+package tasker
+
+import "fmt"
+
+//ConflictStrategy what CreateTask does when the task is already
+//registered
+type ConflictStrategy string
+
+var (
+	//ConflictStrategies of TaskCreate.OnConflict, the empty strategy
+	//leaves it to Force
+	ConflictStrategies = struct {
+		//FAIL fail with ErrAlreadyExists
+		FAIL ConflictStrategy
+
+		//OVERWRITE replace the registered task (/F), like Force
+		OVERWRITE ConflictStrategy
+
+		//SKIP keep the registered task as is
+		SKIP ConflictStrategy
+
+		//UPDATE replace the registered task only when its schedule,
+		//action or run level differ, see Diff
+		UPDATE ConflictStrategy
+	}{
+		FAIL:      "FAIL",
+		OVERWRITE: "OVERWRITE",
+		SKIP:      "SKIP",
+		UPDATE:    "UPDATE",
+	}
+)
+
+//resolveConflict applies the conflict strategy of taskcreate, looking the
+//task up for the strategies depending on it. skip reports whether the
+//registered task is kept.
+func (task SchTask) resolveConflict(taskcreate TaskCreate, own bool) (_ TaskCreate, skip bool, err error) {
+	strategy := taskcreate.OnConflict
+	switch strategy {
+	case "":
+		return taskcreate, false, nil
+	case ConflictStrategies.OVERWRITE:
+		taskcreate.Force = true
+		return taskcreate, false, nil
+	case ConflictStrategies.FAIL:
+		if taskcreate.Force {
+			return taskcreate, false, fmt.Errorf("%w: Force with the FAIL conflict strategy", ErrInvalidValue)
+		}
+	case ConflictStrategies.SKIP, ConflictStrategies.UPDATE:
+		taskcreate.Force = true
+	default:
+		return taskcreate, false, fmt.Errorf("%w: conflict strategy %q", ErrInvalidValue, strategy)
+	}
+	if task.debugging() {
+		return taskcreate, false, nil
+	}
+
+	exists, err := task.Exists(taskcreate.Taskname, own)
+	if err != nil || !exists {
+		return taskcreate, false, err
+	}
+
+	switch strategy {
+	case ConflictStrategies.FAIL:
+		return taskcreate, false, fmt.Errorf("%w: %s", ErrAlreadyExists, task.fullName(taskcreate.Taskname, own))
+	case ConflictStrategies.UPDATE:
+		def, err := task.GetTask(taskcreate.Taskname, own)
+		if err != nil {
+			return taskcreate, false, err
+		}
+		return taskcreate, !drifted(def, taskcreate), nil
+	}
+	return taskcreate, true, nil
+}
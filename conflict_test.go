@@ -0,0 +1,67 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestOnConflict(t *testing.T) {
+	var created [][]string
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[0] == _Query.Command && argValue(args, _Query.taskname) != "":
+			return []byte(singletonXML), nil
+		case args[0] == _Query.Command:
+			return []byte(strings.Replace(summaryCSV, "go-wintask-A", "go-wintask-Test", 1)), nil
+		case args[0] == _Create.Command && args[1] != helpSwitch:
+			created = append(created, args)
+		}
+		return nil, nil
+	}))
+	tc := TaskCreate{Taskname: taskName, Taskrun: executable, Arguments: []string{"a", "b c"}}
+
+	tc.OnConflict = ConflictStrategies.FAIL
+	if _, err := task.CreateTask(tc); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("CreateTask() FAIL = %v, want ErrAlreadyExists", err)
+	}
+	tc.OnConflict = ConflictStrategies.SKIP
+	if _, err := task.CreateTask(tc); err != nil {
+		t.Errorf("CreateTask() SKIP = %v", err)
+	}
+	tc.OnConflict = ConflictStrategies.UPDATE
+	if _, err := task.CreateTask(tc); err != nil {
+		t.Errorf("CreateTask() UPDATE = %v", err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("created %v, want the registered task kept", created)
+	}
+
+	//the action drifted
+	tc.Arguments = []string{"d"}
+	if _, err := task.CreateTask(tc); err != nil || len(created) != 1 {
+		t.Fatalf("CreateTask() UPDATE drifted = %v, created %v", err, created)
+	}
+	tc.OnConflict = ConflictStrategies.OVERWRITE
+	if _, err := task.CreateTask(tc); err != nil || len(created) != 2 {
+		t.Fatalf("CreateTask() OVERWRITE = %v, created %v", err, created)
+	}
+	for _, args := range created {
+		if !strings.Contains(" "+strings.Join(args, " ")+" ", " "+_Create.force+" ") {
+			t.Errorf("created without /F: %v", args)
+		}
+	}
+
+	//a missing task is created by every strategy
+	tc.Taskname, tc.OnConflict = "Other", ConflictStrategies.FAIL
+	if _, err := task.CreateTask(tc); err != nil || len(created) != 3 {
+		t.Errorf("CreateTask() FAIL missing = %v, created %v", err, created)
+	}
+
+	for _, tc := range []TaskCreate{{OnConflict: "MERGE"}, {OnConflict: ConflictStrategies.FAIL, Force: true}} {
+		if _, _, err := task.resolveConflict(tc, true); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("resolveConflict(%+v) = %v, want ErrInvalidValue", tc, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package tasker
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	credentialMask = "****"
+	passwordPrompt = "*"
+)
+
+//resolveCredential fills Username and Password from the Credential
+//entry of the credential provider, in debug mode the password is masked
+//instead. A Credential the provider fails to resolve is an error.
+func (task SchTask) resolveCredential(taskcreate TaskCreate) (TaskCreate, error) {
+	if task.debugging() {
+		return taskcreate.maskCredential(), nil
+	}
+
+	user, password, err := task.Credentials().Credential(task.context(), taskcreate.Credential)
+	if err != nil {
+		return taskcreate, fmt.Errorf("credential %s: %w", taskcreate.Credential, err)
+	}
+
+	if taskcreate.Username == "" {
+		taskcreate.Username = user
+	}
+	taskcreate.Password = password
+	taskcreate.Credential = ""
+	return taskcreate, nil
+}
+
+//maskCredential replaces the Credential entry by a masked password
+func (taskcreate TaskCreate) maskCredential() TaskCreate {
+	taskcreate.Password = credentialMask
+	taskcreate.Credential = ""
+	return taskcreate
+}
+
+//passwordInput returns the stdin answering the schtasks password prompt
+//when PromptPassword is set
+func (taskcreate TaskCreate) passwordInput() io.Reader {
+	if !taskcreate.PromptPassword || taskcreate.Password == "" || isServiceAccount(taskcreate.Username) {
+		return nil
+	}
+	return strings.NewReader(taskcreate.Password + "\r\n")
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package tasker
+
+//ReadCredential reads the user and password of a generic credential
+//from the Windows Credential Manager, unsupported outside windows.
+func ReadCredential(target string) (string, string, error) {
+	return "", "", ErrUnsupportedPlatform
+}
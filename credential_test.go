@@ -0,0 +1,27 @@
+package tasker
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPromptPassword(t *testing.T) {
+	tc := TaskCreate{
+		Taskname:       taskName,
+		Taskrun:        executable,
+		Username:       "runasuser",
+		Password:       "secret",
+		PromptPassword: true,
+	}
+
+	cmds := strings.Join(tasker.TaskMake(tc, _Create.Command, true), " ")
+	if strings.Contains(cmds, tc.Password) {
+		t.Errorf("password leaked to the command line: %s", cmds)
+	}
+
+	input, err := io.ReadAll(tc.passwordInput())
+	if err != nil || string(input) != "secret\r\n" {
+		t.Errorf("unexpected prompt input %q, %v", input, err)
+	}
+}
@@ -0,0 +1,76 @@
+//go:build windows
+
+package tasker
+
+import (
+	"fmt"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+const (
+	credTypeGeneric = 1
+)
+
+var (
+	advapi32     = syscall.NewLazyDLL("advapi32.dll")
+	procCredRead = advapi32.NewProc("CredReadW")
+	procCredFree = advapi32.NewProc("CredFree")
+)
+
+//credential mirrors the win32 CREDENTIALW structure
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+//ReadCredential reads the user and password of a generic credential
+//from the Windows Credential Manager, e.g. one stored with
+//	cmdkey /generic:target /user:name /pass:password
+func ReadCredential(target string) (string, string, error) {
+	name, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return "", "", err
+	}
+
+	var cred *credential
+	ret, _, err := procCredRead.Call(uintptr(unsafe.Pointer(name)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		return "", "", fmt.Errorf("credential %q: %v", target, err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	user := ""
+	if cred.UserName != nil {
+		user = utf16PtrToString(cred.UserName)
+	}
+
+	//the blob holds the password as UTF-16 without terminator
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	chars := make([]uint16, len(blob)/2)
+	for i := range chars {
+		chars[i] = uint16(blob[2*i]) | uint16(blob[2*i+1])<<8
+	}
+
+	return user, string(utf16.Decode(chars)), nil
+}
+
+//utf16PtrToString converts a NUL terminated UTF-16 string
+func utf16PtrToString(p *uint16) string {
+	chars := []uint16{}
+	for ptr := unsafe.Pointer(p); *(*uint16)(ptr) != 0; ptr = unsafe.Add(ptr, 2) {
+		chars = append(chars, *(*uint16)(ptr))
+	}
+	return string(utf16.Decode(chars))
+}
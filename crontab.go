@@ -0,0 +1,253 @@
+package tasker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	//ErrCrontab the crontab line has no scheduled task equivalent
+	ErrCrontab = errors.New("tasker: unsupported crontab entry")
+
+	//cronAliases schedules of the @ shorthands, as five cron fields
+	cronAliases = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+
+	cronDays   = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}
+	cronMonths = []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+)
+
+//ParseCrontab converts the entries of a Unix crontab into tasks based on
+//defaults. The tasks are named after defaults.Taskname ("cron" when
+//empty) and the entry number, e.g. cron-1, and run the command through
+//cmd.exe /C. Environment assignments are skipped. Schedules without an
+//equivalent, like several hours a day, fail with ErrCrontab.
+func ParseCrontab(r io.Reader, defaults TaskCreate) ([]TaskCreate, error) {
+	base := defaults.Taskname
+	if base == "" {
+		base = "cron"
+	}
+
+	tasks := []TaskCreate{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") || isCronVariable(entry) {
+			continue
+		}
+
+		taskcreate := defaults
+		taskcreate.Taskname = base + "-" + strconv.Itoa(len(tasks)+1)
+		if err := taskcreate.parseCronEntry(entry); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrCrontab, line, err)
+		}
+		tasks = append(tasks, taskcreate)
+	}
+
+	return tasks, scanner.Err()
+}
+
+//ImportCrontab creates the tasks of a Unix crontab, see ParseCrontab. The
+//tasks created before a failure are returned with the error.
+func (task SchTask) ImportCrontab(r io.Reader, defaults TaskCreate) ([]TaskCreate, error) {
+	tasks, err := ParseCrontab(r, defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, taskcreate := range tasks {
+		if output, err := task.CreateTask(taskcreate); err != nil {
+			return tasks[:i], fmt.Errorf("%s: %w: %s", taskcreate.Taskname, err, strings.TrimSpace(output))
+		}
+	}
+	return tasks, nil
+}
+
+//isCronVariable reports whether the crontab line assigns a variable
+func isCronVariable(entry string) bool {
+	i := strings.Index(entry, "=")
+	return i > 0 && !strings.ContainsAny(entry[:i], " \t*@")
+}
+
+//parseCronEntry sets the schedule and action of a crontab entry
+func (taskcreate *TaskCreate) parseCronEntry(entry string) error {
+	var fields []string
+	if strings.HasPrefix(entry, "@") {
+		alias, command, _ := strings.Cut(entry, " ")
+		if alias == "@reboot" {
+			taskcreate.Schedule = Schedules.ONSTART
+			return taskcreate.setCronCommand(command)
+		}
+		schedule, ok := cronAliases[alias]
+		if !ok {
+			return fmt.Errorf("unknown alias %s", alias)
+		}
+		fields = append(strings.Fields(schedule), command)
+	} else {
+		rest := entry
+		for i := 0; i < 5; i++ {
+			rest = strings.TrimLeft(rest, " \t")
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				return fmt.Errorf("expected five time fields and a command")
+			}
+			fields = append(fields, rest[:end])
+			rest = rest[end:]
+		}
+		fields = append(fields, rest)
+	}
+
+	if err := taskcreate.setCronSchedule(fields[0], fields[1], fields[2], fields[3], fields[4]); err != nil {
+		return err
+	}
+	return taskcreate.setCronCommand(fields[5])
+}
+
+//setCronCommand runs the crontab command through the command processor
+func (taskcreate *TaskCreate) setCronCommand(command string) error {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return fmt.Errorf("missing command")
+	}
+	taskcreate.Taskrun = "cmd.exe"
+	taskcreate.rawArguments = "/C " + command
+	return nil
+}
+
+//setCronSchedule maps the cron time fields to a schedule
+func (taskcreate *TaskCreate) setCronSchedule(minute, hour, dom, month, dow string) error {
+	//every N minutes
+	if step, ok := cronStep(minute); ok && hour == "*" && dom == "*" && month == "*" && dow == "*" {
+		taskcreate.Schedule = Schedules.MINUTE
+		taskcreate.Modifier = strconv.Itoa(step)
+		return nil
+	}
+
+	m, err := cronNumber(minute, 0, 59)
+	if err != nil {
+		return err
+	}
+
+	//every N hours at minute m
+	if step, ok := cronStep(hour); ok && dom == "*" && month == "*" && dow == "*" {
+		taskcreate.Schedule = Schedules.HOURLY
+		taskcreate.Modifier = strconv.Itoa(step)
+		taskcreate.Starttime = fmt.Sprintf("00:%02d", m)
+		return nil
+	}
+
+	h, err := cronNumber(hour, 0, 23)
+	if err != nil {
+		return err
+	}
+	taskcreate.Starttime = fmt.Sprintf("%02d:%02d", h, m)
+
+	switch {
+	case dom == "*" && month == "*" && dow == "*":
+		taskcreate.Schedule = Schedules.DAILY
+	case dom == "*" && month == "*":
+		days, err := cronList(dow, 0, 7, cronDays)
+		if err != nil {
+			return err
+		}
+		taskcreate.Schedule = Schedules.WEEKLY
+		taskcreate.Days = days
+	case dow == "*":
+		days, err := cronList(dom, 1, 31, nil)
+		if err != nil {
+			return err
+		}
+		taskcreate.Schedule = Schedules.MONTHLY
+		taskcreate.Days = days
+		if month != "*" {
+			months, err := cronList(month, 1, 12, cronMonths)
+			if err != nil {
+				return err
+			}
+			taskcreate.Months = months
+		}
+	default:
+		return fmt.Errorf("day of month and day of week combined")
+	}
+	return nil
+}
+
+//cronStep parses "*" or "*/N" as a step of N
+func cronStep(field string) (int, bool) {
+	if field == "*" {
+		return 1, true
+	}
+	if !strings.HasPrefix(field, "*/") {
+		return 0, false
+	}
+	step, err := strconv.Atoi(field[2:])
+	return step, err == nil && step > 0
+}
+
+//cronNumber parses a single value between min and max
+func cronNumber(field string, min, max int) (int, error) {
+	n, err := strconv.Atoi(field)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%q is not a single value between %d and %d", field, min, max)
+	}
+	return n, nil
+}
+
+//cronList expands a list of values and ranges, e.g. 1-5,7, mapped to
+//names when given. Names given in the crontab are accepted as well.
+func cronList(field string, min, max int, names []string) ([]string, error) {
+	values := []string{}
+	seen := map[string]bool{}
+	add := func(value string) {
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		from, err := cronValue(first, min, max, names)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if isRange {
+			if to, err = cronValue(last, min, max, names); err != nil {
+				return nil, err
+			}
+		}
+		if to < from {
+			return nil, fmt.Errorf("%q is a descending range", part)
+		}
+		for n := from; n <= to; n++ {
+			if names != nil {
+				add(names[n])
+			} else {
+				add(strconv.Itoa(n))
+			}
+		}
+	}
+	return values, nil
+}
+
+//cronValue parses a number or a three letter name
+func cronValue(value string, min, max int, names []string) (int, error) {
+	for i, name := range names {
+		if name != "" && strings.EqualFold(value, name) {
+			return i, nil
+		}
+	}
+	return cronNumber(value, min, max)
+}
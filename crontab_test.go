@@ -0,0 +1,57 @@
+package tasker
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const crontab = `# m h dom mon dow command
+MAILTO=ops@example.com
+*/15 * * * * C:\app\poll.exe --quiet
+30 */2 * * *	C:\app\sync.exe
+0 2 * * * backup.cmd > C:\logs\backup.log 2>&1
+0 8 * * 1-5 report.exe
+0 0 1,15 jan-mar * invoice.exe
+@reboot start.cmd
+@weekly cleanup.exe
+`
+
+func TestParseCrontab(t *testing.T) {
+	tasks, err := ParseCrontab(strings.NewReader(crontab), TaskCreate{Taskname: "job", Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []TaskCreate{
+		{Schedule: Schedules.MINUTE, Modifier: "15"},
+		{Schedule: Schedules.HOURLY, Modifier: "2", Starttime: "00:30"},
+		{Schedule: Schedules.DAILY, Starttime: "02:00"},
+		{Schedule: Schedules.WEEKLY, Starttime: "08:00", Days: []string{"MON", "TUE", "WED", "THU", "FRI"}},
+		{Schedule: Schedules.MONTHLY, Starttime: "00:00", Days: []string{"1", "15"}, Months: []string{"JAN", "FEB", "MAR"}},
+		{Schedule: Schedules.ONSTART},
+		{Schedule: Schedules.WEEKLY, Starttime: "00:00", Days: []string{"SUN"}},
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("ParseCrontab() = %d tasks, want %d", len(tasks), len(want))
+	}
+	for i, tc := range tasks {
+		got := TaskCreate{Schedule: tc.Schedule, Modifier: tc.Modifier, Starttime: tc.Starttime, Days: tc.Days, Months: tc.Months}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("entry %d = %+v, want %+v", i+1, got, want[i])
+		}
+		if !tc.Force || tc.Taskrun != "cmd.exe" {
+			t.Errorf("entry %d lost the defaults or action: %+v", i+1, tc)
+		}
+	}
+	if tasks[0].Taskname != "job-1" || tasks[2].rawArguments != `/C backup.cmd > C:\logs\backup.log 2>&1` {
+		t.Errorf("unexpected name or command %q, %q", tasks[0].Taskname, tasks[2].rawArguments)
+	}
+
+	for _, entry := range []string{"0 9,17 * * * x.exe", "0 0 1 * 1 x.exe", "@often x.exe", "* * * *", "0 0 5-1 * * x.exe"} {
+		if _, err := ParseCrontab(strings.NewReader(entry), TaskCreate{}); !errors.Is(err, ErrCrontab) {
+			t.Errorf("ParseCrontab(%q) = %v, want ErrCrontab", entry, err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package tasker
+
+import "reflect"
+
+//credentialFields fields defaulted together, a task naming its own user
+//mustn't get the password of the default one
+var credentialFields = map[string]bool{
+	"Username": true, "Password": true, "Credential": true, "PromptPassword": true, "NoPassword": true,
+}
+
+//WithDefaults returns a copy of the tasker filling the fields a
+//TaskCreate leaves unset with those of defaults, e.g. the Level,
+//Username or StartWhenAvailable shared by all the tasks of an
+//application. The account fields are only filled when the task sets
+//none of them, boolean fields can only default to true and Taskname is
+//never filled. Defaults apply to the tasks created, not to changes; the
+//default folder is set with WithFolder.
+func (task SchTask) WithDefaults(defaults TaskCreate) SchTask {
+	task.defaults = &defaults
+	return task
+}
+
+//withDefaults fills the unset fields of taskcreate, see WithDefaults
+func (task SchTask) withDefaults(taskcreate TaskCreate) TaskCreate {
+	if task.defaults == nil {
+		return taskcreate
+	}
+
+	value := reflect.ValueOf(&taskcreate).Elem()
+	defaults := reflect.ValueOf(*task.defaults)
+	fields := value.Type()
+	ownAccount := false
+	for i := 0; i < value.NumField(); i++ {
+		if credentialFields[fields.Field(i).Name] && !value.Field(i).IsZero() {
+			ownAccount = true
+		}
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field, name := value.Field(i), fields.Field(i).Name
+		if !field.CanSet() || !field.IsZero() || name == "Taskname" || ownAccount && credentialFields[name] {
+			continue
+		}
+		field.Set(defaults.Field(i))
+	}
+	return taskcreate
+}
@@ -0,0 +1,40 @@
+package tasker
+
+import "testing"
+
+func TestWithDefaults(t *testing.T) {
+	available := true
+	task := tasker.WithDefaults(TaskCreate{Taskname: "ignored", Level: Level.HIGHEST, Username: "svc-app", Password: "x",
+		StartWhenAvailable: &available, Tags: []string{"App"}})
+
+	tc := task.withDefaults(TaskCreate{Taskname: taskName, Taskrun: executable, Tags: []string{"Other"}})
+	if tc.Taskname != taskName || tc.Level != Level.HIGHEST || tc.Username != "svc-app" || tc.Password != "x" ||
+		tc.StartWhenAvailable != &available || len(tc.Tags) != 1 || tc.Tags[0] != "Other" {
+		t.Errorf("withDefaults() = %+v", tc)
+	}
+
+	//a task with its own account keeps it whole
+	tc = task.withDefaults(TaskCreate{Taskname: taskName, Username: Accounts.SYSTEM})
+	if tc.Username != Accounts.SYSTEM || tc.Password != "" || tc.Level != Level.HIGHEST {
+		t.Errorf("withDefaults() own account = %+v", tc)
+	}
+
+	if tc := tasker.withDefaults(TaskCreate{Taskname: taskName}); tc.Level != "" {
+		t.Errorf("withDefaults() without defaults = %+v", tc)
+	}
+}
+
+func TestPatchStartWhenAvailable(t *testing.T) {
+	available := true
+	tc := TaskCreate{Taskname: taskName, StartWhenAvailable: &available}
+	if !tc.needsPatch() {
+		t.Fatal("needsPatch is false with StartWhenAvailable")
+	}
+	root, err := parseNode([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tc.patchDefinition(root) || root.get("Settings", "StartWhenAvailable") != "true" {
+		t.Errorf("Settings = %+v", root.child("Settings"))
+	}
+}
@@ -0,0 +1,187 @@
+package tasker
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+//TaskDefinition registered task definition as described by the
+//Task Scheduler XML schema, see /QUERY /TN name /XML
+type TaskDefinition struct {
+	XMLName          xml.Name         `xml:"http://schemas.microsoft.com/windows/2004/02/mit/task Task"`
+	Version          string           `xml:"version,attr,omitempty"`
+	RegistrationInfo RegistrationInfo `xml:"RegistrationInfo"`
+	Triggers         Triggers         `xml:"Triggers"`
+	Principals       []Principal      `xml:"Principals>Principal"`
+	Settings         Settings         `xml:"Settings"`
+	Actions          Actions          `xml:"Actions"`
+}
+
+//RegistrationInfo general task information
+type RegistrationInfo struct {
+	Date        string `xml:"Date,omitempty"`
+	Author      string `xml:"Author,omitempty"`
+	Description string `xml:"Description,omitempty"`
+	URI         string `xml:"URI,omitempty"`
+}
+
+//Triggers list of task triggers, the element name of each trigger
+//holds its kind e.g. TimeTrigger, CalendarTrigger, BootTrigger.
+type Triggers struct {
+	Items []Trigger `xml:",any"`
+}
+
+//Trigger a single task trigger
+type Trigger struct {
+	XMLName            xml.Name
+	ID                 string      `xml:"id,attr,omitempty"`
+	Enabled            string      `xml:"Enabled,omitempty"`
+	StartBoundary      string      `xml:"StartBoundary,omitempty"`
+	EndBoundary        string      `xml:"EndBoundary,omitempty"`
+	ExecutionTimeLimit string      `xml:"ExecutionTimeLimit,omitempty"`
+	Repetition         *Repetition `xml:"Repetition"`
+	Delay              string      `xml:"Delay,omitempty"`
+	RandomDelay        string      `xml:"RandomDelay,omitempty"`
+	Subscription       string      `xml:"Subscription,omitempty"`
+	UserID             string      `xml:"UserId,omitempty"`
+	ScheduleByDay      *struct {
+		DaysInterval int `xml:"DaysInterval"`
+	} `xml:"ScheduleByDay"`
+	ScheduleByWeek *struct {
+		WeeksInterval int      `xml:"WeeksInterval,omitempty"`
+		DaysOfWeek    Elements `xml:"DaysOfWeek"`
+	} `xml:"ScheduleByWeek"`
+	ScheduleByMonth *struct {
+		DaysOfMonth struct {
+			Day []string `xml:"Day"`
+		} `xml:"DaysOfMonth"`
+		Months Elements `xml:"Months"`
+	} `xml:"ScheduleByMonth"`
+	ScheduleByMonthDayOfWeek *struct {
+		Weeks struct {
+			Week []string `xml:"Week"`
+		} `xml:"Weeks"`
+		DaysOfWeek Elements `xml:"DaysOfWeek"`
+		Months     Elements `xml:"Months"`
+	} `xml:"ScheduleByMonthDayOfWeek"`
+}
+
+//Kind trigger kind, e.g. TimeTrigger
+func (t Trigger) Kind() string {
+	return t.XMLName.Local
+}
+
+//Repetition trigger repetition pattern
+type Repetition struct {
+	Interval          string `xml:"Interval,omitempty"`
+	Duration          string `xml:"Duration,omitempty"`
+	StopAtDurationEnd bool   `xml:"StopAtDurationEnd,omitempty"`
+}
+
+//Elements list of empty elements, e.g. <DaysOfWeek><Monday /></DaysOfWeek>
+type Elements struct {
+	Items []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+//Names returns the element names of the list
+func (e Elements) Names() []string {
+	names := []string{}
+	for _, item := range e.Items {
+		names = append(names, item.XMLName.Local)
+	}
+	return names
+}
+
+//Principal security context the task runs under
+type Principal struct {
+	ID        string `xml:"id,attr,omitempty"`
+	UserID    string `xml:"UserId,omitempty"`
+	GroupID   string `xml:"GroupId,omitempty"`
+	LogonType string `xml:"LogonType,omitempty"`
+	RunLevel  string `xml:"RunLevel,omitempty"`
+}
+
+//Settings task settings
+type Settings struct {
+	MultipleInstancesPolicy    string `xml:"MultipleInstancesPolicy,omitempty"`
+	DisallowStartIfOnBatteries string `xml:"DisallowStartIfOnBatteries,omitempty"`
+	StopIfGoingOnBatteries     string `xml:"StopIfGoingOnBatteries,omitempty"`
+	StartWhenAvailable         string `xml:"StartWhenAvailable,omitempty"`
+	Enabled                    string `xml:"Enabled,omitempty"`
+	Hidden                     string `xml:"Hidden,omitempty"`
+	ExecutionTimeLimit         string `xml:"ExecutionTimeLimit,omitempty"`
+	DeleteExpiredTaskAfter     string `xml:"DeleteExpiredTaskAfter,omitempty"`
+	Priority                   string `xml:"Priority,omitempty"`
+	AllowHardTerminate         string `xml:"AllowHardTerminate,omitempty"`
+	StopOnIdleEnd              string `xml:"IdleSettings>StopOnIdleEnd,omitempty"`
+
+	MaintenanceSettings *MaintenanceDefinition `xml:"MaintenanceSettings"`
+}
+
+//Actions list of task actions
+type Actions struct {
+	Context     string             `xml:"Context,attr,omitempty"`
+	Exec        []ExecAction       `xml:"Exec"`
+	ComHandler  []ComHandlerAction `xml:"ComHandler"`
+	SendEmail   []EmailAction      `xml:"SendEmail"`
+	ShowMessage []MessageAction    `xml:"ShowMessage"`
+}
+
+//ExecAction program started by the task
+type ExecAction struct {
+	Command          string `xml:"Command"`
+	Arguments        string `xml:"Arguments,omitempty"`
+	WorkingDirectory string `xml:"WorkingDirectory,omitempty"`
+}
+
+//Principal returns the first principal of the definition
+func (def TaskDefinition) Principal() Principal {
+	if len(def.Principals) > 0 {
+		return def.Principals[0]
+	}
+	return Principal{}
+}
+
+//ParseDefinition parses a task XML document
+func ParseDefinition(data []byte) (TaskDefinition, error) {
+	def := TaskDefinition{}
+
+	//schtasks declares UTF-16 but the output was decoded to UTF-8
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	err := decoder.Decode(&def)
+	return def, err
+}
+
+//ExportXML returns the XML definition of a registered task
+func (task SchTask) ExportXML(name string, own bool) (string, error) {
+	name, err := task.resolveName(name, own)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := task.execute(_Query.Command, _Query.taskname, name, _Query.xml)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+//GetTask returns the parsed definition of a registered task
+func (task SchTask) GetTask(name string, own bool) (TaskDefinition, error) {
+	output, err := task.ExportXML(name, own)
+	if err != nil {
+		return TaskDefinition{}, err
+	}
+
+	return ParseDefinition([]byte(output))
+}
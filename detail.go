@@ -0,0 +1,186 @@
+package tasker
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"time"
+)
+
+//TaskDetail verbose task information as reported by /QUERY /V
+type TaskDetail struct {
+	Host         string
+	Name         string
+	NextRunTime  time.Time
+	Status       Status
+	LogonMode    string
+	LastRunTime  time.Time
+	LastResult   int
+	Author       string
+	TaskToRun    string
+	StartIn      string
+	Comment      string
+	State        string
+	RunAsUser    string
+	ScheduleType string
+	StartTime    string
+	StartDate    string
+	EndDate      string
+	Days         string
+	Months       string
+	RepeatEvery  string
+
+	//URI and ID identify the task across renames and moves, only filled
+	//with WithIdentity, see TaskIdentity
+	URI string
+	ID  string
+}
+
+//verbose columns, their order is the same on every display language
+const (
+	colHost = iota
+	colName
+	colNextRunTime
+	colStatus
+	colLogonMode
+	colLastRunTime
+	colLastResult
+	colAuthor
+	colTaskToRun
+	colStartIn
+	colComment
+	colState
+	colIdleTime
+	colPowerManagement
+	colRunAsUser
+	colDeleteIfNotRescheduled
+	colStopAfter
+	colSchedule
+	colScheduleType
+	colStartTime
+	colStartDate
+	colEndDate
+	colDays
+	colMonths
+	colRepeatEvery
+	colCount
+)
+
+var (
+	//timeLayouts date formats schtasks prints depending on the locale
+	timeLayouts = []string{
+		"1/2/2006 3:04:05 PM",
+		"2006-01-02 15:04:05",
+		"2006/01/02 15:04:05",
+		"02.01.2006 15:04:05",
+		"02/01/2006 15:04:05",
+		"1/2/2006 15:04:05",
+	}
+)
+
+//QueryDetail returns the verbose information of the tasks matching name,
+//see Query for the matching rules.
+func (task SchTask) QueryDetail(name string, own bool, opts ...CallOption) ([]TaskDetail, error) {
+	task = task.with(opts)
+	filter := Filter{name, own}
+	args := task.queryArgs(_Query.Command, _Query.format, _Query.formatCSV, _Query.verbose)
+	switch {
+	case filter.exact():
+		path, err := task.resolveName(name, own)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, _Query.taskname, path)
+	case own && task.folder() != "":
+		args = append(args, _Query.taskname, task.folder()+"\\")
+	}
+
+	output, err := task.execute(args...)
+	if err != nil {
+		if filter.exact() && isNotFound(err) {
+			return []TaskDetail{}, nil
+		}
+		return nil, err
+	}
+
+	details := []TaskDetail{}
+	for _, detail := range task.parseDetail(output) {
+		if task.match(filter, detail.Name) {
+			details = append(details, detail)
+		}
+	}
+
+	if task.identity {
+		if err := task.identify(details); err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+//parseDetail parses the CSV output of /QUERY /V
+func (task SchTask) parseDetail(output []byte) []TaskDetail {
+	reader := csv.NewReader(bytes.NewReader(output))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	details := []TaskDetail{}
+	header := ""
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			break
+		}
+		if len(record) < colCount {
+			continue
+		}
+
+		//headers are repeated for every folder
+		if task.compatibility {
+			if header == "" {
+				header = record[colName]
+				continue
+			}
+			if record[colName] == header {
+				continue
+			}
+		}
+
+		details = append(details, TaskDetail{
+			Host:         record[colHost],
+			Name:         record[colName],
+			NextRunTime:  parseTime(record[colNextRunTime]),
+			Status:       ParseStatus(record[colStatus]),
+			LogonMode:    record[colLogonMode],
+			LastRunTime:  parseTime(record[colLastRunTime]),
+			LastResult:   parseCode(record[colLastResult]),
+			Author:       record[colAuthor],
+			TaskToRun:    record[colTaskToRun],
+			StartIn:      record[colStartIn],
+			Comment:      record[colComment],
+			State:        record[colState],
+			RunAsUser:    record[colRunAsUser],
+			ScheduleType: record[colScheduleType],
+			StartTime:    record[colStartTime],
+			StartDate:    record[colStartDate],
+			EndDate:      record[colEndDate],
+			Days:         record[colDays],
+			Months:       record[colMonths],
+			RepeatEvery:  record[colRepeatEvery],
+		})
+	}
+
+	return details
+}
+
+//parseTime parses a schtasks date, N/A and unknown formats give the
+//zero time
+func parseTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
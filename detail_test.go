@@ -0,0 +1,33 @@
+package tasker
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const detailCSV = `"HOST","\go-wintask-Test","N/A","Ready","Interactive only","10/15/2026 2:30:00 PM","267011","HOST\user","notepad.exe","N/A","N/A","Enabled","Disabled","Stop On Battery Mode","user","Disabled","72:00:00","Scheduling data is not available in this format.","Daily ","2:30:00 PM","10/15/2026","N/A","Every 1 day(s)","N/A","Disabled","Disabled","Disabled","Disabled"
+`
+
+func TestParseDetail(t *testing.T) {
+	details := New(false).parseDetail([]byte(detailCSV))
+	if len(details) != 1 {
+		t.Fatalf("expected one task, got %d", len(details))
+	}
+
+	d := details[0]
+	if d.Name != "\\go-wintask-Test" || d.LastResult != 267011 || d.TaskToRun != executable {
+		t.Errorf("unexpected detail %+v", d)
+	}
+	if !d.NextRunTime.IsZero() || d.LastRunTime.Hour() != 14 {
+		t.Errorf("unexpected times %v, %v", d.NextRunTime, d.LastRunTime)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"nextRunTime":null`) || !strings.Contains(string(data), `"lastRunTime":"2026-10-15T14:30:00`) {
+		t.Errorf("unexpected json %s", data)
+	}
+}
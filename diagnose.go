@@ -0,0 +1,126 @@
+package tasker
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//DiagnosticCheck single check of Diagnose
+type DiagnosticCheck struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+//Diagnosis report of Diagnose
+type Diagnosis struct {
+	Checks []DiagnosticCheck
+}
+
+//OK reports whether every check passed
+func (diagnosis Diagnosis) OK() bool {
+	for _, check := range diagnosis.Checks {
+		if !check.OK {
+			return false
+		}
+	}
+	return true
+}
+
+//String renders the report one check per line, for support requests
+func (diagnosis Diagnosis) String() string {
+	var b strings.Builder
+	for _, check := range diagnosis.Checks {
+		result := "ok"
+		if !check.OK {
+			result = "FAILED"
+		}
+		fmt.Fprintf(&b, "%-10s %-6s %s\n", check.Name, result, check.Detail)
+	}
+	return b.String()
+}
+
+//Diagnose checks the environment the tasker depends on: the schtasks
+//binary, the Task Scheduler service, the privileges and codepage of the
+//process, and registers, queries and deletes a throwaway hidden task.
+//Meant for support when the library misbehaves on a given machine.
+func (task SchTask) Diagnose() Diagnosis {
+	diagnosis := Diagnosis{Checks: []DiagnosticCheck{}}
+	add := func(name string, err error, detail string) bool {
+		check := DiagnosticCheck{Name: name, OK: err == nil, Detail: detail}
+		if err != nil {
+			check.Detail = err.Error()
+		}
+		diagnosis.Checks = append(diagnosis.Checks, check)
+		return err == nil
+	}
+
+	if task.backend != nil {
+		add("binary", nil, "replaced by a backend")
+	} else {
+		_, err := os.Stat(task.bin)
+		add("binary", err, task.bin)
+	}
+
+	state, err := ServiceStatus()
+	if err == nil && state != ServiceRunning {
+		err = fmt.Errorf("%w: %s", ErrServiceNotRunning, state)
+	}
+	add("service", err, state.String())
+
+	add("privileges", nil, fmt.Sprintf("administrator %t, elevated %t", IsAdmin(), IsElevated()))
+
+	caps := task.Capabilities()
+	add("locale", nil, fmt.Sprintf("codepage %d, OS %q, capabilities detected %t, /HRESULT %t",
+		consoleCodepage(), caps.OSVersion, caps.Detected, caps.HRESULT))
+
+	if task.debugging() {
+		add("roundtrip", nil, "skipped in debug mode")
+		return diagnosis
+	}
+	task.roundtrip(add)
+	return diagnosis
+}
+
+//roundtrip registers, queries and deletes a throwaway hidden task,
+//reporting each step
+func (task SchTask) roundtrip(add func(name string, err error, detail string) bool) {
+	name := "diagnose-" + strconv.FormatInt(rand.Int63(), 36)
+	taskcreate := TaskCreate{
+		Taskname:  name,
+		Taskrun:   systemBinary("cmd.exe"),
+		Arguments: []string{"/c", "exit"},
+		Schedule:  Schedules.ONCE,
+		//through the task XML, /SD is parsed in the short date format of
+		//the locale
+		StartBoundary: time.Date(2099, 12, 31, 23, 59, 0, 0, time.Local),
+		Force:         true,
+	}
+
+	output, err := task.createTask(taskcreate, true)
+	if !add("create", err, strings.TrimSpace(output)) {
+		return
+	}
+	defer func() {
+		_, err := task.DeleteTask(name, true, true)
+		add("delete", err, task.fullName(name, true))
+	}()
+
+	_, err = task.updateDefinition(taskcreate, true, func(root *xmlNode) bool {
+		root.ensure("Settings", "Hidden").Text = "true"
+		return true
+	})
+	if !add("hide", err, "") {
+		return
+	}
+
+	exists, err := task.Exists(name, true)
+	if err == nil && !exists {
+		err = fmt.Errorf("%w: %s", ErrNotFound, task.fullName(name, true))
+	}
+	add("query", err, "")
+}
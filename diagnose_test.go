@@ -0,0 +1,51 @@
+package tasker
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	registered := ""
+	deleted := false
+	dated := false
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		dated = dated || argValue(args, _Create.startdate) != ""
+		switch {
+		case args[0] == _Create.Command && args[1] != helpSwitch && registered == "":
+			registered = argValue(args, _Create.taskname)
+		case args[0] == _Delete.Command:
+			deleted = argValue(args, _Delete.taskname) == registered
+		case args[0] == _Query.Command && argValue(args, _Query.taskname) != "":
+			return []byte(singletonXML), nil
+		case args[0] == _Query.Command:
+			return []byte("\"" + registered + "\",\"N/A\",\"Ready\"\r\n"), nil
+		}
+		return nil, nil
+	}))
+
+	diagnosis := task.Diagnose()
+	names := []string{}
+	for _, check := range diagnosis.Checks {
+		names = append(names, check.Name)
+		switch check.Name {
+		case "binary", "create", "hide", "query", "delete":
+			if !check.OK {
+				t.Errorf("check %s failed: %s", check.Name, check.Detail)
+			}
+		}
+	}
+	if got := strings.Join(names, ","); got != "binary,service,privileges,locale,create,hide,query,delete" {
+		t.Errorf("Diagnose() checks %s", got)
+	}
+	if !strings.HasPrefix(registered, "\\go-wintask-diagnose-") || !deleted {
+		t.Errorf("the throwaway task %q was not deleted", registered)
+	}
+	if dated {
+		t.Error("the throwaway task passed a locale dependent /SD")
+	}
+	if !supported && (diagnosis.OK() || !strings.Contains(diagnosis.String(), "service    FAILED")) {
+		t.Errorf("Diagnose() must report the unavailable service:\n%s", diagnosis)
+	}
+}
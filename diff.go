@@ -0,0 +1,80 @@
+package tasker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+//String renders the difference as Field: "old" -> "new"
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %q -> %q", d.Field, d.Old, d.New)
+}
+
+//Diff lists the fields of the triggers, actions, settings, principals and
+//registration info differing between two definitions, sorted by field
+//path e.g. "Triggers[0].StartBoundary". Old holds the value of a.
+func Diff(a, b TaskDefinition) []Difference {
+	fa, fb := map[string]string{}, map[string]string{}
+	flatten(reflect.ValueOf(a), "", fa)
+	flatten(reflect.ValueOf(b), "", fb)
+
+	fields := []string{}
+	for field := range fa {
+		fields = append(fields, field)
+	}
+	for field := range fb {
+		if _, ok := fa[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	diffs := []Difference{}
+	for _, field := range fields {
+		if fa[field] != fb[field] {
+			diffs = append(diffs, Difference{field, fa[field], fb[field]})
+		}
+	}
+	return diffs
+}
+
+//flatten collects the non empty leaf values of v by field path, element
+//names of xml.Name fields are recorded as Kind
+func flatten(v reflect.Value, prefix string, fields map[string]string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			flatten(v.Elem(), prefix, fields)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if name, ok := v.Field(i).Interface().(xml.Name); ok {
+				if prefix != "" && name.Local != "" {
+					fields[prefix+"Kind"] = name.Local
+				}
+				continue
+			}
+			path := prefix + field.Name
+			//lists wrapped in an Items field are addressed directly
+			if field.Name == "Items" {
+				path = prefix[:len(prefix)-1]
+			}
+			flatten(v.Field(i), path+".", fields)
+		}
+	case reflect.Slice, reflect.Array:
+		base := prefix[:len(prefix)-1]
+		for i := 0; i < v.Len(); i++ {
+			flatten(v.Index(i), fmt.Sprintf("%s[%d].", base, i), fields)
+		}
+	default:
+		if value := fmt.Sprint(v.Interface()); value != "" && !v.IsZero() {
+			fields[prefix[:len(prefix)-1]] = value
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package tasker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a, err := ParseDefinition([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ParseDefinition([]byte(strings.Replace(singletonXML, "LeastPrivilege", "HighestAvailable", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diffs := Diff(a, a); len(diffs) != 0 {
+		t.Errorf("expected no differences, got %v", diffs)
+	}
+
+	b.Triggers.Items = append(b.Triggers.Items, Trigger{})
+	b.Triggers.Items[0].XMLName.Local = "BootTrigger"
+	diffs := Diff(a, b)
+	want := []string{
+		`Principals[0].RunLevel: "LeastPrivilege" -> "HighestAvailable"`,
+		`Triggers[0].Kind: "" -> "BootTrigger"`,
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("got %v, want %v", diffs, want)
+	}
+	for i := range want {
+		if diffs[i].String() != want[i] {
+			t.Errorf("got %s, want %s", diffs[i], want[i])
+		}
+	}
+}
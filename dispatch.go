@@ -0,0 +1,101 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	//DispatchFlag command line flag routing a run of the executable to a
+	//handler, e.g. --wintask-run=cleanup
+	DispatchFlag = "--wintask-run"
+)
+
+var (
+	//ErrUnknownHandler no handler is registered under the name
+	ErrUnknownHandler = errors.New("tasker: unknown handler")
+
+	handlersMu sync.RWMutex
+	handlers   = map[string]HandlerFunc{}
+)
+
+//HandlerFunc function run by a scheduled task, args are the command line
+//arguments following the dispatch flag
+type HandlerFunc func(args []string) error
+
+//Handle registers a handler under name, see RegisterHandler and Dispatch.
+//Handlers are usually registered from init or at the start of main.
+func Handle(name string, handler HandlerFunc) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	handlers[name] = handler
+}
+
+//handler returns the handler registered under name
+func handler(name string) (HandlerFunc, bool) {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+	h, ok := handlers[name]
+	return h, ok
+}
+
+//RegisterHandler creates an owned task running the handler registered
+//under name on the schedule of taskcreate, by starting the current
+//executable with the dispatch flag. Taskname defaults to name, Taskrun
+//is ignored and Arguments are passed on to the handler.
+//	tasker.Handle("cleanup", cleanup)
+//	task.RegisterHandler("cleanup", tasker.TaskCreate{Schedule: tasker.Schedules.DAILY, Starttime: "03:00"})
+func (task SchTask) RegisterHandler(name string, taskcreate TaskCreate) (string, error) {
+	if _, ok := handler(name); !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownHandler, name)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	if taskcreate.Taskname == "" {
+		taskcreate.Taskname = name
+	}
+	taskcreate.Taskrun = exe
+	taskcreate.Arguments = append([]string{DispatchFlag + "=" + name}, taskcreate.Arguments...)
+	return task.CreateTask(taskcreate)
+}
+
+//Dispatch runs the handler selected by the dispatch flag of the process
+//command line, reporting false when the flag is absent so main continues
+//normally:
+//	if handled, err := tasker.Dispatch(); handled {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		return
+//	}
+func Dispatch() (bool, error) {
+	return DispatchArgs(os.Args[1:])
+}
+
+//DispatchArgs is Dispatch for the given command line arguments
+func DispatchArgs(args []string) (bool, error) {
+	for i, arg := range args {
+		name, ok := strings.CutPrefix(arg, DispatchFlag+"=")
+		if !ok {
+			if arg != DispatchFlag || i+1 >= len(args) {
+				continue
+			}
+			name, i = args[i+1], i+1
+		}
+
+		h, ok := handler(name)
+		if !ok {
+			return true, fmt.Errorf("%w: %s", ErrUnknownHandler, name)
+		}
+		return true, h(args[i+1:])
+	}
+
+	return false, nil
+}
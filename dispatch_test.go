@@ -0,0 +1,53 @@
+package tasker
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDispatch(t *testing.T) {
+	var got []string
+	Handle("cleanup", func(args []string) error {
+		got = args
+		return nil
+	})
+
+	if handled, err := DispatchArgs([]string{"-v"}); handled || err != nil {
+		t.Errorf("DispatchArgs() without flag = %v, %v", handled, err)
+	}
+
+	handled, err := DispatchArgs([]string{"-v", DispatchFlag + "=cleanup", "--days", "7"})
+	if !handled || err != nil || !reflect.DeepEqual(got, []string{"--days", "7"}) {
+		t.Errorf("DispatchArgs() = %v, %v with args %v", handled, err, got)
+	}
+
+	got = nil
+	handled, err = DispatchArgs([]string{DispatchFlag, "cleanup"})
+	if !handled || err != nil || len(got) != 0 {
+		t.Errorf("DispatchArgs() separate value = %v, %v with args %v", handled, err, got)
+	}
+
+	if handled, err := DispatchArgs([]string{DispatchFlag + "=missing"}); !handled || !errors.Is(err, ErrUnknownHandler) {
+		t.Errorf("DispatchArgs() unknown = %v, %v", handled, err)
+	}
+}
+
+func TestRegisterHandler(t *testing.T) {
+	Handle("report", func([]string) error { return nil })
+
+	debug := tasker.WithDebug(true)
+	if _, err := debug.RegisterHandler("missing", TaskCreate{}); !errors.Is(err, ErrUnknownHandler) {
+		t.Errorf("RegisterHandler() unknown = %v", err)
+	}
+	if _, err := debug.RegisterHandler("report", TaskCreate{Schedule: Schedules.DAILY}); err != nil {
+		t.Errorf("RegisterHandler() = %v", err)
+	}
+
+	tc := TaskCreate{Taskname: "report", Taskrun: "app.exe", Arguments: []string{DispatchFlag + "=report", "x"}}
+	cmds := strings.Join(tasker.TaskMake(tc, _Create.Command, true), " ")
+	if !strings.Contains(cmds, DispatchFlag+"=report x") {
+		t.Errorf("handler arguments not passed: %s", cmds)
+	}
+}
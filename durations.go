@@ -0,0 +1,85 @@
+package tasker
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	//maxDuration longest /DU schtasks accepts, 9999:59
+	maxDuration = 9999*time.Hour + 59*time.Minute
+)
+
+//FormatDuration formats d as the HHHH:mm value of /DU, e.g. 90 minutes
+//as "0001:30" and 30 hours as "0030:00". d must be whole minutes, at
+//least one and at most 9999:59.
+func FormatDuration(d time.Duration) (string, error) {
+	switch {
+	case d < time.Minute:
+		return "", fmt.Errorf("%w: duration %v is shorter than a minute", ErrInvalidValue, d)
+	case d%time.Minute != 0:
+		return "", fmt.Errorf("%w: duration %v is not whole minutes", ErrInvalidValue, d)
+	case d > maxDuration:
+		return "", fmt.Errorf("%w: duration %v exceeds 9999:59", ErrInvalidValue, d)
+	}
+	return fmt.Sprintf("%04d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute)), nil
+}
+
+//EndTime returns the HH:mm value of /ET d after the HH:mm start time.
+//The end time is a time of day, d must end the same day; longer windows
+//need a duration, see FormatDuration.
+func EndTime(start string, d time.Duration) (string, error) {
+	at, err := time.Parse("15:04", start)
+	if err != nil {
+		return "", fmt.Errorf("%w: start time %q is not HH:mm", ErrInvalidValue, start)
+	}
+	if d < time.Minute || d%time.Minute != 0 {
+		return "", fmt.Errorf("%w: end after %v is not whole minutes", ErrInvalidValue, d)
+	}
+
+	end := at.Add(d)
+	if end.Day() != at.Day() {
+		return "", fmt.Errorf("%w: end after %v passes midnight from %s, use a duration", ErrInvalidValue, d, start)
+	}
+	return end.Format("15:04"), nil
+}
+
+//checkDurations validates the typed durations and that they don't
+//compete with the string fields they set
+func (taskcreate TaskCreate) checkDurations() error {
+	if taskcreate.RunFor != 0 {
+		if taskcreate.Duration != "" {
+			return fmt.Errorf("%w: both Duration and RunFor given", ErrInvalidValue)
+		}
+		if _, err := FormatDuration(taskcreate.RunFor); err != nil {
+			return err
+		}
+	}
+	if taskcreate.EndAfter != 0 {
+		if taskcreate.Endtime != "" {
+			return fmt.Errorf("%w: both Endtime and EndAfter given", ErrInvalidValue)
+		}
+		if taskcreate.Starttime == "" {
+			return fmt.Errorf("%w: EndAfter needs a Starttime", ErrInvalidValue)
+		}
+		if _, err := EndTime(taskcreate.Starttime, taskcreate.EndAfter); err != nil {
+			return err
+		}
+	}
+	if taskcreate.RunFor != 0 && taskcreate.EndAfter != 0 {
+		return fmt.Errorf("%w: /DU and /ET exclude each other", ErrInvalidValue)
+	}
+	return nil
+}
+
+//withDurations sets Duration and Endtime from the typed durations,
+//invalid ones are left out, see checkDurations
+func (taskcreate TaskCreate) withDurations() TaskCreate {
+	if taskcreate.RunFor != 0 && taskcreate.Duration == "" {
+		taskcreate.Duration, _ = FormatDuration(taskcreate.RunFor)
+	}
+	if taskcreate.EndAfter != 0 && taskcreate.Endtime == "" {
+		taskcreate.Endtime, _ = EndTime(taskcreate.Starttime, taskcreate.EndAfter)
+	}
+	return taskcreate
+}
@@ -0,0 +1,68 @@
+package tasker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		time.Minute:                  "0000:01",
+		90 * time.Minute:             "0001:30",
+		24 * time.Hour:               "0024:00",
+		30*time.Hour + 5*time.Minute: "0030:05",
+		maxDuration:                  "9999:59",
+	}
+	for d, want := range cases {
+		if got, err := FormatDuration(d); got != want || err != nil {
+			t.Errorf("FormatDuration(%v) = %q, %v, want %q", d, got, err, want)
+		}
+	}
+	for _, d := range []time.Duration{0, 30 * time.Second, 90 * time.Second, maxDuration + time.Minute} {
+		if _, err := FormatDuration(d); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("FormatDuration(%v) = %v, want ErrInvalidValue", d, err)
+		}
+	}
+}
+
+func TestEndTime(t *testing.T) {
+	if end, err := EndTime("22:30", 89*time.Minute); end != "23:59" || err != nil {
+		t.Errorf("EndTime() = %q, %v", end, err)
+	}
+	for _, d := range []time.Duration{90 * time.Minute, 24 * time.Hour, time.Second} {
+		if _, err := EndTime("22:30", d); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("EndTime(%v) = %v, want ErrInvalidValue", d, err)
+		}
+	}
+	if _, err := EndTime("10pm", time.Hour); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("EndTime() bad start = %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	taskcreate := TaskCreate{Taskname: taskName, Taskrun: executable, Schedule: Schedules.DAILY,
+		Starttime: "08:00", Interval: "10", RunFor: 26 * time.Hour}
+	if err := taskcreate.checkDurations(); err != nil {
+		t.Fatal(err)
+	}
+	if cmds := tasker.TaskMake(taskcreate, _Create.Command, true); !containsPair(cmds, _Create.duration, "0026:00") {
+		t.Errorf("TaskMake() = %v", cmds)
+	}
+
+	taskcreate.RunFor, taskcreate.EndAfter = 0, 9*time.Hour
+	if cmds := tasker.TaskMake(taskcreate, _Create.Command, true); !containsPair(cmds, _Create.endtime, "17:00") {
+		t.Errorf("TaskMake() = %v", cmds)
+	}
+
+	for _, tc := range []TaskCreate{
+		{RunFor: time.Hour, Duration: "01:00"},
+		{EndAfter: time.Hour},
+		{EndAfter: time.Hour, Starttime: "08:00", Endtime: "09:00"},
+		{EndAfter: time.Hour, Starttime: "08:00", RunFor: time.Hour},
+	} {
+		if err := tc.checkDurations(); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("checkDurations(%+v) = %v, want ErrInvalidValue", tc, err)
+		}
+	}
+}
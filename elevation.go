@@ -0,0 +1,64 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	relaunchMessage = "Access denied, re-launched elevated to complete the registration."
+
+	//protectedFolder tasks of the system components, only administrators
+	//may register tasks below it
+	protectedFolder = "\\Microsoft\\"
+)
+
+var (
+	//ErrElevationRequired the task can only be registered by an
+	//administrator
+	ErrElevationRequired = errors.New("tasker: administrator rights required")
+)
+
+//relaunchError reports the exit code of the elevated process, nil when
+//it succeeded
+func relaunchError(code uint32) error {
+	if code == 0 {
+		return nil
+	}
+	return fmt.Errorf("tasker: elevated process exited with code %d", code)
+}
+
+//NeedsElevation reports whether creating the task requires administrator
+//rights, i.e. it runs as a built-in service account, at the HIGHEST run
+//level or is registered below the \Microsoft folder.
+func NeedsElevation(taskcreate TaskCreate) bool {
+	return elevationReason(taskcreate, NormalizeName(taskcreate.Taskname)) != ""
+}
+
+//CheckPrivileges reports ErrElevationRequired, with the reason, when the
+//current process lacks the rights to create the owned task, so callers
+//can fail fast instead of running into an access denied error.
+func (task SchTask) CheckPrivileges(taskcreate TaskCreate) error {
+	reason := elevationReason(taskcreate, task.fullName(taskcreate.Taskname, true))
+	if reason == "" || IsAdmin() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrElevationRequired, reason)
+}
+
+//elevationReason describes why registering taskcreate as name requires
+//administrator rights, "" when it doesn't
+func elevationReason(taskcreate TaskCreate, name string) string {
+	switch {
+	case isServiceAccount(taskcreate.Username):
+		return "runs as " + taskcreate.Username
+	case taskcreate.LogonMode == LogonModes.ALLUSERS:
+		return "runs for every user logging on"
+	case strings.EqualFold(taskcreate.Level, Level.HIGHEST):
+		return "runs with the " + Level.HIGHEST + " run level"
+	case strings.HasPrefix(strings.ToLower(name), strings.ToLower(protectedFolder)):
+		return "is registered below " + protectedFolder
+	}
+	return ""
+}
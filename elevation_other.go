@@ -0,0 +1,19 @@
+//go:build !windows
+
+package tasker
+
+//IsElevated reports whether the current process runs with an elevated
+//(administrator) token, always false outside windows.
+func IsElevated() bool {
+	return false
+}
+
+//IsAdmin reports whether the current process holds administrator rights,
+//always false outside windows.
+func IsAdmin() bool {
+	return false
+}
+
+func relaunchElevated() error {
+	return ErrUnsupportedPlatform
+}
@@ -0,0 +1,51 @@
+package tasker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNeedsElevation(t *testing.T) {
+	cases := map[string]struct {
+		tc   TaskCreate
+		want bool
+	}{
+		"user":      {TaskCreate{Taskname: taskName}, false},
+		"system":    {TaskCreate{Taskname: taskName}.AsSystem(), true},
+		"highest":   {TaskCreate{Taskname: taskName, Level: Level.HIGHEST}, true},
+		"microsoft": {TaskCreate{Taskname: "Microsoft\\Windows\\Test"}, true},
+		"app":       {TaskCreate{Taskname: "MicrosoftEdge\\Test"}, false},
+	}
+	for name, c := range cases {
+		if got := NeedsElevation(c.tc); got != c.want {
+			t.Errorf("%s: NeedsElevation() = %v, want %v", name, got, c.want)
+		}
+	}
+}
+
+func TestCheckPrivileges(t *testing.T) {
+	if err := tasker.CheckPrivileges(TaskCreate{Taskname: taskName}); err != nil {
+		t.Errorf("CheckPrivileges() = %v", err)
+	}
+
+	tc := TaskCreate{Taskname: taskName, Level: Level.HIGHEST}
+	if err := tasker.CheckPrivileges(tc); IsAdmin() != (err == nil) {
+		t.Errorf("CheckPrivileges() = %v with IsAdmin() = %v", err, IsAdmin())
+	} else if err != nil && !errors.Is(err, ErrElevationRequired) {
+		t.Errorf("CheckPrivileges() = %v, want ErrElevationRequired", err)
+	}
+
+	folder := tasker.WithFolder("\\Microsoft\\Windows\\App")
+	if err := folder.CheckPrivileges(TaskCreate{Taskname: taskName}); IsAdmin() != (err == nil) {
+		t.Errorf("CheckPrivileges() below \\Microsoft = %v", err)
+	}
+}
+
+func TestRelaunchError(t *testing.T) {
+	if err := relaunchError(0); err != nil {
+		t.Errorf("relaunchError(0) = %v", err)
+	}
+	if err := relaunchError(1); err == nil {
+		t.Error("relaunchError(1) must report the failed elevated process")
+	}
+}
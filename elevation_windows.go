@@ -0,0 +1,121 @@
+//go:build windows
+
+package tasker
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	tokenElevation = 20
+	swShowNormal   = 1
+
+	seeMaskNoCloseProcess = 0x00000040
+	seeMaskNoAsync        = 0x00000100
+)
+
+var (
+	shell32             = syscall.NewLazyDLL("shell32.dll")
+	procShellExecuteExW = shell32.NewProc("ShellExecuteExW")
+	procIsUserAnAdmin   = shell32.NewProc("IsUserAnAdmin")
+)
+
+//shellExecuteInfo SHELLEXECUTEINFOW
+type shellExecuteInfo struct {
+	size       uint32
+	mask       uint32
+	hwnd       uintptr
+	verb       *uint16
+	file       *uint16
+	parameters *uint16
+	directory  *uint16
+	show       int32
+	instApp    uintptr
+	idList     uintptr
+	class      *uint16
+	keyClass   uintptr
+	hotKey     uint32
+	icon       uintptr
+	process    syscall.Handle
+}
+
+//IsElevated reports whether the current process runs with an elevated
+//(administrator) token.
+func IsElevated() bool {
+	process, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return false
+	}
+
+	var token syscall.Token
+	if err := syscall.OpenProcessToken(process, syscall.TOKEN_QUERY, &token); err != nil {
+		return false
+	}
+	defer token.Close()
+
+	var elevated, size uint32
+	err = syscall.GetTokenInformation(token, tokenElevation, (*byte)(unsafe.Pointer(&elevated)), uint32(unsafe.Sizeof(elevated)), &size)
+	return err == nil && elevated != 0
+}
+
+//IsAdmin reports whether the current process holds administrator rights,
+//a member of the Administrators group running with a filtered UAC token
+//does not.
+func IsAdmin() bool {
+	if procIsUserAnAdmin.Find() != nil {
+		return IsElevated()
+	}
+	ret, _, _ := procIsUserAnAdmin.Call()
+	return ret != 0
+}
+
+//relaunchElevated starts the current executable with the same arguments
+//through ShellExecuteEx "runas", showing the UAC prompt, and waits for
+//it. A declined prompt or an elevated process exiting with a non-zero
+//code is an error.
+func relaunchElevated() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	args := make([]string, 0, len(os.Args))
+	for _, arg := range os.Args[1:] {
+		args = append(args, syscall.EscapeArg(arg))
+	}
+
+	info := shellExecuteInfo{
+		mask: seeMaskNoCloseProcess | seeMaskNoAsync,
+		show: swShowNormal,
+	}
+	info.size = uint32(unsafe.Sizeof(info))
+	info.verb, _ = syscall.UTF16PtrFromString("runas")
+	info.file, _ = syscall.UTF16PtrFromString(exe)
+	info.parameters, _ = syscall.UTF16PtrFromString(strings.Join(args, " "))
+	info.directory, _ = syscall.UTF16PtrFromString(dir)
+
+	ret, _, err := procShellExecuteExW.Call(uintptr(unsafe.Pointer(&info)))
+	if ret == 0 {
+		return err
+	}
+	if info.process == 0 {
+		return nil
+	}
+	defer syscall.CloseHandle(info.process)
+
+	if _, err := syscall.WaitForSingleObject(info.process, syscall.INFINITE); err != nil {
+		return err
+	}
+	var code uint32
+	if err := syscall.GetExitCodeProcess(info.process, &code); err != nil {
+		return err
+	}
+	return relaunchError(code)
+}
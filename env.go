@@ -0,0 +1,38 @@
+package tasker
+
+import (
+	"os"
+	"strings"
+)
+
+//expandEnv replaces %NAME% references with the value of the environment
+//variable like cmd.exe does, references to undefined variables and lone
+//percent signs are kept as is
+func expandEnv(s string) string {
+	expanded := strings.Builder{}
+	for {
+		start := strings.IndexByte(s, '%')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start+1:], '%')
+		if end < 0 {
+			break
+		}
+		end += start + 1
+
+		name := s[start+1 : end]
+		if value, ok := os.LookupEnv(name); ok && name != "" {
+			expanded.WriteString(s[:start])
+			expanded.WriteString(value)
+			s = s[end+1:]
+		} else {
+			//the closing percent may open the next reference
+			expanded.WriteString(s[:end])
+			s = s[end:]
+		}
+	}
+	expanded.WriteString(s)
+
+	return expanded.String()
+}
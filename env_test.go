@@ -0,0 +1,40 @@
+package tasker
+
+import (
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("WINTASK_ROOT", `C:\Windows`)
+	t.Setenv("WINTASK_DIR", `C:\Program Files\app`)
+
+	cases := map[string]string{
+		`%WINTASK_ROOT%\notepad.exe`:   `C:\Windows\notepad.exe`,
+		`%WINTASK_DIR%\%WINTASK_ROOT%`: `C:\Program Files\app\C:\Windows`,
+		`%WINTASK_UNSET%\x`:            `%WINTASK_UNSET%\x`,
+		`100% %WINTASK_ROOT%`:          `100% C:\Windows`,
+		`%%`:                           `%%`,
+		`50%`:                          `50%`,
+		`%WINTASK_UNSET%WINTASK_ROOT%`: `%WINTASK_UNSETC:\Windows`,
+		`no variables`:                 `no variables`,
+	}
+	for s, want := range cases {
+		if got := expandEnv(s); got != want {
+			t.Errorf("expandEnv(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestActionExpand(t *testing.T) {
+	t.Setenv("WINTASK_DIR", `C:\Program Files\app`)
+
+	tc := TaskCreate{Taskrun: `%WINTASK_DIR%\app.exe`, Arguments: []string{`%WINTASK_DIR%\data`}}
+	if run, args := tc.action(); run != tc.Taskrun || args != tc.Arguments[0] {
+		t.Errorf("action() = %s %s, want the variables unexpanded", run, args)
+	}
+
+	tc.Expand = true
+	if run, args := tc.action(); run != `C:\Program Files\app\app.exe` || args != `"C:\Program Files\app\data"` {
+		t.Errorf("action() = %s %s, want the variables expanded", run, args)
+	}
+}
@@ -0,0 +1,41 @@
+package tasker
+
+import (
+	"errors"
+	"io/fs"
+	"os/exec"
+)
+
+var (
+	//ErrUnsupportedPlatform returned by every operation outside windows,
+	//the package still compiles so call sites can be built and tested
+	//on any platform.
+	ErrUnsupportedPlatform = errors.New("tasker: the task scheduler is only available on windows")
+
+	//ErrBinaryNotFound the schtasks (or wevtutil) executable does not exist
+	ErrBinaryNotFound = errors.New("tasker: binary not found")
+)
+
+//unsupported returns the output and error of operations attempted
+//outside windows
+func unsupported() ([]byte, error) {
+	return []byte(ErrUnsupportedPlatform.Error()), ErrUnsupportedPlatform
+}
+
+//isMissingBinary reports whether starting a process failed since its
+//executable does not exist
+func isMissingBinary(err error) bool {
+	return errors.Is(err, fs.ErrNotExist) || errors.Is(err, exec.ErrNotFound)
+}
+
+//isAccessDenied reports whether schtasks failed for lack of rights, known
+//from the HRESULT only as the output is localized
+func isAccessDenied(err error) bool {
+	return errors.Is(err, ErrAccessDenied)
+}
+
+//isNotFound reports whether schtasks failed since the task or folder
+//does not exist, known from the HRESULT only as the output is localized
+func isNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
@@ -0,0 +1,49 @@
+package tasker
+
+import (
+	"fmt"
+	"os/user"
+	"strings"
+)
+
+//WithEventLog returns a copy of the tasker writing every create, delete,
+//change, run and end it performs to the Application event log under
+//source, with the task name, the user and the result, giving auditors a
+//trail of the changes made by the application. Passwords are not logged.
+func (task SchTask) WithEventLog(source string) SchTask {
+	task.eventSource = source
+	return task
+}
+
+//logEvent reports a command to the event log when enabled, help
+//requests are skipped
+func (task SchTask) logEvent(args []string, err error) {
+	if task.eventSource == "" || len(args) == 0 {
+		return
+	}
+	for _, arg := range args {
+		if arg == helpSwitch {
+			return
+		}
+	}
+
+	reportEvent(task.eventSource, err != nil, eventMessage(args, err))
+}
+
+//eventMessage describes a command, e.g.
+//"/CREATE \go-wintask-Backup by DOMAIN\user: succeeded"
+func eventMessage(args []string, err error) string {
+	name := argValue(args, _Create.taskname)
+
+	account := "unknown user"
+	if u, uerr := user.Current(); uerr == nil {
+		account = u.Username
+	}
+
+	result := "succeeded"
+	if err != nil {
+		result = "failed: " + err.Error()
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s %s by %s: %s", args[0], name, account, result))
+}
@@ -0,0 +1,5 @@
+//go:build !windows
+
+package tasker
+
+func reportEvent(source string, failed bool, message string) {}
@@ -0,0 +1,24 @@
+package tasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEventMessage(t *testing.T) {
+	args := []string{_Create.Command, _Create.username, "user", _Create.password, "secret", _Create.taskname, "\\go-wintask-Test"}
+
+	msg := eventMessage(args, nil)
+	if !strings.HasPrefix(msg, "/CREATE \\go-wintask-Test by ") || !strings.HasSuffix(msg, ": succeeded") {
+		t.Errorf("eventMessage() = %q", msg)
+	}
+	if strings.Contains(msg, "secret") {
+		t.Errorf("eventMessage() leaks the password: %q", msg)
+	}
+
+	msg = eventMessage(args, errors.New("boom"))
+	if !strings.HasSuffix(msg, ": failed: boom") {
+		t.Errorf("eventMessage() = %q", msg)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build windows
+
+package tasker
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+const (
+	eventError       = 0x0001
+	eventInformation = 0x0004
+
+	eventIDSuccess = 1
+	eventIDFailure = 2
+)
+
+var (
+	procRegisterEventSource   = advapi32.NewProc("RegisterEventSourceW")
+	procReportEvent           = advapi32.NewProc("ReportEventW")
+	procDeregisterEventSource = advapi32.NewProc("DeregisterEventSource")
+)
+
+//reportEvent writes a message to the Application event log, failures are
+//ignored so auditing never breaks the operation itself
+func reportEvent(source string, failed bool, message string) {
+	name, err := syscall.UTF16PtrFromString(source)
+	if err != nil {
+		return
+	}
+	text, err := syscall.UTF16PtrFromString(message)
+	if err != nil {
+		return
+	}
+
+	handle, _, _ := procRegisterEventSource.Call(0, uintptr(unsafe.Pointer(name)))
+	if handle == 0 {
+		return
+	}
+	defer procDeregisterEventSource.Call(handle)
+
+	kind, id := uintptr(eventInformation), uintptr(eventIDSuccess)
+	if failed {
+		kind, id = eventError, eventIDFailure
+	}
+	strings := [1]*uint16{text}
+	procReportEvent.Call(handle, kind, 0, id, 0, 1, 0, uintptr(unsafe.Pointer(&strings[0])), 0)
+}
@@ -0,0 +1,52 @@
+package tasker
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var (
+	//CSVColumns header of WriteCSV, the same names as the JSON encoding
+	//of TaskDetail whatever the display language of schtasks. Columns
+	//are only ever appended.
+	CSVColumns = []string{
+		"host", "name", "nextRunTime", "status", "logonMode", "lastRunTime", "lastResult",
+		"author", "taskToRun", "startIn", "comment", "state", "runAsUser", "scheduleType",
+		"startTime", "startDate", "endDate", "days", "months", "repeatEvery",
+	}
+)
+
+//WriteCSV writes the task details as CSV with the CSVColumns header.
+//Timestamps are RFC3339, empty when not available, statuses canonical,
+//see Status, and last results decimal.
+func WriteCSV(w io.Writer, tasks []TaskDetail) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVColumns); err != nil {
+		return err
+	}
+
+	for _, d := range tasks {
+		record := []string{
+			d.Host, d.Name, csvTime(d.NextRunTime), string(d.Status), d.LogonMode,
+			csvTime(d.LastRunTime), strconv.Itoa(d.LastResult), d.Author, d.TaskToRun,
+			d.StartIn, d.Comment, d.State, d.RunAsUser, d.ScheduleType, d.StartTime,
+			d.StartDate, d.EndDate, d.Days, d.Months, d.RepeatEvery,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+//csvTime formats a timestamp as RFC3339, "" when zero
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,46 @@
+package tasker
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	details := New(false).parseDetail([]byte(detailCSV))
+	buf := &bytes.Buffer{}
+	if err := WriteCSV(buf, details); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || !reflect.DeepEqual(records[0], CSVColumns) {
+		t.Fatalf("WriteCSV() = %v", records)
+	}
+
+	//the columns carry the values of the JSON encoding
+	data, err := json.Marshal(details[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != len(CSVColumns) {
+		t.Errorf("%d JSON fields, %d CSV columns", len(fields), len(CSVColumns))
+	}
+	row := map[string]string{}
+	for i, column := range CSVColumns {
+		row[column] = records[1][i]
+	}
+	if row["name"] != "\\go-wintask-Test" || row["lastResult"] != "267011" || row["nextRunTime"] != "" ||
+		row["lastRunTime"] != fields["lastRunTime"] || row["status"] != fields["status"] {
+		t.Errorf("WriteCSV() row = %v", row)
+	}
+}
@@ -0,0 +1,95 @@
+package tasker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+//FailurePolicy disables a task once its most recent runs all failed, so
+//a broken job stops hammering the machine. Runs are read from the
+//Task Scheduler event log of the target system, see History.
+type FailurePolicy struct {
+	Taskname string
+	Own      bool
+
+	//MaxFailures consecutive failed runs disabling the task, defaults
+	//to 3.
+	MaxFailures int
+
+	//OnDisable when set is called after the task was disabled with the
+	//failed runs, newest first.
+	OnDisable func(name string, failures []RunRecord)
+}
+
+//EnforceFailurePolicy disables the task when its last MaxFailures runs
+//failed to start or exited with a non-zero code, reporting whether it
+//did.
+func (task SchTask) EnforceFailurePolicy(policy FailurePolicy) (bool, error) {
+	name, err := task.resolveName(policy.Taskname, policy.Own)
+	if err != nil {
+		return false, err
+	}
+	max := policy.MaxFailures
+	if max <= 0 {
+		max = 3
+	}
+
+	//one more record for a run still in progress
+	records, err := task.history(name, max+1)
+	if err != nil {
+		return false, err
+	}
+	failures := consecutiveFailures(records)
+	if len(failures) < max {
+		return false, nil
+	}
+
+	if _, err := task.DisableTask(name, false); err != nil {
+		return false, err
+	}
+	if policy.OnDisable != nil {
+		policy.OnDisable(name, failures[:max])
+	}
+	return true, nil
+}
+
+//WatchFailures enforces the policy every interval until ctx is done or
+//the task was disabled, returning the error stopping it.
+func (task SchTask) WatchFailures(ctx context.Context, policy FailurePolicy, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: watch interval %v", ErrInvalidValue, interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		disabled, err := task.EnforceFailurePolicy(policy)
+		if err != nil || disabled {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+//consecutiveFailures returns the failed runs preceding the most recent
+//success, records newest first. Runs in progress are skipped.
+func consecutiveFailures(records []RunRecord) []RunRecord {
+	failures := []RunRecord{}
+	for _, record := range records {
+		if !record.Completed {
+			continue
+		}
+		if record.Result == 0 && record.ExitCode == 0 {
+			break
+		}
+		failures = append(failures, record)
+	}
+	return failures
+}
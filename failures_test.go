@@ -0,0 +1,71 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConsecutiveFailures(t *testing.T) {
+	records := []RunRecord{
+		{InstanceID: "running"},
+		{InstanceID: "a", Completed: true, ExitCode: 1},
+		{InstanceID: "b", Completed: true, Result: -2147024894},
+		{InstanceID: "c", Completed: true},
+		{InstanceID: "d", Completed: true, ExitCode: 1},
+	}
+	failures := consecutiveFailures(records)
+	if len(failures) != 2 || failures[0].InstanceID != "a" || failures[1].InstanceID != "b" {
+		t.Errorf("consecutiveFailures() = %+v", failures)
+	}
+	if len(consecutiveFailures(nil)) != 0 {
+		t.Error("consecutiveFailures() without runs is not empty")
+	}
+}
+
+func TestDisableTask(t *testing.T) {
+	var got []string
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		got = args
+		return nil, nil
+	}))
+
+	if _, err := task.DisableTask(taskName, true); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/CHANGE", "/TN", "\\go-wintask-Test", "/DISABLE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DisableTask() ran %v, want %v", got, want)
+	}
+	if _, err := task.EnableTask(taskName, true); err != nil || got[3] != "/ENABLE" {
+		t.Errorf("EnableTask() ran %v, %v", got, err)
+	}
+
+	//the backend doesn't read the event log
+	if _, err := task.EnforceFailurePolicy(FailurePolicy{Taskname: taskName, Own: true}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("EnforceFailurePolicy() = %v, want ErrNotSupported", err)
+	}
+
+	//the remote task failed twice, the log is read on its host
+	var queries [][]string
+	remote := task.WithRemote("host1", "").WithBackend(eventBackend{backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		got = args
+		return nil, nil
+	}), func(args []string) ([]byte, error) {
+		queries = append(queries, args)
+		return []byte(strings.Join([]string{logEvent(102, 0, ""), logEvent(201, 0, "1"), logEvent(101, 1, "0x80070002")}, "\n")), nil
+	}})
+	got = nil
+	if disabled, err := remote.EnforceFailurePolicy(FailurePolicy{Taskname: taskName, Own: true, MaxFailures: 2}); !disabled || err != nil {
+		t.Errorf("EnforceFailurePolicy() = %v, %v, want disabled", disabled, err)
+	}
+	if queries[0][len(queries[0])-1] != "/r:host1" || argValue(got, hostSwitch) != "host1" {
+		t.Errorf("EnforceFailurePolicy() queried %v, ran %v", queries, got)
+	}
+
+	if err := task.WatchFailures(context.Background(), FailurePolicy{Taskname: taskName}, 0); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("WatchFailures() without an interval = %v, want ErrInvalidValue", err)
+	}
+}
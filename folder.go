@@ -0,0 +1,7 @@
+package tasker
+
+//WithFolder returns a copy of the tasker registering owned tasks in a
+//dedicated folder instead of under the name prefix, see Folder.
+func (task SchTask) WithFolder(folder string) SchTask {
+	return task.WithNamespace(Folder(folder))
+}
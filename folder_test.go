@@ -0,0 +1,19 @@
+package tasker
+
+import (
+	"testing"
+)
+
+func TestFolder(t *testing.T) {
+	folder := New(false).WithFolder("go-wintask\\app\\")
+
+	if name := folder.fullName(taskName, true); name != "\\go-wintask\\app\\Test" {
+		t.Errorf("unexpected owned name %q", name)
+	}
+	if !folder.owns("\\go-wintask\\app\\Test") || folder.owns("\\go-wintask-Test") {
+		t.Error("ownership must follow the folder")
+	}
+	if !folder.match(Filter{"te*", true}, "\\go-wintask\\app\\Test") || folder.match(Filter{"app", true}, "\\go-wintask\\app\\Test") {
+		t.Error("owned filters must match the plain name")
+	}
+}
@@ -0,0 +1,59 @@
+package tasker
+
+import (
+	"fmt"
+	"time"
+)
+
+//IsHealthy reports whether the task is enabled, its last run succeeded
+//and it ran within maxAge, zero skips the age check. See Unhealthy for
+//the reason a task is not healthy.
+func (task SchTask) IsHealthy(name string, own bool, maxAge time.Duration) (bool, error) {
+	name, err := task.resolveName(name, own)
+	if err != nil {
+		return false, err
+	}
+
+	args := task.queryArgs(_Query.Command, _Query.taskname, name, _Query.format, _Query.formatCSV, _Query.verbose)
+	output, err := task.execute(args...)
+	if err != nil {
+		return false, err
+	}
+	details := task.parseDetail(output)
+	if len(details) == 0 {
+		return false, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	return details[0].Unhealthy(maxAge) == "", nil
+}
+
+//Unhealthy returns why the task is not healthy, "" when it is: disabled,
+//its last result is not zero or its last run is older than maxAge, zero
+//skips the age check. A running task is healthy while its previous run
+//is recent enough.
+func (detail TaskDetail) Unhealthy(maxAge time.Duration) string {
+	if detail.Status == StatusDisabled {
+		return "disabled"
+	}
+
+	switch detail.LastResult {
+	case 0, schedTaskRunning:
+	case schedTaskHasNotRun:
+		if maxAge > 0 {
+			return "never ran"
+		}
+		return ""
+	default:
+		return fmt.Sprintf("last result 0x%X", uint32(detail.LastResult))
+	}
+
+	if maxAge > 0 {
+		if detail.LastRunTime.IsZero() {
+			return "never ran"
+		}
+		if age := time.Since(detail.LastRunTime); age > maxAge {
+			return fmt.Sprintf("last ran %v ago", age.Round(time.Second))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package tasker
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsHealthy(t *testing.T) {
+	//the fixture never ran
+	output := detailCSV
+	task := New(false).WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		return []byte(output), nil
+	}))
+
+	if healthy, err := task.IsHealthy(taskName, true, 0); !healthy || err != nil {
+		t.Errorf("IsHealthy() never ran without max age = %v, %v", healthy, err)
+	}
+	if healthy, err := task.IsHealthy(taskName, true, time.Hour); healthy || err != nil {
+		t.Errorf("IsHealthy() never ran = %v, %v", healthy, err)
+	}
+
+	output = strings.Replace(detailCSV, `"267011"`, `"0"`, 1)
+	if healthy, err := task.IsHealthy(taskName, true, 0); !healthy || err != nil {
+		t.Errorf("IsHealthy() = %v, %v", healthy, err)
+	}
+
+	output = ""
+	if _, err := task.IsHealthy(taskName, true, 0); err == nil {
+		t.Error("IsHealthy() of a missing task succeeded")
+	}
+}
+
+func TestUnhealthy(t *testing.T) {
+	recent := time.Now().Add(-time.Minute)
+	cases := map[string]TaskDetail{
+		"":                    {LastRunTime: recent},
+		"disabled":            {Status: StatusDisabled, LastRunTime: recent},
+		"last result 0x1":     {LastResult: 1, LastRunTime: recent},
+		"last result 0x800":   {LastResult: 0x800, LastRunTime: recent},
+		"never ran":           {LastResult: schedTaskHasNotRun},
+		"last ran 2h0m0s ago": {LastRunTime: time.Now().Add(-2 * time.Hour)},
+	}
+	for want, detail := range cases {
+		if got := detail.Unhealthy(time.Hour); got != want {
+			t.Errorf("Unhealthy(%+v) = %q, want %q", detail, got, want)
+		}
+	}
+
+	running := TaskDetail{Status: StatusRunning, LastResult: schedTaskRunning, LastRunTime: recent}
+	if reason := running.Unhealthy(time.Hour); reason != "" {
+		t.Errorf("Unhealthy() running = %q", reason)
+	}
+}
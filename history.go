@@ -0,0 +1,236 @@
+package tasker
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	eventFile    = "wevtutil.exe"
+	eventChannel = "Microsoft-Windows-TaskScheduler/Operational"
+	eventQuery   = "qe"
+
+	//eventsPerRun events usually logged by a run: 107 or 106, 100, 129,
+	//200, 201 and 102
+	eventsPerRun = 6
+)
+
+//Task Scheduler operational event ids
+const (
+	eventTaskStarted     = 100
+	eventTaskFailedStart = 101
+	eventTaskCompleted   = 102
+	eventActionFailed    = 103
+	eventActionCompleted = 201
+)
+
+//RunRecord a single run of a task from the Task Scheduler event log
+type RunRecord struct {
+	InstanceID string
+	Start      time.Time
+	End        time.Time
+
+	//ExitCode process exit code reported by the action completed event
+	ExitCode int
+
+	//Result launch failure code, zero when the task started
+	Result int
+
+	//Completed whether the run finished, false while still running
+	Completed bool
+}
+
+//event Task Scheduler event as printed by wevtutil /f:xml
+type event struct {
+	System struct {
+		EventID     int `xml:"EventID"`
+		TimeCreated struct {
+			SystemTime string `xml:"SystemTime,attr"`
+		} `xml:"TimeCreated"`
+		Correlation struct {
+			ActivityID string `xml:"ActivityID,attr"`
+		} `xml:"Correlation"`
+	} `xml:"System"`
+	Data []struct {
+		Name  string `xml:"Name,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"EventData>Data"`
+}
+
+//data returns the named event data value
+func (e event) data(name string) string {
+	for _, d := range e.Data {
+		if d.Name == name {
+			return d.Value
+		}
+	}
+	return ""
+}
+
+//History returns up to limit of the most recent runs of the task, newest
+//first, read from the operational event log of the target system through
+//the backend, see EventBackend. The log must be enabled
+//(wevtutil sl Microsoft-Windows-TaskScheduler/Operational /e:true).
+func (task SchTask) History(name string, own bool, limit int) ([]RunRecord, error) {
+	path, err := task.resolveName(name, own)
+	if err != nil {
+		return nil, err
+	}
+	return task.history(path, limit)
+}
+
+//history reads the runs of the registered task path. Runs log about six
+//events, more with several actions, so the query grows until it covers
+//one run more than limit, proving the oldest one complete, or the whole
+//log.
+func (task SchTask) history(path string, limit int) ([]RunRecord, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: history limit %d", ErrInvalidValue, limit)
+	}
+
+	for count := limit * eventsPerRun; ; count *= 2 {
+		events, err := task.queryEvents(path, count)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) < count || len(runRecords(events, limit+1)) > limit {
+			return runRecords(events, limit), nil
+		}
+	}
+}
+
+//checkXPathLiteral rejects names which can't be quoted in an event log
+//query, XPath 1.0 literals have no escape for their quote character and
+//wevtutil and schtasks both mangle a double quote on the command line
+func checkXPathLiteral(name string) error {
+	if strings.ContainsAny(name, `'"`) {
+		return fmt.Errorf("%w: %q contains a quote, it can't be used in an event query", ErrInvalidName, name)
+	}
+	return nil
+}
+
+//runRecords groups events by task instance into at most limit records
+func runRecords(events []event, limit int) []RunRecord {
+	records := []RunRecord{}
+	index := map[string]int{}
+	for _, e := range events {
+		id := e.System.Correlation.ActivityID
+		if id == "" {
+			continue
+		}
+
+		i, ok := index[id]
+		if !ok {
+			if len(records) == limit {
+				continue
+			}
+			records = append(records, RunRecord{InstanceID: id})
+			i = len(records) - 1
+			index[id] = i
+		}
+
+		created, _ := time.Parse(time.RFC3339Nano, e.System.TimeCreated.SystemTime)
+		record := &records[i]
+		switch e.System.EventID {
+		case eventTaskStarted:
+			record.Start = created
+		case eventTaskCompleted:
+			record.End = created
+			record.Completed = true
+		case eventActionCompleted:
+			record.ExitCode = parseCode(e.data("ResultCode"))
+		case eventTaskFailedStart, eventActionFailed:
+			record.End = created
+			record.Completed = true
+			record.Result = parseCode(e.data("ResultCode"))
+		}
+	}
+
+	return records
+}
+
+//queryEvents reads the count newest events of the task path
+func (task SchTask) queryEvents(path string, count int) (events []event, err error) {
+	if err := checkXPathLiteral(path); err != nil {
+		return nil, err
+	}
+	backend, ok := task.Backend().(EventBackend)
+	if !ok {
+		return nil, fmt.Errorf("%w: the backend doesn't read the event log", ErrNotSupported)
+	}
+
+	query := fmt.Sprintf("*[EventData[Data[@Name='TaskName']='%s']]", path)
+	args, err := task.eventRemote([]string{eventQuery, eventChannel, "/q:" + query, "/f:xml", "/rd:true",
+		"/c:" + strconv.Itoa(count)})
+	if err != nil {
+		return nil, err
+	}
+
+	if task.tracer != nil {
+		end := task.tracer.Start(task.context(), Operation{Name: eventQuery, Taskname: path, Host: task.host})
+		defer func() {
+			end(exitCode(err), err)
+		}()
+	}
+	if err := task.limiter.wait(task.context()); err != nil {
+		return nil, err
+	}
+
+	output, err := backend.QueryEvents(args)
+	if err != nil {
+		if errors.Is(err, ErrBinaryNotFound) || errors.Is(err, ErrUnsupportedPlatform) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return parseEvents(output)
+}
+
+//eventRemote appends the wevtutil switches reading the log of the remote
+//host of the tasker, see withRemote
+func (task SchTask) eventRemote(args []string) ([]string, error) {
+	if task.host == "" {
+		return args, nil
+	}
+
+	args = append(args, "/r:"+task.host)
+	if task.hostCredential != "" {
+		user, password, err := task.Credentials().Credential(task.context(), task.hostCredential)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "/u:"+user, "/p:"+password)
+	}
+	return args, nil
+}
+
+//parseEvents parses the root-less sequence of events printed by wevtutil
+func parseEvents(output []byte) ([]event, error) {
+	events := []event{}
+	decoder := xml.NewDecoder(bytes.NewReader(output))
+	for {
+		e := event{}
+		err := decoder.Decode(&e)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+//parseCode parses a decimal or hexadecimal result code
+func parseCode(value string) int {
+	code, _ := strconv.ParseInt(strings.TrimSpace(value), 0, 64)
+	return int(int32(code))
+}
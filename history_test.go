@@ -0,0 +1,100 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const historyXML = `<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System><EventID>102</EventID><TimeCreated SystemTime='2026-10-15T14:31:00.0000000Z'/><Correlation ActivityID='{A}'/></System><EventData><Data Name='TaskName'>\go-wintask-Test</Data><Data Name='InstanceId'>{A}</Data></EventData></Event>
+<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System><EventID>201</EventID><TimeCreated SystemTime='2026-10-15T14:31:00.0000000Z'/><Correlation ActivityID='{A}'/></System><EventData><Data Name='TaskName'>\go-wintask-Test</Data><Data Name='ResultCode'>3</Data></EventData></Event>
+<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System><EventID>100</EventID><TimeCreated SystemTime='2026-10-15T14:30:00.0000000Z'/><Correlation ActivityID='{A}'/></System><EventData><Data Name='TaskName'>\go-wintask-Test</Data></EventData></Event>
+<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System><EventID>100</EventID><TimeCreated SystemTime='2026-10-14T14:30:00.0000000Z'/><Correlation ActivityID='{B}'/></System><EventData><Data Name='TaskName'>\go-wintask-Test</Data></EventData></Event>
+`
+
+func TestRunRecords(t *testing.T) {
+	events, err := parseEvents([]byte(historyXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := runRecords(events, 1)
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %d", len(records))
+	}
+
+	r := records[0]
+	if r.InstanceID != "{A}" || !r.Completed || r.ExitCode != 3 || r.End.Sub(r.Start).Minutes() != 1 {
+		t.Errorf("unexpected record %+v", r)
+	}
+}
+
+//logEvent returns a Task Scheduler event of the test task
+func logEvent(id, activity int, code string) string {
+	return fmt.Sprintf(`<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System><EventID>%d</EventID>`+
+		`<TimeCreated SystemTime='2026-10-%02dT14:30:00.0000000Z'/><Correlation ActivityID='{%d}'/></System>`+
+		`<EventData><Data Name='TaskName'>\go-wintask-Test</Data><Data Name='ResultCode'>%s</Data></EventData></Event>`, id, 28-activity, activity, code)
+}
+
+//runEvents returns the events of count runs of the test task, newest
+//first, each logging 107, 100, 129, 200, 201 and 102
+func runEvents(count int) []string {
+	events := []string{}
+	for run := 0; run < count; run++ {
+		for _, id := range []int{102, 201, 200, 129, 100, 107} {
+			events = append(events, logEvent(id, run, "0"))
+		}
+	}
+	return events
+}
+
+func TestHistory(t *testing.T) {
+	events := runEvents(3)
+	var queries []string
+	backend := eventBackend{backendFunc(nil), func(args []string) ([]byte, error) {
+		queries = append(queries, strings.Join(args, " "))
+		count, _ := strconv.Atoi(strings.TrimPrefix(args[5], "/c:"))
+		if count > len(events) {
+			count = len(events)
+		}
+		return []byte(strings.Join(events[:count], "\n")), nil
+	}}
+	task := tasker.WithBackend(backend)
+
+	//two runs take twelve events, the query grows until a third one shows
+	records, err := task.History(taskName, true, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1].InstanceID != "{1}" || records[1].Start.IsZero() || !records[1].Completed {
+		t.Errorf("History() = %+v", records)
+	}
+	want := `qe Microsoft-Windows-TaskScheduler/Operational /q:*[EventData[Data[@Name='TaskName']='\go-wintask-Test']] /f:xml /rd:true /c:12`
+	if len(queries) != 2 || queries[0] != want || !strings.HasSuffix(queries[1], "/c:24") {
+		t.Errorf("History() queried %q, want %q and /c:24", queries, want)
+	}
+
+	queries = nil
+	if _, err := task.WithRemote("host1", "").History(taskName, true, 5); err != nil || !strings.HasSuffix(queries[0], "/c:30 /r:host1") {
+		t.Errorf("remote History() queried %q, %v", queries, err)
+	}
+
+	for _, limit := range []int{0, -1} {
+		if _, err := task.History(taskName, true, limit); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("History() limit %d = %v, want ErrInvalidValue", limit, err)
+		}
+	}
+	if _, err := tasker.WithBackend(backendFunc(nil)).History(taskName, true, 1); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("History() without an event backend = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestHistoryQuotes(t *testing.T) {
+	for _, name := range []string{`it's`, `say "hi"`} {
+		if _, err := tasker.History(name, false, 1); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("History(%s) = %v, want ErrInvalidName", name, err)
+		}
+	}
+}
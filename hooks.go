@@ -0,0 +1,115 @@
+package tasker
+
+//Hooks callbacks around the creates, changes and deletes of a tasker,
+//to enforce naming policies, inject default settings or send
+//notifications in one place. Before hooks may edit the task and abort
+//the operation by returning an error, after hooks see the outcome.
+//Unset hooks are skipped. Every task the tasker registers, changes or
+//deletes passes them, e.g. through Sync or EnsureSingleton; the tasks restored,
+//migrated or instantiated from XML only carry a Taskname, a full path,
+//and Force. The targeted changes carry the Taskname and the fields they
+//change: Username and Password for ChangeCredentials, Taskrun and
+//Arguments for ChangeAction, Taskrun for UpdateSelfPath, nothing more for
+//EnableTask and DisableTask.
+type Hooks struct {
+	OnBeforeCreate func(taskcreate *TaskCreate) error
+	OnAfterCreate  func(taskcreate TaskCreate, output string, err error)
+
+	OnBeforeChange func(taskcreate *TaskCreate, own bool) error
+	OnAfterChange  func(taskcreate TaskCreate, own bool, output string, err error)
+
+	//OnBeforeDelete and OnAfterDelete get the task name as given
+	OnBeforeDelete func(taskname string, own bool) error
+	OnAfterDelete  func(taskname string, own bool, output string, err error)
+}
+
+//WithHooks returns a copy of the tasker calling hooks around its
+//operations.
+func (task SchTask) WithHooks(hooks Hooks) SchTask {
+	task.hooks = hooks
+	return task
+}
+
+//CreateTask is Create reporting failures as an error, see Error, instead
+//of exiting.
+func (task SchTask) CreateTask(taskcreate TaskCreate, opts ...CallOption) (string, error) {
+	return task.with(opts).create(taskcreate, true)
+}
+
+//create performs CreateTask for owned or unowned task names, the path
+//every registration of the library takes so the defaults, hooks and
+//machine lock see them all
+func (task SchTask) create(taskcreate TaskCreate, own bool) (output string, err error) {
+	if taskcreate.definition == nil {
+		taskcreate = task.withDefaults(taskcreate)
+	}
+	if hook := task.hooks.OnBeforeCreate; hook != nil {
+		if err := hook(&taskcreate); err != nil {
+			return "", err
+		}
+	}
+	if hook := task.hooks.OnAfterCreate; hook != nil {
+		defer func() {
+			hook(taskcreate, output, err)
+		}()
+	}
+
+	task, unlock, err := task.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	return task.createTask(taskcreate, own)
+}
+
+//ChangeTask is Change reporting failures as an error instead of exiting.
+func (task SchTask) ChangeTask(taskcreate TaskCreate, own bool, opts ...CallOption) (string, error) {
+	task = task.with(opts)
+	return task.change(taskcreate, own, func(taskcreate TaskCreate) (string, error) {
+		task, unlock, err := task.lock()
+		if err != nil {
+			return "", err
+		}
+		defer unlock()
+		return task.changeTask(taskcreate, own)
+	})
+}
+
+//change runs the change hooks around perform, which applies the fields
+//of taskcreate left by the before hook. Targeted changes like
+//ChangeAction take it with the fields they change.
+func (task SchTask) change(taskcreate TaskCreate, own bool, perform func(TaskCreate) (string, error)) (output string, err error) {
+	if hook := task.hooks.OnBeforeChange; hook != nil {
+		if err := hook(&taskcreate, own); err != nil {
+			return "", err
+		}
+	}
+	if hook := task.hooks.OnAfterChange; hook != nil {
+		defer func() {
+			hook(taskcreate, own, output, err)
+		}()
+	}
+	return perform(taskcreate)
+}
+
+//DeleteTask is Delete reporting failures as an error instead of exiting.
+func (task SchTask) DeleteTask(taskname string, own, force bool, opts ...CallOption) (output string, err error) {
+	task = task.with(opts)
+	if hook := task.hooks.OnBeforeDelete; hook != nil {
+		if err := hook(taskname, own); err != nil {
+			return "", err
+		}
+	}
+	if hook := task.hooks.OnAfterDelete; hook != nil {
+		defer func() {
+			hook(taskname, own, output, err)
+		}()
+	}
+
+	task, unlock, err := task.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	return task.deleteTask(taskname, own, force)
+}
@@ -0,0 +1,89 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	var created []string
+	var events []string
+	errPolicy := errors.New("names must start with app")
+
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[0] == _Create.Command && len(args) > 1 && args[1] != "/?" {
+			created = append(created, strings.Join(args, " "))
+		}
+		return []byte("SUCCESS"), nil
+	})).WithHooks(Hooks{
+		OnBeforeCreate: func(taskcreate *TaskCreate) error {
+			if !strings.HasPrefix(taskcreate.Taskname, "app") {
+				return errPolicy
+			}
+			taskcreate.Force = true
+			return nil
+		},
+		OnAfterCreate: func(taskcreate TaskCreate, output string, err error) {
+			events = append(events, "created "+taskcreate.Taskname+" "+output)
+		},
+		OnBeforeDelete: func(taskname string, own bool) error {
+			events = append(events, "deleting "+taskname)
+			return nil
+		},
+		OnAfterChange: func(taskcreate TaskCreate, own bool, output string, err error) {
+			events = append(events, "changed "+taskcreate.Taskname)
+		},
+	})
+
+	if _, err := task.CreateTask(TaskCreate{Taskname: "other", Taskrun: executable}); !errors.Is(err, errPolicy) {
+		t.Errorf("CreateTask() against the policy = %v", err)
+	}
+	if _, err := task.CreateTask(TaskCreate{Taskname: "app-job", Taskrun: executable}); err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 1 || !strings.Contains(created[0], " "+_Create.force+" ") {
+		t.Errorf("created %v, want one forced create", created)
+	}
+
+	task.DeleteTask("app-job", true, true)
+	task.ChangeTask(TaskCreate{Taskname: "app-job", Taskrun: executable}, true)
+	want := "created app-job SUCCESS|deleting app-job|changed app-job"
+	if got := strings.Join(events, "|"); got != want {
+		t.Errorf("hooks called %q, want %q", got, want)
+	}
+}
+
+func TestHooksMigrate(t *testing.T) {
+	var events []string
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[0] == _Query.Command && argValue(args, _Query.taskname) != "":
+			return []byte(singletonXML), nil
+		case args[0] == _Query.Command:
+			return []byte(`"\go-wintask-Test","N/A","Ready"`), nil
+		case args[1] == helpSwitch:
+			return nil, exitError(1)
+		}
+		events = append(events, args[0]+" "+argValue(args, _Create.taskname))
+		return nil, nil
+	})).WithHooks(Hooks{
+		OnBeforeCreate: func(taskcreate *TaskCreate) error {
+			events = append(events, "creating "+taskcreate.Taskname)
+			return nil
+		},
+		OnBeforeDelete: func(taskname string, own bool) error {
+			events = append(events, "deleting "+taskname)
+			return nil
+		},
+	})
+
+	if _, err := task.MigrateNamespace(DefaultPrefix, Folder("app")); err != nil {
+		t.Fatal(err)
+	}
+	want := "creating \\app\\Test|/CREATE \\app\\Test|deleting \\go-wintask-Test|/DELETE \\go-wintask-Test"
+	if got := strings.Join(events, "|"); got != want {
+		t.Errorf("MigrateNamespace() called %q, want %q", got, want)
+	}
+}
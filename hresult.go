@@ -0,0 +1,140 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	hresultSwitch = "/HRESULT"
+	helpSwitch    = "/?"
+)
+
+var (
+	//ErrAccessDenied the caller lacks the rights for the operation
+	ErrAccessDenied = errors.New("tasker: access denied")
+	//ErrNotFound the task or folder does not exist
+	ErrNotFound = errors.New("tasker: task not found")
+	//ErrAlreadyExists a task with the same name is registered
+	ErrAlreadyExists = errors.New("tasker: task already exists")
+	//ErrTaskNotRunning the task is not running
+	ErrTaskNotRunning = errors.New("tasker: task is not running")
+	//ErrAlreadyRunning an instance of the task is already running
+	ErrAlreadyRunning = errors.New("tasker: task is already running")
+	//ErrTaskDisabled the task is disabled
+	ErrTaskDisabled = errors.New("tasker: task is disabled")
+	//ErrServiceNotRunning the Task Scheduler service is not running
+	ErrServiceNotRunning = errors.New("tasker: task scheduler service is not running")
+	//ErrInvalidTask the task definition is invalid
+	ErrInvalidTask = errors.New("tasker: invalid task")
+	//ErrAccountNotFound the run as account does not exist
+	ErrAccountNotFound = errors.New("tasker: account not found")
+	//ErrUserNotLoggedOn the run as user is not logged on
+	ErrUserNotLoggedOn = errors.New("tasker: user not logged on")
+	//ErrMalformedXML the task XML is malformed
+	ErrMalformedXML = errors.New("tasker: malformed task xml")
+	//ErrInvalidValue a task setting has an invalid value
+	ErrInvalidValue = errors.New("tasker: invalid value")
+	//ErrPastEndBoundary the task is past its end boundary
+	ErrPastEndBoundary = errors.New("tasker: task past end boundary")
+	//ErrNotV1Compatible the task is not compatible with /V1
+	ErrNotV1Compatible = errors.New("tasker: task not v1 compatible")
+
+	//hresults well known HRESULT values, SCHED_E_* from schedule.h
+	hresults = map[uint32]error{
+		0x80070005: ErrAccessDenied,
+		0x80070002: ErrNotFound,
+		0x80070003: ErrNotFound,
+		0x800700B7: ErrAlreadyExists,
+		0x80041309: ErrNotFound,          //SCHED_E_TRIGGER_NOT_FOUND
+		0x8004130B: ErrTaskNotRunning,    //SCHED_E_TASK_NOT_RUNNING
+		0x8004130E: ErrInvalidTask,       //SCHED_E_INVALID_TASK
+		0x80041310: ErrAccountNotFound,   //SCHED_E_ACCOUNT_NAME_NOT_FOUND
+		0x80041315: ErrServiceNotRunning, //SCHED_E_SERVICE_NOT_RUNNING
+		0x80041318: ErrInvalidValue,      //SCHED_E_INVALIDVALUE
+		0x8004131A: ErrMalformedXML,      //SCHED_E_MALFORMEDXML
+		0x8004131E: ErrPastEndBoundary,   //SCHED_E_PAST_END_BOUNDARY
+		0x8004131F: ErrAlreadyRunning,    //SCHED_E_ALREADY_RUNNING
+		0x80041320: ErrUserNotLoggedOn,   //SCHED_E_USER_NOT_LOGGED_ON
+		0x80041326: ErrTaskDisabled,      //SCHED_E_TASK_DISABLED
+		0x80041327: ErrNotV1Compatible,   //SCHED_E_TASK_NOT_V1_COMPAT
+	}
+)
+
+//Error failed schtasks invocation. Failures are told by the exit code
+//alone, errors of well known HRESULT values match the named errors, e.g.
+//errors.Is(err, ErrAccessDenied).
+type Error struct {
+	//Command schtasks command, e.g. /CREATE, other arguments are left out
+	//as they may hold passwords.
+	Command string
+	//Output localized text printed by schtasks, for diagnostics only.
+	Output  string
+	HRESULT uint32
+	Err     error
+}
+
+//Error implements error
+func (e *Error) Error() string {
+	msg := strings.TrimSpace(e.Output)
+	if msg == "" && e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if e.HRESULT != 0 {
+		return fmt.Sprintf("schtasks %s: %s (HRESULT 0x%08X)", e.Command, msg, e.HRESULT)
+	}
+	return fmt.Sprintf("schtasks %s: %s", e.Command, msg)
+}
+
+//Unwrap returns the named error of the HRESULT, or the process error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+//exitCoder error carrying the exit code of a process, e.g. *exec.ExitError
+type exitCoder interface {
+	error
+	ExitCode() int
+}
+
+//newError builds the error of a failed invocation, the exit code holds
+//the HRESULT when /HRESULT was passed
+func newError(args []string, output []byte, err error) error {
+	e := &Error{Output: string(output), Err: err}
+	if len(args) > 0 {
+		e.Command = args[0]
+	}
+
+	var exit exitCoder
+	if errors.As(err, &exit) {
+		code := uint32(exit.ExitCode())
+		if code&0x80000000 != 0 {
+			e.HRESULT = code
+			if named, ok := hresults[code]; ok {
+				e.Err = named
+			}
+		}
+	}
+
+	return e
+}
+
+//withHRESULT appends /HRESULT so the exit code reports the HRESULT, not
+//available in compatibility mode, for help requests nor when the help
+//of the installed schtasks doesn't document it, see Capabilities
+func (task SchTask) withHRESULT(args []string) []string {
+	if task.compatibility || len(args) == 0 {
+		return args
+	}
+	for _, arg := range args {
+		if arg == helpSwitch || arg == hresultSwitch {
+			return args
+		}
+	}
+	//the help requests of the capability probe returned above
+	if !task.Capabilities().HRESULT {
+		return args
+	}
+	return append(args[:len(args):len(args)], hresultSwitch)
+}
@@ -0,0 +1,46 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWithHRESULT(t *testing.T) {
+	args := []string{_Run.Command, _Run.taskname, taskName}
+	if got := New(false).withHRESULT(args); got[len(got)-1] != hresultSwitch || len(args) != 3 {
+		t.Errorf("expected /HRESULT appended without touching the input, got %v", got)
+	}
+	if got := New(true).withHRESULT(args); len(got) != 3 {
+		t.Errorf("compatibility mode must not pass /HRESULT, got %v", got)
+	}
+	if got := New(false).withHRESULT([]string{_Create.Command, helpSwitch}); len(got) != 2 {
+		t.Errorf("help must not pass /HRESULT, got %v", got)
+	}
+
+	var ran [][]string
+	older := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		ran = append(ran, args)
+		if args[1] == helpSwitch {
+			return []byte("SCHTASKS /Query [/S system] [/FO format | /XML [xml_type]] [/NH] [/V] [/TN taskname]"), nil
+		}
+		return nil, nil
+	}))
+	if _, err := older.RunTask(taskName, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := ran[len(ran)-1]; got[len(got)-1] == hresultSwitch {
+		t.Errorf("/HRESULT passed to a schtasks not documenting it: %v", got)
+	}
+}
+
+func TestError(t *testing.T) {
+	err := error(&Error{Command: _Create.Command, Output: "ERROR: Access is denied.\r\n", HRESULT: 0x80070005, Err: ErrAccessDenied})
+	if !errors.Is(err, ErrAccessDenied) {
+		t.Error("expected the named error to match")
+	}
+	if !strings.HasSuffix(err.Error(), "Access is denied. (HRESULT 0x80070005)") {
+		t.Errorf("unexpected message %q", err)
+	}
+}
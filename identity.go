@@ -0,0 +1,84 @@
+package tasker
+
+import "strings"
+
+//TaskIdentity keys of a registered task which, unlike its path, survive
+//renames and folder moves, so external systems can correlate it.
+type TaskIdentity struct {
+	//Path current path of the task
+	Path string
+
+	//URI of the registration info, set when the task was first
+	//registered and kept when its XML is registered again under another
+	//path. "" when the definition has none.
+	URI string
+
+	//ID GUID the Task Scheduler registered the task under in upper case,
+	//read from the TaskCache registry key, the GUID of a ResourceID. ""
+	//on remote hosts and when the key can't be read.
+	ID string
+}
+
+//WithIdentity returns a copy of the tasker filling the URI and ID of
+//the tasks of Query and the details of QueryDetail, at the cost of an
+//additional query per task.
+func (task SchTask) WithIdentity(enabled bool) SchTask {
+	task.identity = enabled
+	return task
+}
+
+//Identity returns the identity of the task, for a Task t:
+//	tasker.Identity(t.Name(), false)
+func (task SchTask) Identity(name string, own bool, opts ...CallOption) (TaskIdentity, error) {
+	task = task.with(opts)
+	path, err := task.resolveName(name, own)
+	if err != nil {
+		return TaskIdentity{}, err
+	}
+	def, err := task.GetTask(path, false)
+	if err != nil {
+		return TaskIdentity{}, err
+	}
+
+	identity := TaskIdentity{Path: path, URI: strings.TrimSpace(def.RegistrationInfo.URI)}
+	if task.host == "" {
+		identity.ID = registrationID(path)
+	}
+	return identity, nil
+}
+
+//identify fills the URI and ID of the details
+func (task SchTask) identify(details []TaskDetail) error {
+	for i := range details {
+		identity, err := task.Identity(details[i].Name, false)
+		if err != nil {
+			return err
+		}
+		details[i].URI, details[i].ID = identity.URI, identity.ID
+	}
+	return nil
+}
+
+//identifyTasks fills the URI and ID of the tasks
+func (task SchTask) identifyTasks(tasks []Task) error {
+	for i := range tasks {
+		identity, err := task.Identity(tasks[i].name, false)
+		if err != nil {
+			return err
+		}
+		tasks[i].uri, tasks[i].id = identity.URI, identity.ID
+	}
+	return nil
+}
+
+//URI of the registration info of the task, only set by taskers
+//WithIdentity, see TaskIdentity
+func (t Task) URI() string {
+	return t.uri
+}
+
+//ID registration GUID of the task, only set by taskers WithIdentity, see
+//TaskIdentity
+func (t Task) ID() string {
+	return t.id
+}
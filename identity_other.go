@@ -0,0 +1,9 @@
+//go:build !windows
+
+package tasker
+
+//registrationID returns the registration GUID of the task, unknown
+//outside windows
+func registrationID(path string) string {
+	return ""
+}
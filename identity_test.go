@@ -0,0 +1,51 @@
+package tasker
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestIdentity(t *testing.T) {
+	registered := strings.Replace(singletonXML, "<URI>\\go-wintask-Test</URI>", "<URI>\\Old\\go-wintask-Test</URI>", 1)
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[0] == _Query.Command && argValue(args, _Query.taskname) != "" && argValue(args, _Query.format) == "":
+			return []byte(registered), nil
+		case strings.Contains(strings.Join(args, " "), " "+_Query.verbose+" "):
+			return []byte(detailCSV), nil
+		}
+		return []byte(`"\go-wintask-Test","N/A","Ready"`), nil
+	}))
+
+	identity, err := task.Identity(taskName, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Path != "\\go-wintask-Test" || identity.URI != "\\Old\\go-wintask-Test" || identity.ID != "" {
+		t.Errorf("Identity() = %+v", identity)
+	}
+
+	details, err := task.WithIdentity(true).QueryDetail(taskName, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details) != 1 || details[0].URI != identity.URI {
+		t.Errorf("QueryDetail() = %+v", details)
+	}
+	if details, _ := task.QueryDetail(taskName, true); len(details) != 1 || details[0].URI != "" {
+		t.Errorf("QueryDetail() without identity = %+v", details)
+	}
+
+	tasks := task.WithIdentity(true).Query(taskName, true)
+	if len(tasks) != 1 || tasks[0].URI() != identity.URI || tasks[0].ID() != identity.ID {
+		t.Errorf("Query() = %+v", tasks)
+	}
+	if data, err := json.Marshal(tasks[0]); err != nil || !strings.Contains(string(data), `"uri":"\\Old\\go-wintask-Test"`) {
+		t.Errorf("json = %s, %v", data, err)
+	}
+	if tasks := task.Query(taskName, true); len(tasks) != 1 || tasks[0].URI() != "" {
+		t.Errorf("Query() without identity = %+v", tasks)
+	}
+}
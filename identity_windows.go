@@ -0,0 +1,38 @@
+//go:build windows
+
+package tasker
+
+import (
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	//taskCacheTree registry key of the registered task paths
+	taskCacheTree = `SOFTWARE\Microsoft\Windows NT\CurrentVersion\Schedule\TaskCache\Tree`
+
+	hkeyLocalMachine = 0x80000002
+	rrfRtRegSz       = 0x00000002
+)
+
+var procRegGetValue = advapi32.NewProc("RegGetValueW")
+
+//registrationID returns the registration GUID of the local task from the
+//Id value of its TaskCache key in upper case, "" when it can't be read
+func registrationID(path string) string {
+	key, err := syscall.UTF16PtrFromString(taskCacheTree + taskPath(path))
+	if err != nil {
+		return ""
+	}
+	value, _ := syscall.UTF16PtrFromString("Id")
+
+	buf := make([]uint16, 64)
+	size := uint32(2 * len(buf))
+	ret, _, _ := procRegGetValue.Call(hkeyLocalMachine, uintptr(unsafe.Pointer(key)), uintptr(unsafe.Pointer(value)),
+		rrfRtRegSz, 0, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if ret != 0 {
+		return ""
+	}
+	return strings.ToUpper(syscall.UTF16ToString(buf))
+}
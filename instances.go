@@ -0,0 +1,124 @@
+package tasker
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//instancesScript prints the running instances of a task through the
+//Task Scheduler COM API, one per line with the tab separated instance
+//id, engine process id and current action
+const instancesScript = `$ErrorActionPreference = 'Stop'
+$service = New-Object -ComObject Schedule.Service
+$service.Connect(%s)
+foreach ($instance in @($service.GetFolder(%s).GetTask(%s).GetInstances(0))) {
+	[Console]::Out.WriteLine("$($instance.InstanceGuid)` + "`t" + `$($instance.EnginePID)` + "`t" + `$($instance.CurrentAction)")
+}
+`
+
+//RunningInstance an active instance of a task
+type RunningInstance struct {
+	//InstanceID identifies the run, the InstanceID of its RunRecord
+	InstanceID string
+
+	//PID process id of the engine running the instance
+	PID int
+
+	//CurrentAction name of the action being performed
+	CurrentAction string
+
+	//Start time the instance started, from the Task Scheduler event log.
+	//Zero when the log is disabled or the task runs on a remote host.
+	Start time.Time
+}
+
+//RunningInstances returns the active instances of the task, none when it
+//is not running.
+func (task SchTask) RunningInstances(name string, own bool) ([]RunningInstance, error) {
+	path, err := task.resolveName(name, own)
+	if err != nil {
+		return nil, err
+	}
+	if task.debugging() {
+		return []RunningInstance{}, nil
+	}
+
+	instances, err := task.instances(path)
+	if err != nil || len(instances) == 0 || task.host != "" {
+		return instances, err
+	}
+
+	//instances started after the running ones may have completed since
+	records, err := task.history(path, len(instances)+8)
+	if err != nil {
+		return nil, err
+	}
+	startTimes(instances, records)
+	return instances, nil
+}
+
+//instances lists the running instances of the task path without their
+//start time
+func (task SchTask) instances(path string) ([]RunningInstance, error) {
+	if !supported {
+		return nil, ErrUnsupportedPlatform
+	}
+
+	output, err := powershell(task.instancesScript(path))
+	if err != nil {
+		return nil, err
+	}
+	return parseInstances(output), nil
+}
+
+//instancesScript returns the script listing the instances of the task
+//path, on the remote host when set
+func (task SchTask) instancesScript(path string) string {
+	host := ""
+	if task.host != "" {
+		host = powershellQuote(task.host)
+	}
+	p := ParseTaskPath(path)
+	return fmt.Sprintf(instancesScript, host, powershellQuote(p.Folder), powershellQuote(p.Name))
+}
+
+//parseInstances reads the instances printed one per line, ignoring
+//those without a process
+func parseInstances(output []byte) []RunningInstance {
+	instances := []RunningInstance{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), "\t", 3)
+		if len(fields) < 2 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[1])
+		if err != nil || pid <= 0 {
+			continue
+		}
+
+		instance := RunningInstance{InstanceID: fields[0], PID: pid}
+		if len(fields) == 3 {
+			instance.CurrentAction = fields[2]
+		}
+		instances = append(instances, instance)
+	}
+	return instances
+}
+
+//startTimes sets the start time of the instances from the runs of the
+//same instance id
+func startTimes(instances []RunningInstance, records []RunRecord) {
+	for i := range instances {
+		for _, record := range records {
+			if strings.EqualFold(record.InstanceID, instances[i].InstanceID) {
+				instances[i].Start = record.Start
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package tasker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInstancesScript(t *testing.T) {
+	script := tasker.instancesScript("\\MyApp\\Bob's Task")
+	if !strings.Contains(script, "GetFolder('\\MyApp').GetTask('Bob''s Task')") || !strings.Contains(script, "Connect()") {
+		t.Errorf("instancesScript() = %s", script)
+	}
+	if script := tasker.instancesScript("\\Test"); !strings.Contains(script, "GetFolder('\\').GetTask('Test')") {
+		t.Errorf("instancesScript() root = %s", script)
+	}
+	if script := tasker.WithRemote("srv01", "").instancesScript("\\Test"); !strings.Contains(script, "Connect('srv01')") {
+		t.Errorf("instancesScript() remote = %s", script)
+	}
+}
+
+func TestParseInstances(t *testing.T) {
+	output := "{A1}\t1234\tnotepad.exe\r\n{B2}\t0\t\r\n\r\n{C3}\t5678\r\n"
+	instances := parseInstances([]byte(output))
+	want := []RunningInstance{{InstanceID: "{A1}", PID: 1234, CurrentAction: "notepad.exe"}, {InstanceID: "{C3}", PID: 5678}}
+	if !reflect.DeepEqual(instances, want) {
+		t.Fatalf("parseInstances() = %+v, want %+v", instances, want)
+	}
+
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	startTimes(instances, []RunRecord{{InstanceID: "{B2}"}, {InstanceID: "{a1}", Start: start}})
+	if !instances[0].Start.Equal(start) || !instances[1].Start.IsZero() {
+		t.Errorf("startTimes() = %+v", instances)
+	}
+}
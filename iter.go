@@ -0,0 +1,99 @@
+package tasker
+
+import (
+	"bufio"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+//QueryIter calls yield for every task matching name, see Query, while
+//the rows stream from schtasks instead of buffering the whole output.
+//The enumeration stops early when yield returns false.
+func (task SchTask) QueryIter(name string, own bool, yield func(Task) bool) (err error) {
+	//replacement backends, snapshots and exact names don't stream
+	filter := Filter{name, own}
+	if task.backend != nil || own && task.snapshot != nil || filter.exact() && !task.compatibility {
+		all, err := task.candidates(filter)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range all {
+			if task.match(filter, t.name) && !yield(t) {
+				return nil
+			}
+		}
+		return nil
+	}
+
+	if !supported {
+		return ErrUnsupportedPlatform
+	}
+
+	args := task.queryArgs(_Query.Command, _Query.format, _Query.formatCSV)
+	if own && task.folder() != "" {
+		args = append(args, _Query.taskname, task.folder()+"\\")
+	}
+	args, err = task.withRemote(args)
+	if err != nil {
+		return err
+	}
+	if task.tracer != nil {
+		end := task.tracer.Start(task.context(), operationOf(args))
+		defer func() {
+			end(exitCode(err), err)
+		}()
+	}
+
+	if err := task.limiter.wait(task.context()); err != nil {
+		return err
+	}
+	cmd := task.command(task.withHRESULT(args)...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr := &strings.Builder{}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		if isMissingBinary(err) {
+			return fmt.Errorf("%w: %s", ErrBinaryNotFound, task.bin)
+		}
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		t, ok := task.parseLine(string(decodeOutput(scanner.Bytes())))
+		if !ok || !task.match(filter, t.name) {
+			continue
+		}
+		if !yield(t) {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return nil
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return newError(args, decodeOutput([]byte(stderr.String())), err)
+	}
+	return scanner.Err()
+}
+
+//Tasks returns an iterator over the tasks matching name, see QueryIter.
+//A failed enumeration is yielded as the last element.
+//	for t, err := range tasker.Tasks("*", true) { ... }
+func (task SchTask) Tasks(name string, own bool) iter.Seq2[Task, error] {
+	return func(yield func(Task, error) bool) {
+		stopped := false
+		err := task.QueryIter(name, own, func(t Task) bool {
+			stopped = !yield(t, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield(Task{}, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package tasker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//checkRandomDelay validates that a random delay is only given for the
+//time based schedules, whose TimeTrigger and CalendarTrigger support it
+func (taskcreate TaskCreate) checkRandomDelay() error {
+	if taskcreate.RandomDelay == 0 {
+		return nil
+	}
+	if taskcreate.RandomDelay < time.Second {
+		return fmt.Errorf("%w: random delay %v", ErrInvalidValue, taskcreate.RandomDelay)
+	}
+
+	switch strings.ToUpper(taskcreate.Schedule) {
+	case Schedules.MINUTE, Schedules.HOURLY, Schedules.DAILY, Schedules.WEEKLY, Schedules.MONTHLY, Schedules.ONCE:
+		return nil
+	}
+	return fmt.Errorf("%w: random delay with schedule %s, only time based schedules support it",
+		ErrInvalidValue, taskcreate.Schedule)
+}
+
+//patchRandomDelay sets the random delay of the time based triggers,
+//reports whether anything changed
+func (taskcreate TaskCreate) patchRandomDelay(root *xmlNode) bool {
+	if taskcreate.RandomDelay <= 0 {
+		return false
+	}
+
+	changed := false
+	triggers := root.ensure("Triggers")
+	for _, kind := range []string{"TimeTrigger", "CalendarTrigger"} {
+		for _, trigger := range triggers.children(kind) {
+			trigger.insert("RandomDelay", "ScheduleByDay", "ScheduleByWeek", "ScheduleByMonth",
+				"ScheduleByMonthDayOfWeek").Text = xmlDuration(taskcreate.RandomDelay)
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,74 @@
+package tasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const calendarXML = `<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <Triggers>
+    <CalendarTrigger>
+      <StartBoundary>2024-06-01T03:00:00</StartBoundary>
+      <Enabled>true</Enabled>
+      <ScheduleByDay><DaysInterval>1</DaysInterval></ScheduleByDay>
+    </CalendarTrigger>
+    <BootTrigger><Enabled>true</Enabled></BootTrigger>
+  </Triggers>
+</Task>`
+
+func TestPatchRandomDelay(t *testing.T) {
+	tc := TaskCreate{Taskname: taskName, Schedule: Schedules.DAILY, RandomDelay: 30 * time.Minute}
+	if !tc.needsPatch() {
+		t.Fatal("needsPatch is false with a random delay")
+	}
+
+	root, err := parseNode([]byte(calendarXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tc.patchDefinition(root) {
+		t.Fatal("patchDefinition reported no change")
+	}
+	calendar := root.child("Triggers").child("CalendarTrigger")
+	if calendar.get("RandomDelay") != "PT30M" || calendar.Nodes[2].XMLName.Local != "RandomDelay" {
+		t.Errorf("CalendarTrigger = %+v", calendar.Nodes)
+	}
+	if root.child("Triggers").child("BootTrigger").child("RandomDelay") != nil {
+		t.Error("RandomDelay set on a boot trigger")
+	}
+
+	data, err := root.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := ParseDefinition([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Triggers.Items[0].RandomDelay != "PT30M" {
+		t.Errorf("Triggers = %+v", def.Triggers.Items)
+	}
+	if !strings.Contains(data, "<RandomDelay>PT30M</RandomDelay>") {
+		t.Errorf("marshal() = %s", data)
+	}
+}
+
+func TestCheckRandomDelay(t *testing.T) {
+	for _, schedule := range []string{Schedules.DAILY, "weekly", Schedules.MONTHLY, Schedules.ONCE} {
+		if err := (TaskCreate{Schedule: schedule, RandomDelay: time.Hour}).checkRandomDelay(); err != nil {
+			t.Errorf("checkRandomDelay(%s) = %v", schedule, err)
+		}
+	}
+	for _, tc := range []TaskCreate{
+		{Schedule: Schedules.ONSTART, RandomDelay: time.Hour},
+		{Schedule: Schedules.ONLOGON, RandomDelay: time.Hour},
+		{Schedule: Schedules.DAILY, RandomDelay: -time.Hour},
+	} {
+		if err := tc.checkRandomDelay(); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("checkRandomDelay(%+v) = %v, want ErrInvalidValue", tc, err)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package tasker
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	jobExt = ".job"
+
+	//jobFixedSize length of the fixed-length section of a .job file
+	jobFixedSize = 68
+	//jobTriggerSize length of a trigger of a .job file
+	jobTriggerSize = 48
+	//jobDisabled TASK_FLAG_DISABLED
+	jobDisabled = 0x4
+)
+
+var (
+	//ErrMalformedJob the .job file is malformed
+	ErrMalformedJob = errors.New("tasker: malformed job file")
+
+	//jobStatuses SCHED_S_TASK_* status values of .job files
+	jobStatuses = map[uint32]Status{
+		0x00041300: StatusReady,
+		0x00041301: StatusRunning,
+		0x00041302: StatusDisabled,
+		0x00041303: StatusReady, //has not run
+		0x00041304: StatusReady, //no more runs
+		0x00041305: StatusReady, //not scheduled
+	}
+
+	//jobSchedules /SC schedule of the .job trigger types
+	jobSchedules = map[uint32]string{
+		0: Schedules.ONCE,
+		1: Schedules.DAILY,
+		2: Schedules.WEEKLY,
+		3: Schedules.MONTHLY,
+		4: Schedules.MONTHLY,
+		5: Schedules.ONIDLE,
+		6: Schedules.ONSTART,
+		7: Schedules.ONLOGON,
+	}
+)
+
+//JobTask legacy Task Scheduler 1.0 task, created by AT or the old API
+//and stored as a .job file. Reported apart from the registered tasks as
+//schtasks and the task XML can't fully manage it.
+type JobTask struct {
+	//Name file name without the extension
+	Name string
+	File string
+
+	Application      string
+	Parameters       string
+	WorkingDirectory string
+	Author           string
+	Comment          string
+
+	Status      Status
+	LastRunTime time.Time
+	ExitCode    uint32
+
+	//Schedules /SC schedule of every trigger, e.g. DAILY
+	Schedules []string
+}
+
+//LegacyJobs reads the .job tasks of %SystemRoot%\Tasks, none when the
+//folder doesn't exist.
+func LegacyJobs() ([]JobTask, error) {
+	root := os.Getenv("SystemRoot")
+	if root == "" {
+		root = defaultSystemRoot
+	}
+	jobs, err := ReadJobs(filepath.Join(root, "Tasks"))
+	if errors.Is(err, os.ErrNotExist) {
+		return []JobTask{}, nil
+	}
+	return jobs, err
+}
+
+//ReadJobs reads every .job file of dir
+func ReadJobs(dir string) ([]JobTask, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []JobTask{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), jobExt) {
+			continue
+		}
+
+		file := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return jobs, err
+		}
+		job, err := ParseJob(data)
+		if err != nil {
+			return jobs, fmt.Errorf("%s: %w", file, err)
+		}
+		job.Name = strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		job.File = file
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+//ParseJob parses the content of a .job file as documented by [MS-TSCH]
+//2.4, the name is left to the caller
+func ParseJob(data []byte) (JobTask, error) {
+	if len(data) < jobFixedSize {
+		return JobTask{}, fmt.Errorf("%w: %d bytes", ErrMalformedJob, len(data))
+	}
+	le := binary.LittleEndian
+
+	job := JobTask{
+		ExitCode:    le.Uint32(data[40:]),
+		LastRunTime: systemTime(data[52:68]),
+		Schedules:   []string{},
+	}
+	job.Status = jobStatuses[le.Uint32(data[44:])]
+	if job.Status == "" {
+		job.Status = StatusUnknown
+	}
+	if le.Uint32(data[48:])&jobDisabled != 0 {
+		job.Status = StatusDisabled
+	}
+
+	reader := jobReader{data: data, offset: jobFixedSize}
+	reader.uint16() //running instance count
+	for _, field := range []*string{&job.Application, &job.Parameters, &job.WorkingDirectory, &job.Author, &job.Comment} {
+		*field = reader.text()
+	}
+	reader.skip(int(reader.uint16())) //user data
+	reader.skip(int(reader.uint16())) //reserved data
+
+	count := int(reader.uint16())
+	for i := 0; i < count; i++ {
+		trigger := reader.bytes(jobTriggerSize)
+		if trigger == nil {
+			break
+		}
+		if schedule, ok := jobSchedules[le.Uint32(trigger[32:])]; ok {
+			job.Schedules = append(job.Schedules, schedule)
+		}
+	}
+
+	if reader.err != nil {
+		return JobTask{}, reader.err
+	}
+	return job, nil
+}
+
+//systemTime decodes a SYSTEMTIME in local time, zero when never set
+func systemTime(data []byte) time.Time {
+	le := binary.LittleEndian
+	year := int(le.Uint16(data))
+	if year == 0 {
+		return time.Time{}
+	}
+	return time.Date(year, time.Month(le.Uint16(data[2:])), int(le.Uint16(data[6:])),
+		int(le.Uint16(data[8:])), int(le.Uint16(data[10:])), int(le.Uint16(data[12:])),
+		int(le.Uint16(data[14:]))*int(time.Millisecond), time.Local)
+}
+
+//jobReader reads the variable-length section of a .job file, the first
+//read past the end sets err
+type jobReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+//bytes returns the next n bytes, nil past the end
+func (r *jobReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.offset+n > len(r.data) {
+		r.err = fmt.Errorf("%w: truncated at offset %d", ErrMalformedJob, r.offset)
+		return nil
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *jobReader) skip(n int) {
+	r.bytes(n)
+}
+
+func (r *jobReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+//text reads a string prefixed by its length in characters, including
+//the terminating null
+func (r *jobReader) text() string {
+	b := r.bytes(2 * int(r.uint16()))
+	chars := make([]uint16, len(b)/2)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return strings.TrimRight(string(utf16.Decode(chars)), "\x00")
+}
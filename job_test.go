@@ -0,0 +1,92 @@
+package tasker
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+//jobFile builds a .job file running application with the trigger types
+func jobFile(status, flags uint32, application string, triggers ...uint32) []byte {
+	le := binary.LittleEndian
+	data := make([]byte, jobFixedSize)
+	le.PutUint32(data[40:], 1)
+	le.PutUint32(data[44:], status)
+	le.PutUint32(data[48:], flags)
+	for i, v := range []uint16{2026, 1, 1, 5, 10, 30, 0, 0} {
+		le.PutUint16(data[52+2*i:], v)
+	}
+
+	data = le.AppendUint16(data, 0)
+	for _, text := range []string{application, "/quiet", "C:\\app", "bob", ""} {
+		chars := utf16.Encode([]rune(text + "\x00"))
+		data = le.AppendUint16(data, uint16(len(chars)))
+		for _, c := range chars {
+			data = le.AppendUint16(data, c)
+		}
+	}
+	data = le.AppendUint16(data, 2)
+	data = append(data, 0xAA, 0xBB)
+	data = le.AppendUint16(data, 0)
+
+	data = le.AppendUint16(data, uint16(len(triggers)))
+	for _, kind := range triggers {
+		trigger := make([]byte, jobTriggerSize)
+		le.PutUint16(trigger, jobTriggerSize)
+		le.PutUint32(trigger[32:], kind)
+		data = append(data, trigger...)
+	}
+	return data
+}
+
+func TestParseJob(t *testing.T) {
+	job, err := ParseJob(jobFile(0x00041300, 0, "C:\\app\\backup.exe", 1, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := JobTask{
+		Application:      "C:\\app\\backup.exe",
+		Parameters:       "/quiet",
+		WorkingDirectory: "C:\\app",
+		Author:           "bob",
+		Status:           StatusReady,
+		LastRunTime:      time.Date(2026, 1, 5, 10, 30, 0, 0, time.Local),
+		ExitCode:         1,
+		Schedules:        []string{Schedules.DAILY, Schedules.ONSTART},
+	}
+	if !reflect.DeepEqual(job, want) {
+		t.Errorf("ParseJob() = %+v, want %+v", job, want)
+	}
+
+	if job, _ := ParseJob(jobFile(0x00041300, jobDisabled, "x.exe")); job.Status != StatusDisabled {
+		t.Errorf("ParseJob() disabled = %v", job.Status)
+	}
+	data := jobFile(0x00041301, 0, "x.exe", 2)
+	if _, err := ParseJob(data[:len(data)-1]); !errors.Is(err, ErrMalformedJob) {
+		t.Errorf("ParseJob() truncated = %v", err)
+	}
+}
+
+func TestReadJobs(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "At1.job"), jobFile(0x00041301, 0, "a.exe", 0), 0644)
+	os.WriteFile(filepath.Join(dir, "desktop.ini"), []byte("[.ShellClassInfo]"), 0644)
+
+	jobs, err := ReadJobs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Name != "At1" || jobs[0].Status != StatusRunning || jobs[0].File != filepath.Join(dir, "At1.job") {
+		t.Errorf("ReadJobs() = %+v", jobs)
+	}
+
+	t.Setenv("SystemRoot", filepath.Join(dir, "missing"))
+	if jobs, err := LegacyJobs(); err != nil || len(jobs) != 0 {
+		t.Errorf("LegacyJobs() = %+v, %v", jobs, err)
+	}
+}
@@ -0,0 +1,96 @@
+package tasker
+
+import (
+	"encoding/json"
+	"time"
+)
+
+//MarshalJSON encodes the task with normalized field names and the next
+//run time as RFC3339, null when not scheduled.
+func (t Task) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Host        string     `json:"host,omitempty"`
+		Name        string     `json:"name"`
+		NextRunTime *time.Time `json:"nextRunTime"`
+		Status      string     `json:"status"`
+		URI         string     `json:"uri,omitempty"`
+		ID          string     `json:"id,omitempty"`
+	}{
+		Host:        t.host,
+		Name:        t.name,
+		NextRunTime: jsonTime(parseTime(t.datetime)),
+		Status:      string(t.Status()),
+		URI:         t.uri,
+		ID:          t.id,
+	})
+}
+
+//MarshalJSON encodes the task detail with normalized field names and
+//RFC3339 timestamps, null when not available.
+func (d TaskDetail) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Host         string     `json:"host"`
+		Name         string     `json:"name"`
+		NextRunTime  *time.Time `json:"nextRunTime"`
+		Status       string     `json:"status"`
+		LogonMode    string     `json:"logonMode"`
+		LastRunTime  *time.Time `json:"lastRunTime"`
+		LastResult   int        `json:"lastResult"`
+		Author       string     `json:"author"`
+		TaskToRun    string     `json:"taskToRun"`
+		StartIn      string     `json:"startIn"`
+		Comment      string     `json:"comment"`
+		State        string     `json:"state"`
+		RunAsUser    string     `json:"runAsUser"`
+		ScheduleType string     `json:"scheduleType"`
+		StartTime    string     `json:"startTime"`
+		StartDate    string     `json:"startDate"`
+		EndDate      string     `json:"endDate"`
+		Days         string     `json:"days"`
+		Months       string     `json:"months"`
+		RepeatEvery  string     `json:"repeatEvery"`
+		URI          string     `json:"uri,omitempty"`
+		ID           string     `json:"id,omitempty"`
+	}{
+		Host:         d.Host,
+		Name:         d.Name,
+		NextRunTime:  jsonTime(d.NextRunTime),
+		Status:       string(d.Status),
+		LogonMode:    d.LogonMode,
+		LastRunTime:  jsonTime(d.LastRunTime),
+		LastResult:   d.LastResult,
+		Author:       d.Author,
+		TaskToRun:    d.TaskToRun,
+		StartIn:      d.StartIn,
+		Comment:      d.Comment,
+		State:        d.State,
+		RunAsUser:    d.RunAsUser,
+		ScheduleType: d.ScheduleType,
+		StartTime:    d.StartTime,
+		StartDate:    d.StartDate,
+		EndDate:      d.EndDate,
+		Days:         d.Days,
+		Months:       d.Months,
+		RepeatEvery:  d.RepeatEvery,
+		URI:          d.URI,
+		ID:           d.ID,
+	})
+}
+
+//QueryJSON returns the verbose details of the tasks matching name as a
+//JSON array, see QueryDetail.
+func (task SchTask) QueryJSON(name string, own bool) ([]byte, error) {
+	details, err := task.QueryDetail(name, own)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(details)
+}
+
+//jsonTime returns nil for the zero time so it encodes as null
+func jsonTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,61 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	//SCHED_S_* statuses reported as Last Result instead of an exit code
+	schedTaskRunning   = 0x41301
+	schedTaskHasNotRun = 0x41303
+)
+
+var (
+	//ErrNeverRun the task has not completed a run yet
+	ErrNeverRun = errors.New("tasker: task has not run yet")
+)
+
+//LastExitCode returns the exit code of the program started by the most
+//recent completed run of the task, or its launch failure code when it
+//failed to start. The operational log of the target system is preferred
+//when it is enabled, otherwise the Last Result of the verbose query is
+//used.
+func (task SchTask) LastExitCode(name string, own bool) (int, error) {
+	name, err := task.resolveName(name, own)
+	if err != nil {
+		return 0, err
+	}
+
+	args := task.queryArgs(_Query.Command, _Query.taskname, name, _Query.format, _Query.formatCSV, _Query.verbose)
+	output, err := task.execute(args...)
+	if err != nil {
+		return 0, err
+	}
+	details := task.parseDetail(output)
+	if len(details) == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	//the newest record may be the run still in progress
+	if records, err := task.history(name, 2); err == nil {
+		for _, record := range records {
+			if !record.Completed {
+				continue
+			}
+			if record.Result != 0 {
+				return record.Result, nil
+			}
+			return record.ExitCode, nil
+		}
+	}
+
+	switch result := details[0].LastResult; result {
+	case schedTaskHasNotRun:
+		return 0, fmt.Errorf("%w: %s", ErrNeverRun, name)
+	case schedTaskRunning:
+		return 0, fmt.Errorf("%w: %s is running its first instance", ErrNeverRun, name)
+	default:
+		return result, nil
+	}
+}
@@ -0,0 +1,50 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLastExitCode(t *testing.T) {
+	output := detailCSV
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[1] == helpSwitch {
+			return nil, exitError(1)
+		}
+		if !strings.Contains(strings.Join(args, " "), "/TN \\go-wintask-Test /FO CSV /V") {
+			t.Errorf("unexpected query %v", args)
+		}
+		return []byte(output), nil
+	}))
+
+	//the backend doesn't read the event log, Last Result is used
+	if _, err := task.LastExitCode(taskName, true); !errors.Is(err, ErrNeverRun) {
+		t.Errorf("LastExitCode() never run = %v, want ErrNeverRun", err)
+	}
+
+	output = strings.Replace(detailCSV, `"267011"`, `"3"`, 1)
+	if code, err := task.LastExitCode(taskName, true); code != 3 || err != nil {
+		t.Errorf("LastExitCode() = %d, %v, want 3", code, err)
+	}
+
+	output = ""
+	if _, err := task.LastExitCode(taskName, true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LastExitCode() without rows = %v, want ErrNotFound", err)
+	}
+
+	//a run in progress, one failing to start and one exiting with 5
+	events := []string{logEvent(100, 0, ""), logEvent(101, 1, "0x80070002"), logEvent(201, 2, "5"), logEvent(102, 2, "")}
+	output = detailCSV
+	logged := task.WithBackend(eventBackend{task.Backend().(backendFunc), func(args []string) ([]byte, error) {
+		return []byte(strings.Join(events, "\n")), nil
+	}})
+	if code, err := logged.LastExitCode(taskName, true); code != -2147024894 || err != nil {
+		t.Errorf("LastExitCode() after a launch failure = %d, %v, want -2147024894", code, err)
+	}
+	events = append(events[:1], events[2:]...)
+	if code, err := logged.LastExitCode(taskName, true); code != 5 || err != nil {
+		t.Errorf("LastExitCode() from the event log = %d, %v, want 5", code, err)
+	}
+}
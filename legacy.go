@@ -0,0 +1,50 @@
+package tasker
+
+//EmailAction legacy SendEmail action, the element order follows the task
+//schema
+type EmailAction struct {
+	Server      string   `xml:"Server"`
+	Subject     string   `xml:"Subject,omitempty"`
+	To          string   `xml:"To,omitempty"`
+	Cc          string   `xml:"Cc,omitempty"`
+	Bcc         string   `xml:"Bcc,omitempty"`
+	ReplyTo     string   `xml:"ReplyTo,omitempty"`
+	From        string   `xml:"From"`
+	Body        string   `xml:"Body,omitempty"`
+	Attachments []string `xml:"Attachments>File,omitempty"`
+}
+
+//MessageAction legacy ShowMessage action
+type MessageAction struct {
+	Title string `xml:"Title"`
+	Body  string `xml:"Body"`
+}
+
+//patch writes the action into its SendEmail element
+func (email EmailAction) patch(node *xmlNode) {
+	node.set(email.Server, "Server")
+	for _, field := range []struct{ name, value string }{
+		{"Subject", email.Subject}, {"To", email.To}, {"Cc", email.Cc},
+		{"Bcc", email.Bcc}, {"ReplyTo", email.ReplyTo},
+	} {
+		if field.value != "" {
+			node.set(field.value, field.name)
+		}
+	}
+	node.set(email.From, "From")
+	if email.Body != "" {
+		node.set(email.Body, "Body")
+	}
+	if len(email.Attachments) > 0 {
+		attachments := node.add("Attachments")
+		for _, file := range email.Attachments {
+			attachments.add("File").Text = file
+		}
+	}
+}
+
+//patch writes the action into its ShowMessage element
+func (message MessageAction) patch(node *xmlNode) {
+	node.set(message.Title, "Title")
+	node.set(message.Body, "Body")
+}
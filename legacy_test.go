@@ -0,0 +1,68 @@
+package tasker
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLegacyActions(t *testing.T) {
+	tc := TaskCreate{
+		Taskname: taskName,
+		Taskrun:  "notepad.exe",
+		Email: &EmailAction{
+			Server:      "smtp.example.com",
+			Subject:     "done",
+			To:          "ops@example.com",
+			From:        "tasks@example.com",
+			Body:        "the task ran",
+			Attachments: []string{`C:\logs\run.log`},
+		},
+		Message: &MessageAction{Title: "go-wintask", Body: "hello"},
+	}
+	if !tc.needsPatch() {
+		t.Fatal("needsPatch is false with legacy actions")
+	}
+
+	root, err := parseNode([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//patching twice replaces instead of appending
+	tc.patchDefinition(root)
+	if !tc.patchDefinition(root) {
+		t.Fatal("patchDefinition reported no change")
+	}
+
+	data, err := root.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := -1
+	for _, element := range []string{"<Server>", "<Subject>", "<To>", "<From>", "<Body>", "<Attachments>"} {
+		i := strings.Index(data, element)
+		if i < last {
+			t.Errorf("%s out of schema order: %s", element, data)
+		}
+		last = i
+	}
+	def, err := ParseDefinition([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	actions := def.Actions
+	if len(actions.Exec) != 1 || len(actions.SendEmail) != 1 || len(actions.ShowMessage) != 1 {
+		t.Fatalf("actions = %+v", actions)
+	}
+	if !reflect.DeepEqual(actions.SendEmail[0], *tc.Email) {
+		t.Errorf("SendEmail = %+v, want %+v", actions.SendEmail[0], *tc.Email)
+	}
+	if actions.ShowMessage[0] != *tc.Message {
+		t.Errorf("ShowMessage = %+v, want %+v", actions.ShowMessage[0], *tc.Message)
+	}
+
+	if err := tasker.ValidateV1(tc, true); !errors.Is(err, ErrNotV1Compatible) {
+		t.Errorf("ValidateV1 = %v, want ErrNotV1Compatible", err)
+	}
+}
@@ -0,0 +1,148 @@
+package tasker
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	//runLimitID id of the action counting the runs
+	runLimitID = "RunLimit"
+)
+
+//runLimitScript counts the runs in a file of %ProgramData% and disables
+//or deletes the task on the last one
+const runLimitScript = `$file = Join-Path $env:ProgramData %s
+$runs = 1 + [int](Get-Content -LiteralPath $file -ErrorAction SilentlyContinue)
+if ($runs -lt %d) {
+	New-Item -ItemType Directory -Force -Path (Split-Path $file) | Out-Null
+	Set-Content -LiteralPath $file -Value $runs
+	exit
+}
+Remove-Item -LiteralPath $file -ErrorAction SilentlyContinue
+$service = New-Object -ComObject Schedule.Service
+$service.Connect()
+$folder = $service.GetFolder(%s)
+%s
+`
+
+//RunLimit stops a task after a number of runs, see CreateLimited
+type RunLimit struct {
+	//Runs number of runs, at least 1
+	Runs int
+
+	//Delete deletes the task after its last run instead of disabling it
+	Delete bool
+
+	//path registered path of the task
+	path string
+}
+
+//CreateLimited creates the owned task like CreateTask, disabling or
+//deleting it after limit.Runs runs, e.g. to retry an installer three
+//times after reboots. An action appended to the task counts the runs in
+//%ProgramData%\go-wintask\runs. MINUTE, HOURLY and DAILY schedules with
+//a StartBoundary additionally expire after the last run, which stops
+//them even when the counter can't be written, and are deleted by the
+//Task Scheduler with limit.Delete.
+func (task SchTask) CreateLimited(taskcreate TaskCreate, limit RunLimit, opts ...CallOption) (string, error) {
+	task = task.with(opts)
+	if limit.Runs < 1 {
+		return "", fmt.Errorf("%w: run limit %d", ErrInvalidValue, limit.Runs)
+	}
+	name, err := task.resolveName(taskcreate.Taskname, true)
+	if err != nil {
+		return "", err
+	}
+
+	limit.path = name
+	taskcreate.runLimit = &limit
+	if taskcreate.EndBoundary.IsZero() {
+		taskcreate.EndBoundary = taskcreate.lastRunBoundary(limit.Runs)
+	}
+	return task.CreateTask(taskcreate)
+}
+
+//lastRunBoundary returns an expiration between the last run allowed and
+//the next one, zero when the starts are unknown in advance
+func (taskcreate TaskCreate) lastRunBoundary(runs int) time.Time {
+	if taskcreate.StartBoundary.IsZero() || taskcreate.Interval != "" {
+		return time.Time{}
+	}
+
+	modifier := 1
+	if taskcreate.Modifier != "" {
+		n, err := strconv.Atoi(taskcreate.Modifier)
+		if err != nil || n < 1 {
+			return time.Time{}
+		}
+		modifier = n
+	}
+	var period time.Duration
+	switch strings.ToUpper(taskcreate.Schedule) {
+	case Schedules.MINUTE:
+		period = time.Duration(modifier) * time.Minute
+	case Schedules.HOURLY:
+		period = time.Duration(modifier) * time.Hour
+	case Schedules.DAILY:
+		period = time.Duration(modifier) * 24 * time.Hour
+	default:
+		return time.Time{}
+	}
+	return taskcreate.StartBoundary.Add(time.Duration(runs-1)*period + period/2)
+}
+
+//patchRunLimit appends the action counting the runs, reports whether
+//anything changed
+func (taskcreate TaskCreate) patchRunLimit(root *xmlNode) bool {
+	limit := taskcreate.runLimit
+	if limit == nil {
+		return false
+	}
+
+	actions := root.ensure("Actions")
+	kept := []*xmlNode{}
+	for _, node := range actions.Nodes {
+		if node.attr("id") != runLimitID {
+			kept = append(kept, node)
+		}
+	}
+	actions.Nodes = kept
+
+	exec := actions.add("Exec")
+	exec.Attrs = append(exec.Attrs, xml.Attr{Name: xml.Name{Local: "id"}, Value: runLimitID})
+	exec.set(powershellCommand, "Command")
+	exec.set("-NoProfile -NonInteractive -EncodedCommand "+encodeCommand(limit.script()), "Arguments")
+
+	if limit.Delete && !taskcreate.EndBoundary.IsZero() {
+		root.ensure("Settings", "DeleteExpiredTaskAfter").Text = "PT0S"
+	}
+	return true
+}
+
+//script returns the PowerShell script counting the runs of the task
+func (limit RunLimit) script() string {
+	p := ParseTaskPath(limit.path)
+	stop := fmt.Sprintf("$folder.GetTask(%s).Enabled = $false", powershellQuote(p.Name))
+	if limit.Delete {
+		stop = fmt.Sprintf("$folder.DeleteTask(%s, 0)", powershellQuote(p.Name))
+	}
+	file := "go-wintask\\runs\\" + strings.ReplaceAll(strings.Trim(limit.path, "\\"), "\\", "_") + ".count"
+	return fmt.Sprintf(runLimitScript, powershellQuote(file), limit.Runs, powershellQuote(p.Folder), stop)
+}
+
+//encodeCommand encodes script for -EncodedCommand, base64 of UTF-16LE,
+//sparing the quoting of the action arguments
+func encodeCommand(script string) string {
+	chars := utf16.Encode([]rune(script))
+	data := make([]byte, 2*len(chars))
+	for i, c := range chars {
+		data[2*i], data[2*i+1] = byte(c), byte(c>>8)
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
@@ -0,0 +1,99 @@
+package tasker
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+func TestCreateLimited(t *testing.T) {
+	var created []string
+	registered := singletonXML
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[1] == helpSwitch:
+			//no help, the capabilities keep their defaults
+			return nil, exitError(1)
+		case args[0] == _Query.Command:
+			return []byte(registered), nil
+		case args[0] == _Create.Command:
+			created = append(created, strings.Join(args, " "))
+			if file := argValue(args, _Create.xml); file != "" {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				registered = decodeUTF16(data)
+			}
+		}
+		return nil, nil
+	}))
+
+	start := time.Date(2026, 11, 1, 3, 0, 0, 0, time.Local)
+	tc := TaskCreate{Taskname: taskName, Taskrun: executable, Schedule: Schedules.DAILY, StartBoundary: start}
+	if _, err := task.CreateLimited(tc, RunLimit{Runs: 3, Delete: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("CreateLimited() ran %v", created)
+	}
+
+	root, err := parseNode([]byte(registered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := root.get("Settings", "DeleteExpiredTaskAfter"); got != "PT0S" {
+		t.Errorf("DeleteExpiredTaskAfter = %q", got)
+	}
+	execs := root.child("Actions").children("Exec")
+	if len(execs) != 2 || execs[1].attr("id") != runLimitID || execs[1].get("Command") != powershellCommand {
+		t.Fatalf("Actions = %+v", execs)
+	}
+	script := decodeCommand(t, strings.TrimPrefix(execs[1].get("Arguments"), "-NoProfile -NonInteractive -EncodedCommand "))
+	for _, want := range []string{"'go-wintask\\runs\\go-wintask-Test.count'", "-lt 3", "GetFolder('\\')", "DeleteTask('go-wintask-Test', 0)"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script lacks %q:\n%s", want, script)
+		}
+	}
+
+	if _, err := task.CreateLimited(tc, RunLimit{}); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("CreateLimited() without runs = %v", err)
+	}
+}
+
+func TestLastRunBoundary(t *testing.T) {
+	start := time.Date(2026, 11, 1, 3, 0, 0, 0, time.UTC)
+	cases := []struct {
+		taskcreate TaskCreate
+		want       time.Time
+	}{
+		{TaskCreate{Schedule: Schedules.DAILY, StartBoundary: start}, start.Add(60 * time.Hour)},
+		{TaskCreate{Schedule: Schedules.MINUTE, Modifier: "10", StartBoundary: start}, start.Add(25 * time.Minute)},
+		{TaskCreate{Schedule: Schedules.HOURLY, StartBoundary: start, Interval: "10"}, time.Time{}},
+		{TaskCreate{Schedule: Schedules.ONSTART, StartBoundary: start}, time.Time{}},
+		{TaskCreate{Schedule: Schedules.DAILY}, time.Time{}},
+	}
+	for _, c := range cases {
+		if got := c.taskcreate.lastRunBoundary(3); !got.Equal(c.want) {
+			t.Errorf("lastRunBoundary(%+v) = %v, want %v", c.taskcreate, got, c.want)
+		}
+	}
+}
+
+//decodeCommand decodes the script of -EncodedCommand
+func decodeCommand(t *testing.T, encoded string) string {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chars := make([]uint16, len(data)/2)
+	for i := range chars {
+		chars[i] = uint16(data[2*i]) | uint16(data[2*i+1])<<8
+	}
+	return string(utf16.Decode(chars))
+}
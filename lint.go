@@ -0,0 +1,133 @@
+package tasker
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//LintCode identifies the kind of a Lint finding, stable across releases
+//so deployment pipelines can gate on or allow single codes
+type LintCode string
+
+var (
+	//LintCodes of the Lint findings
+	LintCodes = struct {
+		//ELEVATEDPASSWORD the task runs with the highest run level and a
+		//stored password, a service account or S4U is safer
+		ELEVATEDPASSWORD LintCode
+
+		//ARGUMENTSINTASKRUN the program holds its arguments, which then
+		//become part of the quoted path
+		ARGUMENTSINTASKRUN LintCode
+
+		//IGNOREDINTERVAL a repetition interval the schedule ignores
+		IGNOREDINTERVAL LintCode
+
+		//NOTIMELIMIT a frequent task whose runs aren't limited to the
+		//interval, a hung run blocks the following ones
+		NOTIMELIMIT LintCode
+	}{
+		ELEVATEDPASSWORD:   "TL001",
+		ARGUMENTSINTASKRUN: "TL002",
+		IGNOREDINTERVAL:    "TL003",
+		NOTIMELIMIT:        "TL004",
+	}
+)
+
+//frequentInterval repetition interval up to which a task counts as
+//frequent
+const frequentInterval = time.Hour
+
+//argumentsPattern matches a program path followed by arguments
+var argumentsPattern = regexp.MustCompile(`(?i)\.(exe|com|bat|cmd|ps1|vbs)\s+\S`)
+
+//LintFinding advisory diagnostic of Lint, the task may still be valid
+type LintFinding struct {
+	Code    LintCode
+	Field   string
+	Message string
+}
+
+//String renders the finding as "TL001 Level: message"
+func (finding LintFinding) String() string {
+	return fmt.Sprintf("%s %s: %s", finding.Code, finding.Field, finding.Message)
+}
+
+//Lint reports suspicious combinations of options which schtasks accepts
+//but rarely does what was meant, going beyond the validation of
+//CreateTask. It runs offline, without the Task Scheduler.
+func Lint(taskcreate TaskCreate) []LintFinding {
+	findings := []LintFinding{}
+	schedule := strings.ToUpper(taskcreate.Schedule)
+
+	if strings.EqualFold(taskcreate.Level, Level.HIGHEST) && !isServiceAccount(taskcreate.Username) &&
+		(taskcreate.Password != "" || taskcreate.Credential != "" || taskcreate.LogonMode == LogonModes.PASSWORD) {
+		findings = append(findings, LintFinding{LintCodes.ELEVATEDPASSWORD, "Level",
+			"the elevated task stores a password, prefer a service account or the S4U logon mode"})
+	}
+
+	if argumentsPattern.MatchString(taskcreate.Taskrun) {
+		findings = append(findings, LintFinding{LintCodes.ARGUMENTSINTASKRUN, "Taskrun",
+			fmt.Sprintf("%q is quoted as a whole, pass the arguments in Arguments", taskcreate.Taskrun)})
+	}
+
+	switch schedule {
+	case Schedules.MINUTE, Schedules.HOURLY, Schedules.ONSTART, Schedules.ONLOGON, Schedules.ONIDLE, Schedules.ONEVENT:
+		if taskcreate.Interval != "" {
+			findings = append(findings, LintFinding{LintCodes.IGNOREDINTERVAL, "Interval",
+				fmt.Sprintf("the %s schedule doesn't repeat by interval", schedule)})
+		}
+	}
+
+	frequent := schedule == Schedules.MINUTE || schedule == Schedules.HOURLY
+	if minutes, err := strconv.Atoi(taskcreate.Interval); err == nil && time.Duration(minutes)*time.Minute <= frequentInterval {
+		frequent = true
+	}
+	if frequent && taskcreate.Preempt <= 0 {
+		findings = append(findings, LintFinding{LintCodes.NOTIMELIMIT, "Preempt",
+			"the runs of the frequent task have no time limit, a hung run blocks the following ones"})
+	}
+
+	return findings
+}
+
+//LintDefinition is Lint for a task XML definition, e.g. read by GetTask
+//or kept in a deployment repository.
+func LintDefinition(def TaskDefinition) []LintFinding {
+	findings := []LintFinding{}
+
+	principal := def.Principal()
+	if principal.RunLevel == "HighestAvailable" && principal.LogonType == "Password" {
+		findings = append(findings, LintFinding{LintCodes.ELEVATEDPASSWORD, "Principal",
+			"the elevated task stores a password, prefer a service account or the S4U logon type"})
+	}
+
+	for i, exec := range def.Actions.Exec {
+		if argumentsPattern.MatchString(strings.Trim(exec.Command, "\"")) {
+			findings = append(findings, LintFinding{LintCodes.ARGUMENTSINTASKRUN, fmt.Sprintf("Actions.Exec[%d].Command", i),
+				fmt.Sprintf("%q holds arguments, pass them in Arguments", exec.Command)})
+		}
+	}
+
+	interval := time.Duration(0)
+	for _, trigger := range def.Triggers.Items {
+		if trigger.Repetition == nil {
+			continue
+		}
+		if d, err := parseXMLDuration(trigger.Repetition.Interval); err == nil && d > 0 && (interval == 0 || d < interval) {
+			interval = d
+		}
+	}
+	if interval > 0 && interval <= frequentInterval {
+		limit, err := parseXMLDuration(def.Settings.ExecutionTimeLimit)
+		if def.Settings.ExecutionTimeLimit == "" || err != nil || limit == 0 || limit > interval {
+			findings = append(findings, LintFinding{LintCodes.NOTIMELIMIT, "Settings.ExecutionTimeLimit",
+				fmt.Sprintf("the runs aren't limited to the %v interval, a hung run blocks the following ones", interval)})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,65 @@
+package tasker
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+//lintCodes returns the codes of the findings
+func lintCodes(findings []LintFinding) string {
+	codes := []string{}
+	for _, finding := range findings {
+		codes = append(codes, string(finding.Code))
+	}
+	return strings.Join(codes, ",")
+}
+
+func TestLint(t *testing.T) {
+	cases := []struct {
+		taskcreate TaskCreate
+		want       string
+	}{
+		{TaskCreate{Taskrun: executable, Schedule: Schedules.DAILY}, ""},
+		{TaskCreate{Taskrun: executable, Level: Level.HIGHEST, Username: "bob", Password: "x"}, "TL001"},
+		{TaskCreate{Taskrun: executable, Level: Level.HIGHEST, Username: "SYSTEM"}, ""},
+		{TaskCreate{Taskrun: "C:\\Program Files\\App\\app.exe --quiet"}, "TL002"},
+		{TaskCreate{Taskrun: "C:\\Program Files\\App\\app.exe"}, ""},
+		{TaskCreate{Taskrun: executable, Schedule: Schedules.HOURLY, Interval: "30"}, "TL003,TL004"},
+		{TaskCreate{Taskrun: executable, Schedule: Schedules.MINUTE, Modifier: "5", Preempt: 4 * time.Minute}, ""},
+		{TaskCreate{Taskrun: executable, Schedule: Schedules.DAILY, Interval: "600"}, ""},
+	}
+	for _, c := range cases {
+		if got := lintCodes(Lint(c.taskcreate)); got != c.want {
+			t.Errorf("Lint(%+v) = %q, want %q", c.taskcreate, got, c.want)
+		}
+	}
+
+	finding := Lint(TaskCreate{Taskrun: executable, Schedule: Schedules.MINUTE})[0]
+	if !strings.HasPrefix(finding.String(), "TL004 Preempt: ") {
+		t.Errorf("unexpected finding %s", finding)
+	}
+}
+
+func TestLintDefinition(t *testing.T) {
+	def, err := ParseDefinition([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findings := LintDefinition(def); len(findings) != 0 {
+		t.Errorf("LintDefinition() = %v", findings)
+	}
+
+	def.Principals = []Principal{{RunLevel: "HighestAvailable", LogonType: "Password"}}
+	def.Actions.Exec = []ExecAction{{Command: "app.exe /quiet"}}
+	def.Triggers.Items = []Trigger{{Repetition: &Repetition{Interval: "PT15M"}}}
+	def.Settings.ExecutionTimeLimit = "PT72H"
+	if got := lintCodes(LintDefinition(def)); got != "TL001,TL002,TL004" {
+		t.Errorf("LintDefinition() = %q", got)
+	}
+
+	def.Settings.ExecutionTimeLimit = "PT10M"
+	if got := lintCodes(LintDefinition(def)); got != "TL001,TL002" {
+		t.Errorf("LintDefinition() limited = %q", got)
+	}
+}
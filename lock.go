@@ -0,0 +1,70 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	//defaultLockName machine lock of WithMachineLock without a name
+	defaultLockName = "go-wintask"
+)
+
+//ErrLocked another process held the machine lock for the whole timeout,
+//see WithMachineLock
+var ErrLocked = errors.New("tasker: machine lock held by another process")
+
+//machineLock lock serializing the changes of the processes sharing name
+type machineLock struct {
+	name    string
+	timeout time.Duration
+}
+
+//WithMachineLock returns a copy of the tasker serializing its task
+//creations, changes and deletions with every process locking name, e.g.
+//an application and its updater registering the same tasks. Every
+//command changing a task takes the lock, operations of several commands
+//like CreateTask or EnsureTrigger hold it throughout. A call waits up to
+//timeout for the lock, then fails with ErrLocked. On windows
+//the lock is the named mutex Global\name, released by the system when
+//its holder dies, elsewhere a lock file of the temporary directory. An
+//empty name locks "go-wintask".
+func (task SchTask) WithMachineLock(name string, timeout time.Duration) SchTask {
+	if name == "" {
+		name = defaultLockName
+	}
+	task.machineLock = &machineLock{name: name, timeout: timeout}
+	return task
+}
+
+//lock acquires the machine lock of the tasker, returning a copy holding
+//it, unlock releases it. Taskers without one or already holding it only
+//get a no-op unlock, so the commands of an operation share the lock.
+func (task SchTask) lock() (_ SchTask, unlock func(), err error) {
+	if task.machineLock == nil || task.lockHeld || task.debugging() {
+		return task, func() {}, nil
+	}
+
+	unlock, acquired, err := acquireLock(task.machineLock.name, task.machineLock.timeout)
+	if err != nil {
+		return task, nil, fmt.Errorf("tasker: machine lock %s: %w", task.machineLock.name, err)
+	}
+	if !acquired {
+		return task, nil, fmt.Errorf("%w: %s not released within %v", ErrLocked, task.machineLock.name, task.machineLock.timeout)
+	}
+	task.lockHeld = true
+	return task, unlock, nil
+}
+
+//mutates reports whether the schtasks arguments change a registered task
+func mutates(args []string) bool {
+	if len(args) < 2 || args[1] == helpSwitch {
+		return false
+	}
+	switch args[0] {
+	case _Create.Command, _Change.Command, _Delete.Command:
+		return true
+	}
+	return false
+}
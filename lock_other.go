@@ -0,0 +1,36 @@
+//go:build !windows
+
+package tasker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+//lockPoll interval between attempts to create the lock file
+const lockPoll = 10 * time.Millisecond
+
+//acquireLock creates the lock file of name, waiting while another
+//process holds it
+func acquireLock(name string, timeout time.Duration) (unlock func(), acquired bool, err error) {
+	path := filepath.Join(os.TempDir(), name+".lock")
+	deadline := time.Now().Add(timeout)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			file.Close()
+			return func() {
+				os.Remove(path)
+			}, true, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, false, err
+		}
+		if !time.Now().Before(deadline) {
+			return nil, false, nil
+		}
+		time.Sleep(lockPoll)
+	}
+}
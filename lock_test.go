@@ -0,0 +1,84 @@
+package tasker
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWithMachineLock(t *testing.T) {
+	ran := 0
+	task := tasker.WithMachineLock("go-wintask-test", 20*time.Millisecond).WithBackend(backendFunc(
+		func(args []string, stdin io.Reader) ([]byte, error) {
+			if args[1] != helpSwitch {
+				ran++
+			}
+			return nil, nil
+		}))
+
+	//another process holds the lock
+	unlock, acquired, err := acquireLock("go-wintask-test", 0)
+	if err != nil || !acquired {
+		t.Fatalf("acquireLock() = %v, %v", acquired, err)
+	}
+	if _, err := task.DeleteTask(taskName, true, true); !errors.Is(err, ErrLocked) || ran != 0 {
+		t.Errorf("DeleteTask() locked = %v, ran %d", err, ran)
+	}
+
+	unlock()
+	if _, err := task.DeleteTask(taskName, true, true); err != nil || ran != 1 {
+		t.Errorf("DeleteTask() = %v, ran %d", err, ran)
+	}
+	//released after the call
+	if _, err := task.DeleteTask(taskName, true, true); err != nil || ran != 2 {
+		t.Errorf("DeleteTask() again = %v, ran %d", err, ran)
+	}
+}
+
+func TestMachineLockWriters(t *testing.T) {
+	var ran []string
+	task := New(false).WithMachineLock("go-wintask-test", 20*time.Millisecond).WithBackend(backendFunc(
+		func(args []string, stdin io.Reader) ([]byte, error) {
+			switch {
+			case args[1] == helpSwitch:
+				return nil, exitError(1)
+			case args[0] == _Query.Command && argValue(args, _Query.taskname) != "":
+				return []byte(singletonXML), nil
+			case args[0] == _Query.Command:
+				return []byte(summaryCSV), nil
+			}
+			ran = append(ran, args[0])
+			return nil, nil
+		}))
+
+	unlock, acquired, err := acquireLock("go-wintask-test", 0)
+	if err != nil || !acquired {
+		t.Fatalf("acquireLock() = %v, %v", acquired, err)
+	}
+	if _, err := task.ChangeAction(taskName, "calc.exe", nil, true); !errors.Is(err, ErrLocked) {
+		t.Errorf("ChangeAction() locked = %v", err)
+	}
+	if _, err := task.EnsureTrigger(taskName, true, Trigger{XMLName: xml.Name{Local: "BootTrigger"}}); !errors.Is(err, ErrLocked) {
+		t.Errorf("EnsureTrigger() locked = %v", err)
+	}
+	results, err := task.MigrateNamespace(DefaultPrefix, Folder("app"))
+	if err != nil || len(results) == 0 {
+		t.Fatalf("MigrateNamespace() = %v, %v", results, err)
+	}
+	for _, result := range results {
+		if !errors.Is(result.Err, ErrLocked) {
+			t.Errorf("MigrateNamespace() locked %s = %v", result.From, result.Err)
+		}
+	}
+	if len(ran) != 0 {
+		t.Errorf("locked writers ran %v", ran)
+	}
+
+	//the commands of an operation share the lock
+	unlock()
+	if _, err := task.CreateTask(TaskCreate{Taskname: taskName, Taskrun: executable, Description: "locked"}); err != nil || len(ran) != 2 {
+		t.Errorf("CreateTask() = %v, ran %v", err, ran)
+	}
+}
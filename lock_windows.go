@@ -0,0 +1,54 @@
+//go:build windows
+
+package tasker
+
+import (
+	"runtime"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	procCreateMutexW = kernel32.NewProc("CreateMutexW")
+	procReleaseMutex = kernel32.NewProc("ReleaseMutex")
+)
+
+//acquireLock waits for the named mutex, which belongs to the thread
+//owning it so the goroutine stays on it until unlock
+func acquireLock(name string, timeout time.Duration) (unlock func(), acquired bool, err error) {
+	path, err := syscall.UTF16PtrFromString(`Global\` + name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	runtime.LockOSThread()
+	h, _, callErr := procCreateMutexW.Call(0, 0, uintptr(unsafe.Pointer(path)))
+	if h == 0 {
+		runtime.UnlockOSThread()
+		return nil, false, callErr
+	}
+	mutex := syscall.Handle(h)
+
+	wait := uint32(syscall.INFINITE - 1)
+	if timeout < 0 {
+		wait = 0
+	} else if timeout < time.Duration(wait)*time.Millisecond {
+		wait = uint32(timeout / time.Millisecond)
+	}
+	event, err := syscall.WaitForSingleObject(mutex, wait)
+	switch event {
+	//an abandoned mutex was held by a process which died
+	case syscall.WAIT_OBJECT_0, syscall.WAIT_ABANDONED:
+		return func() {
+			procReleaseMutex.Call(h)
+			syscall.CloseHandle(mutex)
+			runtime.UnlockOSThread()
+		}, true, nil
+	case syscall.WAIT_TIMEOUT:
+		err = nil
+	}
+	syscall.CloseHandle(mutex)
+	runtime.UnlockOSThread()
+	return nil, false, err
+}
@@ -0,0 +1,151 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	//ErrLogonMode the credentials given don't fit the LogonMode
+	ErrLogonMode = errors.New("tasker: credentials don't match the logon mode")
+)
+
+//LogonMode whether a task needs its user to be logged on, the "Security
+//options" of the Task Scheduler UI
+type LogonMode string
+
+var (
+	//LogonModes of TaskCreate.LogonMode, the empty mode leaves the choice
+	//to schtasks from the credentials given
+	LogonModes = struct {
+		//INTERACTIVE run only when the user is logged on (/IT), no
+		//password is stored
+		INTERACTIVE LogonMode
+
+		//PASSWORD run whether the user is logged on or not, the password
+		//is stored
+		PASSWORD LogonMode
+
+		//S4U run whether the user is logged on or not without storing a
+		//password (/NP), only local resources are available
+		S4U LogonMode
+
+		//ALLUSERS run in the context of each user logging on, through
+		//the BUILTIN\Users group principal, see ForAllUsers. Set through
+		//the task XML.
+		ALLUSERS LogonMode
+	}{
+		INTERACTIVE: "INTERACTIVE",
+		PASSWORD:    "PASSWORD",
+		S4U:         "S4U",
+		ALLUSERS:    "ALLUSERS",
+	}
+)
+
+//usersGroup SID of the BUILTIN\Users group
+const usersGroup = "S-1-5-32-545"
+
+//ForAllUsers returns a copy of the task starting at every logon in the
+//context of the user logging on, the usual way to install a per-user
+//agent machine-wide. The credentials are cleared as the task runs with
+//the interactive token of each user. Registering it requires
+//administrator rights.
+func (taskcreate TaskCreate) ForAllUsers() TaskCreate {
+	taskcreate.LogonMode = LogonModes.ALLUSERS
+	taskcreate.Schedule = Schedules.ONLOGON
+	taskcreate.Username = ""
+	taskcreate.Password = ""
+	taskcreate.Credential = ""
+	taskcreate.PromptPassword = false
+	taskcreate.NoPassword = false
+	taskcreate.Interactive = false
+	return taskcreate
+}
+
+//checkLogonMode validates the credentials against the logon mode, service
+//accounts run whether logged on or not without any password
+func (taskcreate TaskCreate) checkLogonMode() error {
+	mode := taskcreate.LogonMode
+	if mode == LogonModes.ALLUSERS {
+		return taskcreate.checkAllUsers()
+	}
+	if mode == "" || isServiceAccount(taskcreate.Username) {
+		return nil
+	}
+	hasPassword := taskcreate.Password != "" || taskcreate.Credential != ""
+
+	var reason string
+	switch mode {
+	case LogonModes.INTERACTIVE:
+		if hasPassword || taskcreate.NoPassword {
+			reason = "a logged on user needs no stored password"
+		}
+	case LogonModes.PASSWORD:
+		if !hasPassword || taskcreate.NoPassword || taskcreate.Interactive {
+			reason = "a password has to be stored"
+		}
+	case LogonModes.S4U:
+		if hasPassword || taskcreate.Interactive {
+			reason = "no password may be stored"
+		}
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrLogonMode, mode)
+	}
+
+	if reason != "" {
+		return fmt.Errorf("%w: %s, %s", ErrLogonMode, mode, reason)
+	}
+	return nil
+}
+
+//withLogonMode sets the switches selecting the logon mode
+func (taskcreate TaskCreate) withLogonMode() TaskCreate {
+	switch taskcreate.LogonMode {
+	case LogonModes.INTERACTIVE:
+		taskcreate.Interactive = true
+	case LogonModes.S4U:
+		taskcreate.NoPassword = true
+	case LogonModes.ALLUSERS:
+		if taskcreate.Schedule == "" {
+			taskcreate.Schedule = Schedules.ONLOGON
+		}
+	}
+	return taskcreate
+}
+
+//checkAllUsers validates a task running for every user logging on, it
+//has no credentials of its own and only the logon schedule
+func (taskcreate TaskCreate) checkAllUsers() error {
+	var reason string
+	switch {
+	case taskcreate.Username != "" || taskcreate.Password != "" || taskcreate.Credential != "" ||
+		taskcreate.NoPassword || taskcreate.Interactive:
+		reason = "the task runs as the user logging on"
+	case taskcreate.Schedule != "" && !strings.EqualFold(taskcreate.Schedule, Schedules.ONLOGON):
+		reason = "only the " + Schedules.ONLOGON + " schedule is supported"
+	}
+
+	if reason != "" {
+		return fmt.Errorf("%w: %s, %s", ErrLogonMode, LogonModes.ALLUSERS, reason)
+	}
+	return nil
+}
+
+//patchPrincipal replaces the user principal by the BUILTIN\Users group
+//for the ALLUSERS logon mode and lets the logon triggers fire for any
+//user, reports whether anything changed
+func (taskcreate TaskCreate) patchPrincipal(root *xmlNode) bool {
+	if taskcreate.LogonMode != LogonModes.ALLUSERS {
+		return false
+	}
+
+	principal := root.ensure("Principals", "Principal")
+	principal.remove("UserId")
+	principal.remove("LogonType")
+	principal.insert("GroupId", "DisplayName", "RunLevel", "ProcessTokenSidType", "RequiredPrivileges").Text = usersGroup
+	for _, trigger := range root.ensure("Triggers").children("LogonTrigger") {
+		trigger.remove("UserId")
+	}
+	return true
+}
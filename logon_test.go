@@ -0,0 +1,70 @@
+package tasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLogonMode(t *testing.T) {
+	cases := []struct {
+		taskcreate TaskCreate
+		ok         bool
+	}{
+		{TaskCreate{LogonMode: LogonModes.INTERACTIVE, Username: "bob"}, true},
+		{TaskCreate{LogonMode: LogonModes.INTERACTIVE, Username: "bob", Password: "x"}, false},
+		{TaskCreate{LogonMode: LogonModes.PASSWORD, Username: "bob", Password: "x"}, true},
+		{TaskCreate{LogonMode: LogonModes.PASSWORD, Credential: "app"}, true},
+		{TaskCreate{LogonMode: LogonModes.PASSWORD, Username: "bob"}, false},
+		{TaskCreate{LogonMode: LogonModes.PASSWORD, Username: "bob", Password: "x", Interactive: true}, false},
+		{TaskCreate{LogonMode: LogonModes.S4U, Username: "bob"}, true},
+		{TaskCreate{LogonMode: LogonModes.S4U, Username: "bob", Password: "x"}, false},
+		{TaskCreate{LogonMode: LogonModes.S4U, Username: "SYSTEM", Password: "x"}, true},
+		{TaskCreate{LogonMode: "BATCH"}, false},
+		{TaskCreate{Username: "bob", Password: "x", NoPassword: true}, true},
+		{TaskCreate{Taskname: taskName}.ForAllUsers(), true},
+		{TaskCreate{LogonMode: LogonModes.ALLUSERS, Username: "bob"}, false},
+		{TaskCreate{LogonMode: LogonModes.ALLUSERS, Schedule: Schedules.DAILY}, false},
+	}
+	for _, c := range cases {
+		err := c.taskcreate.checkLogonMode()
+		if c.ok && err != nil || !c.ok && !errors.Is(err, ErrLogonMode) {
+			t.Errorf("checkLogonMode(%+v) = %v", c.taskcreate, err)
+		}
+	}
+
+	cmds := strings.Join(tasker.TaskMake(TaskCreate{Taskname: taskName, Username: "bob", LogonMode: LogonModes.INTERACTIVE}, _Create.Command, true), " ")
+	if !strings.Contains(cmds, _Create.interactive) {
+		t.Errorf("INTERACTIVE without /IT: %s", cmds)
+	}
+	cmds = strings.Join(tasker.TaskMake(TaskCreate{Taskname: taskName, Username: "bob", LogonMode: LogonModes.S4U}, _Create.Command, true), " ")
+	if !strings.Contains(cmds, _Create.noPassword) {
+		t.Errorf("S4U without /NP: %s", cmds)
+	}
+}
+
+func TestForAllUsers(t *testing.T) {
+	taskcreate := TaskCreate{Taskname: taskName, Taskrun: executable, Username: "bob", Password: "x"}.ForAllUsers()
+	if taskcreate.Username != "" || taskcreate.Password != "" || taskcreate.Schedule != Schedules.ONLOGON {
+		t.Errorf("ForAllUsers() = %+v", taskcreate)
+	}
+	if !taskcreate.needsPatch() || !NeedsElevation(taskcreate) {
+		t.Error("ForAllUsers() must be patched and need elevation")
+	}
+
+	root, err := parseNode([]byte(`<Task><Triggers><LogonTrigger><Enabled>true</Enabled><UserId>PC\bob</UserId></LogonTrigger></Triggers>
+<Principals><Principal id="Author"><UserId>PC\bob</UserId><LogonType>InteractiveToken</LogonType><RunLevel>LeastPrivilege</RunLevel></Principal></Principals></Task>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !taskcreate.patchDefinition(root) {
+		t.Fatal("patchDefinition() changed nothing")
+	}
+	principal := root.ensure("Principals", "Principal")
+	if len(principal.Nodes) != 2 || principal.Nodes[0].XMLName.Local != "GroupId" || principal.get("GroupId") != usersGroup {
+		t.Errorf("unexpected principal %+v", principal.Nodes)
+	}
+	if root.get("Triggers", "LogonTrigger", "UserId") != "" {
+		t.Error("the logon trigger must fire for any user")
+	}
+}
@@ -0,0 +1,135 @@
+package tasker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	//maintenanceVersion first task schema version with MaintenanceSettings
+	maintenanceVersion = "1.4"
+)
+
+//MaintenanceSettings lets the task run during Automatic Maintenance
+//instead of at fixed times, Windows 8 and later.
+type MaintenanceSettings struct {
+	//Period how often the task should run, at least one day.
+	Period time.Duration
+
+	//Deadline how long after a missed Period the task is started in an
+	//emergency maintenance window, zero for none.
+	Deadline time.Duration
+
+	//Exclusive run the task apart from the other maintenance tasks.
+	Exclusive bool
+}
+
+//MaintenanceDefinition MaintenanceSettings as found in the task XML
+type MaintenanceDefinition struct {
+	Period    string `xml:"Period"`
+	Deadline  string `xml:"Deadline,omitempty"`
+	Exclusive string `xml:"Exclusive,omitempty"`
+}
+
+//patch writes the settings into the task, raising the schema version
+//when needed
+func (maintenance MaintenanceSettings) patch(root *xmlNode) {
+	settings := root.ensure("Settings")
+	settings.remove("MaintenanceSettings")
+	node := settings.add("MaintenanceSettings")
+	node.set(xmlDuration(maintenance.Period), "Period")
+	if maintenance.Deadline > 0 {
+		node.set(xmlDuration(maintenance.Deadline), "Deadline")
+	}
+	if maintenance.Exclusive {
+		node.set("true", "Exclusive")
+	}
+
+	for i, attr := range root.Attrs {
+		if attr.Name.Local == "version" && olderVersion(attr.Value, maintenanceVersion) {
+			root.Attrs[i].Value = maintenanceVersion
+		}
+	}
+}
+
+//olderVersion reports whether the "major.minor" version is before want
+func olderVersion(version, want string) bool {
+	parse := func(v string) (int, int) {
+		major, minor, _ := strings.Cut(v, ".")
+		x, _ := strconv.Atoi(major)
+		y, _ := strconv.Atoi(minor)
+		return x, y
+	}
+	x, y := parse(version)
+	wx, wy := parse(want)
+	return x < wx || x == wx && y < wy
+}
+
+//xmlDuration formats d as the xs:duration used by the task XML e.g.
+//P1DT2H30M
+func xmlDuration(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+
+	b := strings.Builder{}
+	b.WriteString("P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours > 0 || minutes > 0 || seconds > 0 || days == 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes > 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds > 0 || hours == 0 && minutes == 0 {
+			fmt.Fprintf(&b, "%dS", seconds)
+		}
+	}
+	return b.String()
+}
+
+//parseXMLDuration parses the xs:duration of the task XML, years and
+//months count as 365 and 30 days
+func parseXMLDuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(value, "P")
+	if !ok || rest == "" {
+		return 0, fmt.Errorf("%w: duration %q", ErrInvalidValue, value)
+	}
+
+	units := map[byte]time.Duration{'Y': 365 * 24 * time.Hour, 'M': 30 * 24 * time.Hour, 'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	timeUnits := map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+
+	d := time.Duration(0)
+	number := ""
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case c == 'T':
+			units = timeUnits
+		case c >= '0' && c <= '9' || c == '.':
+			number += string(c)
+		default:
+			unit, ok := units[c]
+			n, err := strconv.ParseFloat(number, 64)
+			if !ok || err != nil {
+				return 0, fmt.Errorf("%w: duration %q", ErrInvalidValue, value)
+			}
+			d += time.Duration(n * float64(unit))
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("%w: duration %q", ErrInvalidValue, value)
+	}
+	return d, nil
+}
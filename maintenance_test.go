@@ -0,0 +1,81 @@
+package tasker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestXMLDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                          "PT0S",
+		90 * time.Second:           "PT1M30S",
+		2 * time.Hour:              "PT2H",
+		24 * time.Hour:             "P1D",
+		7*24*time.Hour + time.Hour: "P7DT1H",
+		30*time.Minute + time.Hour: "PT1H30M",
+		24*time.Hour + time.Second: "P1DT1S",
+	}
+	for d, want := range cases {
+		if got := xmlDuration(d); got != want {
+			t.Errorf("xmlDuration(%v) = %s, want %s", d, got, want)
+		}
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	tc := TaskCreate{
+		Taskname:    taskName,
+		Taskrun:     "notepad.exe",
+		Maintenance: &MaintenanceSettings{Period: 24 * time.Hour, Deadline: 7 * 24 * time.Hour, Exclusive: true},
+	}
+	if !tc.needsPatch() {
+		t.Fatal("needsPatch is false with maintenance settings")
+	}
+
+	root, err := parseNode([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc.patchDefinition(root)
+	tc.patchDefinition(root)
+	data, err := root.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := ParseDefinition([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MaintenanceDefinition{Period: "P1D", Deadline: "P7D", Exclusive: "true"}
+	if got := def.Settings.MaintenanceSettings; got == nil || *got != want {
+		t.Errorf("MaintenanceSettings = %+v, want %+v", got, want)
+	}
+	if def.Version != maintenanceVersion {
+		t.Errorf("version = %s, want %s", def.Version, maintenanceVersion)
+	}
+
+	if olderVersion("1.6", maintenanceVersion) || !olderVersion("1.2", maintenanceVersion) {
+		t.Error("olderVersion compares wrongly")
+	}
+}
+
+func TestParseXMLDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"PT0S":    0,
+		"PT1M30S": 90 * time.Second,
+		"P1DT2H":  26 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"PT0.5S":  500 * time.Millisecond,
+		"P1MT1M":  30*24*time.Hour + time.Minute,
+	}
+	for value, want := range cases {
+		if got, err := parseXMLDuration(value); got != want || err != nil {
+			t.Errorf("parseXMLDuration(%s) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "P", "1D", "PT5", "PT5X"} {
+		if _, err := parseXMLDuration(value); err == nil {
+			t.Errorf("parseXMLDuration(%q) succeeded", value)
+		}
+	}
+}
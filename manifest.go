@@ -0,0 +1,64 @@
+package tasker
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+//ExportedManifest document written by ExportManifest
+type ExportedManifest struct {
+	Exported time.Time      `json:"exported"`
+	Host     string         `json:"host,omitempty"`
+	Tasks    []ExportedTask `json:"tasks"`
+}
+
+//ExportedTask owned task of an ExportedManifest
+type ExportedTask struct {
+	//Name registered path
+	Name string `json:"name"`
+
+	//Taskname name the task was registered for, the Taskname of a Sync
+	//manifest
+	Taskname string `json:"taskname"`
+
+	Status      Status     `json:"status"`
+	NextRunTime *time.Time `json:"nextRunTime"`
+
+	//Definition task XML, see ExportXML
+	Definition string `json:"definition"`
+}
+
+//ExportManifest writes every owned task, its definition and current
+//status as a single JSON document, see ExportedManifest. The counterpart
+//of Sync for support bundles and inventories.
+func (task SchTask) ExportManifest(w io.Writer) error {
+	owned, err := task.owned()
+	if err != nil {
+		return err
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return strings.ToLower(owned[i].name) < strings.ToLower(owned[j].name)
+	})
+
+	manifest := ExportedManifest{Exported: time.Now(), Host: task.host, Tasks: []ExportedTask{}}
+	for _, t := range owned {
+		definition, err := task.ExportXML(t.name, false)
+		if err != nil {
+			return err
+		}
+		manifest.Tasks = append(manifest.Tasks, ExportedTask{
+			Name:        t.name,
+			Taskname:    task.Namespace().Trim(t.name),
+			Status:      t.Status(),
+			NextRunTime: jsonTime(parseTime(t.datetime)),
+			Definition:  definition,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(manifest)
+}
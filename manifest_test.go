@@ -0,0 +1,34 @@
+package tasker
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestExportManifest(t *testing.T) {
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if argValue(args, _Query.taskname) != "" {
+			return []byte(singletonXML), nil
+		}
+		return []byte(summaryCSV), nil
+	}))
+
+	var out bytes.Buffer
+	if err := task.ExportManifest(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := ExportedManifest{}
+	if err := json.Unmarshal(out.Bytes(), &manifest); err != nil {
+		t.Fatalf("invalid manifest %s: %v", out.String(), err)
+	}
+	if manifest.Exported.IsZero() || len(manifest.Tasks) != 2 {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+	b := manifest.Tasks[1]
+	if b.Name != "\\go-wintask-B" || b.Taskname != "B" || b.Status != StatusRunning || b.NextRunTime != nil || b.Definition != singletonXML {
+		t.Errorf("unexpected task %+v", b)
+	}
+}
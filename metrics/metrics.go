@@ -0,0 +1,69 @@
+//Package metrics exports the health of scheduled tasks as prometheus
+//metrics.
+package metrics
+
+import (
+	"time"
+
+	tasker "github.com/janmir/go-wintask"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	statusDesc = prometheus.NewDesc("wintask_task_status",
+		"Current task status, the status label holds the canonical status.",
+		[]string{"task", "status"}, nil)
+	lastResultDesc = prometheus.NewDesc("wintask_task_last_result",
+		"Result code of the last task run.",
+		[]string{"task"}, nil)
+	sinceLastRunDesc = prometheus.NewDesc("wintask_task_seconds_since_last_run",
+		"Seconds elapsed since the last task run.",
+		[]string{"task"}, nil)
+	upDesc = prometheus.NewDesc("wintask_up",
+		"Whether the last task query succeeded.",
+		nil, nil)
+)
+
+//Collector prometheus.Collector querying the matching tasks on scrape
+type Collector struct {
+	task tasker.SchTask
+	name string
+	own  bool
+}
+
+//NewCollector creates a collector for the tasks matching name, see
+//SchTask.Query for the matching rules.
+func NewCollector(task tasker.SchTask, name string, own bool) *Collector {
+	return &Collector{
+		task: task,
+		name: name,
+		own:  own,
+	}
+}
+
+//Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- statusDesc
+	ch <- lastResultDesc
+	ch <- sinceLastRunDesc
+	ch <- upDesc
+}
+
+//Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	details, err := c.task.QueryDetail(c.name, c.own)
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, 1)
+
+	now := time.Now()
+	for _, d := range details {
+		ch <- prometheus.MustNewConstMetric(statusDesc, prometheus.GaugeValue, 1, d.Name, string(d.Status))
+		ch <- prometheus.MustNewConstMetric(lastResultDesc, prometheus.GaugeValue, float64(d.LastResult), d.Name)
+		if !d.LastRunTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(sinceLastRunDesc, prometheus.GaugeValue, now.Sub(d.LastRunTime).Seconds(), d.Name)
+		}
+	}
+}
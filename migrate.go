@@ -0,0 +1,68 @@
+package tasker
+
+//MigrateResult outcome of moving a single task to another namespace
+type MigrateResult struct {
+	From string
+	To   string
+	Err  error
+}
+
+//MigrateNamespace moves every task owned by the prefix into the folder
+//under its plain name, e.g. \go-wintask-backup to \myapp\backup. Each
+//task is registered again from its definition and the old one deleted
+//only once that succeeded, existing tasks of the folder are never
+//replaced. Tasks storing a password can't be registered again without
+//it and are reported failed, see ChangeCredentials. NoPrefix can't be
+//migrated as it would move every task of the library.
+func (task SchTask) MigrateNamespace(from Prefix, to Folder) ([]MigrateResult, error) {
+	if err := checkEnumerable(from); err != nil {
+		return nil, err
+	}
+	all, err := task.list()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []MigrateResult{}
+	for _, t := range all {
+		if !from.Owns(t.name) {
+			continue
+		}
+		result := MigrateResult{From: taskPath(t.name), To: NormalizeName(to.Path(from.Trim(t.name)))}
+		if !task.debugging() {
+			result.Err = task.migrate(result.From, result.To)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+//migrate registers the definition of the task from as to and deletes
+//from, holding the machine lock throughout
+func (task SchTask) migrate(from, to string) error {
+	task, unlock, err := task.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	output, err := task.execute(_Query.Command, _Query.taskname, from, _Query.xml)
+	if err != nil {
+		return err
+	}
+
+	root, err := parseNode(output)
+	if err != nil {
+		return err
+	}
+	if info := root.child("RegistrationInfo"); info != nil && info.child("URI") != nil {
+		info.set(to, "URI")
+	}
+
+	if _, err := task.create(TaskCreate{Taskname: to, definition: root}, false); err != nil {
+		return err
+	}
+	_, err = task.DeleteTask(from, false, true)
+	return err
+}
@@ -0,0 +1,51 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrateNamespace(t *testing.T) {
+	var created, deleted []string
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[0] == _Query.Command && len(args) > 3 && args[3] == _Query.xml:
+			return []byte(strings.Replace(singletonXML, "go-wintask-Test", baseName(args[2]), 1)), nil
+		case args[0] == _Query.Command:
+			return []byte(summaryCSV), nil
+		case args[0] == _Create.Command:
+			data, err := os.ReadFile(args[4])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(decodeUTF16(data), "<URI>"+args[2]+"</URI>") {
+				t.Errorf("URI of %s not updated: %s", args[2], decodeUTF16(data))
+			}
+			if args[2] == "\\app\\B" {
+				return []byte("ERROR: Cannot create a file when that file already exists.\r\n"), exitError(1)
+			}
+			created = append(created, args[2])
+		case args[0] == _Delete.Command:
+			deleted = append(deleted, args[2])
+		}
+		return nil, nil
+	}))
+
+	results, err := task.MigrateNamespace(DefaultPrefix, Folder("app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0] != (MigrateResult{From: "\\go-wintask-A", To: "\\app\\A"}) || results[1].Err == nil {
+		t.Errorf("MigrateNamespace() = %+v", results)
+	}
+	if !reflect.DeepEqual(created, []string{"\\app\\A"}) || !reflect.DeepEqual(deleted, []string{"\\go-wintask-A"}) {
+		t.Errorf("created %v and deleted %v", created, deleted)
+	}
+	if !errors.As(results[1].Err, new(*Error)) {
+		t.Errorf("failed migration error = %v", results[1].Err)
+	}
+}
@@ -0,0 +1,77 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	//maxTaskPath longest task path, tasks are stored as files below
+	//%SystemRoot%\System32\Tasks which leaves this much of MAX_PATH
+	maxTaskPath = 232
+
+	//illegalNameChars characters windows refuses in file names
+	illegalNameChars = "<>:\"/|?*"
+)
+
+var (
+	//ErrInvalidName the task name can't be registered
+	ErrInvalidName = errors.New("tasker: invalid task name")
+)
+
+//NormalizeName returns the task path form of name used by every method:
+//surrounding spaces trimmed, forward slashes turned into folder
+//separators and a single leading backslash, e.g. "app/Backup" becomes
+//"\app\Backup".
+func NormalizeName(name string) string {
+	name = strings.TrimSpace(name)
+	name = strings.Replace(name, "/", "\\", -1)
+	return taskPath(strings.TrimLeft(name, "\\"))
+}
+
+//ValidateName checks a task name, folders included, against the length
+//limit and the characters Task Scheduler refuses
+func ValidateName(name string) error {
+	path := NormalizeName(name)
+	if len(path) > maxTaskPath {
+		return fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidName, name, maxTaskPath)
+	}
+
+	for _, part := range strings.Split(path[1:], "\\") {
+		switch {
+		case part == "":
+			return fmt.Errorf("%w: %q has an empty folder or name", ErrInvalidName, name)
+		case part == "." || part == "..":
+			return fmt.Errorf("%w: %q uses a relative folder", ErrInvalidName, name)
+		case strings.HasSuffix(part, ".") || strings.HasSuffix(part, " "):
+			return fmt.Errorf("%w: %q ends a folder or name with a dot or space", ErrInvalidName, name)
+		case strings.ContainsAny(part, illegalNameChars):
+			return fmt.Errorf("%w: %q contains one of %s", ErrInvalidName, name, illegalNameChars)
+		}
+		for _, r := range part {
+			if r < 0x20 {
+				return fmt.Errorf("%w: %q contains control characters", ErrInvalidName, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+//resolveName returns the validated, normalized registered name
+func (task SchTask) resolveName(name string, own bool) (string, error) {
+	name = task.fullName(name, own)
+	return name, ValidateName(name)
+}
+
+//mustName is resolveName for the methods without an error result,
+//exiting like catch does
+func (task SchTask) mustName(name string, own bool) string {
+	name, err := task.resolveName(name, own)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return name
+}
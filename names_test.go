@@ -0,0 +1,38 @@
+package tasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	cases := map[string]string{
+		"Test":           "\\Test",
+		" \\Test ":       "\\Test",
+		"\\\\app\\Test":  "\\app\\Test",
+		"app/Test":       "\\app\\Test",
+		"go-wintask-Tst": "\\go-wintask-Tst",
+	}
+	for name, want := range cases {
+		if got := NormalizeName(name); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	valid := []string{"Test", "\\app\\Test", "app/sub/Test v1.2"}
+	for _, name := range valid {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "app\\\\Test", "app\\..\\Test", "Test.", "Te:st", "Te*st", "Te\tst", strings.Repeat("x", maxTaskPath)}
+	for _, name := range invalid {
+		if err := ValidateName(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("ValidateName(%q) = %v, want ErrInvalidName", name, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package tasker
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	//DefaultPrefix name prefix of the owned tasks unless another
+	//namespace is chosen
+	DefaultPrefix = Prefix("go-wintask-")
+
+	//NoPrefix namespace managing the task names exactly as given. Its
+	//tasks can't be told apart from the rest of the library, the
+	//operations enumerating the owned tasks, e.g. Audit, Sync or
+	//MigrateNamespace, fail with ErrInvalidValue.
+	NoPrefix = Prefix("")
+)
+
+//Namespace decides which registered tasks the library owns and how the
+//names given for them are registered, see Prefix and Folder.
+type Namespace interface {
+	//Path returns the registered path of the owned task name
+	Path(name string) string
+
+	//Owns reports whether the registered task path belongs to the
+	//namespace
+	Owns(path string) bool
+
+	//Trim returns the name an owned task path was registered for, paths
+	//not owned are returned as is
+	Trim(path string) string
+
+	//Folder returns the folder holding every owned task, "" when they
+	//are spread over the whole library
+	Folder() string
+}
+
+//Prefix namespace owning the tasks whose name starts with the prefix, in
+//any folder. The default, see DefaultPrefix.
+type Prefix string
+
+//Path implements Namespace
+func (prefix Prefix) Path(name string) string {
+	return string(prefix) + name
+}
+
+//Owns implements Namespace
+func (prefix Prefix) Owns(path string) bool {
+	return strings.HasPrefix(strings.ToLower(ParseTaskPath(path).Name), strings.ToLower(string(prefix)))
+}
+
+//Trim implements Namespace
+func (prefix Prefix) Trim(path string) string {
+	if prefix == NoPrefix {
+		return taskPath(path)
+	}
+	if !prefix.Owns(path) {
+		return path
+	}
+	return ParseTaskPath(path).Name[len(prefix):]
+}
+
+//Folder implements Namespace
+func (prefix Prefix) Folder() string {
+	return ""
+}
+
+//checkEnumerable rejects NoPrefix for the operations enumerating the
+//owned tasks, it would own every task of the library
+func checkEnumerable(namespace Namespace) error {
+	if namespace == Namespace(NoPrefix) {
+		return fmt.Errorf("%w: the owned tasks of NoPrefix can't be enumerated", ErrInvalidValue)
+	}
+	return nil
+}
+
+//Folder namespace owning the tasks of a dedicated folder, e.g.
+//"\go-wintask\myapp", registered under their plain name. Owned queries
+//only enumerate the folder, which is faster and keeps the Task
+//Scheduler UI tidy.
+type Folder string
+
+//Path implements Namespace
+func (folder Folder) Path(name string) string {
+	return folder.Folder() + "\\" + name
+}
+
+//Owns implements Namespace
+func (folder Folder) Owns(path string) bool {
+	return strings.HasPrefix(strings.ToLower(taskPath(path)), strings.ToLower(folder.Folder()+"\\"))
+}
+
+//Trim implements Namespace
+func (folder Folder) Trim(path string) string {
+	if !folder.Owns(path) {
+		return path
+	}
+	return taskPath(path)[len(folder.Folder())+1:]
+}
+
+//Folder implements Namespace
+func (folder Folder) Folder() string {
+	return strings.TrimSuffix(taskPath(string(folder)), "\\")
+}
+
+//WithNamespace returns a copy of the tasker owning the tasks of
+//namespace.
+func (task SchTask) WithNamespace(namespace Namespace) SchTask {
+	task.namespace = namespace
+	return task
+}
+
+//Namespace returns the namespace of the owned tasks
+func (task SchTask) Namespace() Namespace {
+	if task.namespace == nil {
+		return DefaultPrefix
+	}
+	return task.namespace
+}
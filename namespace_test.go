@@ -0,0 +1,72 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNamespace(t *testing.T) {
+	if tasker.Namespace() != DefaultPrefix || (SchTask{}).Namespace() != DefaultPrefix {
+		t.Error("the default namespace is not DefaultPrefix")
+	}
+
+	prefix := Prefix("app-")
+	if prefix.Path("Test") != "app-Test" || !prefix.Owns("\\sub\\APP-Test") || prefix.Owns("\\go-wintask-Test") {
+		t.Error("ownership must follow the prefix")
+	}
+	if prefix.Trim("\\sub\\app-Test") != "Test" || prefix.Folder() != "" {
+		t.Error("unexpected prefix trim or folder")
+	}
+
+	folder := Folder("app\\")
+	if folder.Folder() != "\\app" || folder.Path("Test") != "\\app\\Test" || folder.Trim("\\App\\Test") != "Test" {
+		t.Error("unexpected folder path or trim")
+	}
+
+	task := New(false, folder)
+	if name := task.fullName(taskName, true); name != "\\app\\Test" {
+		t.Errorf("unexpected owned name %q", name)
+	}
+	if task.fullName(taskName, false) != "\\Test" {
+		t.Error("names not owned must not get the namespace")
+	}
+	if !New(false, prefix).match(Filter{"te*", true}, "\\app-Test") || New(false, prefix).match(Filter{"te*", true}, "\\go-wintask-Test") {
+		t.Error("owned filters must match within the namespace")
+	}
+}
+
+func TestNoPrefix(t *testing.T) {
+	task := New(false, NoPrefix)
+	if task.fullName(taskName, true) != "\\Test" || task.fullName("Tools\\Test", true) != "\\Tools\\Test" {
+		t.Error("NoPrefix must keep the names as given")
+	}
+	if !NoPrefix.Owns("\\Tools\\Test") || NoPrefix.Trim("\\Tools\\Test") != "\\Tools\\Test" {
+		t.Error("NoPrefix must own every task under its full path")
+	}
+	if !task.match(Filter{"tools\\test", true}, "\\Tools\\Test") || !task.match(Filter{"te*", true}, "\\Tools\\Test") {
+		t.Error("owned filters must match the raw names")
+	}
+	if New(false).fullName(taskName, false) != "\\Test" {
+		t.Error("own false must override the prefix")
+	}
+
+	var calls [][]string
+	task = task.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		calls = append(calls, args)
+		return nil, nil
+	}))
+	if _, err := task.Audit(nil); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Audit() with NoPrefix = %v, want ErrInvalidValue", err)
+	}
+	if _, err := task.Sync(strings.NewReader(`{"prune": true}`), nil, false); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Sync() with NoPrefix = %v, want ErrInvalidValue", err)
+	}
+	if _, err := task.MigrateNamespace(NoPrefix, Folder("app")); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("MigrateNamespace() from NoPrefix = %v, want ErrInvalidValue", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("NoPrefix enumerated the library: %v", calls)
+	}
+}
@@ -0,0 +1,137 @@
+package tasker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+//truncatedMarker line replacing the output past the limit, see
+//WithOutputLimit
+const truncatedMarker = "[tasker: output truncated, %d bytes dropped]\r\n"
+
+//WithOutputLimit returns a copy of the tasker keeping at most limit
+//bytes of the output of every schtasks process, the rest is dropped and
+//replaced by a marker line. Lines are kept whole, so queries return the
+//tasks listed before the cut. 0, the default, keeps everything. See
+//QueryTo for enumerations which are too large to hold.
+func (task SchTask) WithOutputLimit(limit int) SchTask {
+	task.outputLimit = limit
+	return task
+}
+
+//QueryTo writes the CSV of /QUERY for every task to w while schtasks
+//produces it, with the verbose /V columns when verbose is set. Unlike
+//Query and QueryDetail the output is never held in memory, for servers
+//with thousands of tasks.
+func (task SchTask) QueryTo(w io.Writer, verbose bool, opts ...CallOption) (err error) {
+	task = task.with(opts)
+	args := []string{_Query.Command, _Query.format, _Query.formatCSV}
+	if verbose {
+		args = append(args, _Query.verbose)
+	}
+
+	//replacement backends don't stream
+	if task.backend != nil {
+		output, err := task.execute(args...)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(output)
+		return err
+	}
+
+	if !supported {
+		return ErrUnsupportedPlatform
+	}
+	args, err = task.withRemote(args)
+	if err != nil {
+		return err
+	}
+	if task.tracer != nil {
+		end := task.tracer.Start(task.context(), operationOf(args))
+		defer func() {
+			end(exitCode(err), err)
+		}()
+	}
+
+	if err := task.limiter.wait(task.context()); err != nil {
+		return err
+	}
+	cmd := task.command(task.withHRESULT(args)...)
+	stdout := &lineDecoder{w: w}
+	stderr := &cappedWriter{limit: 64 * 1024}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err = cmd.Run()
+	if isMissingBinary(err) {
+		return fmt.Errorf("%w: %s", ErrBinaryNotFound, task.bin)
+	}
+	if err != nil {
+		return newError(args, stderr.output(), err)
+	}
+	return stdout.flush()
+}
+
+//cappedWriter collects output up to limit bytes and counts the rest
+type cappedWriter struct {
+	limit   int
+	buf     bytes.Buffer
+	dropped int64
+}
+
+//Write implements io.Writer, never failing so the process isn't blocked
+func (c *cappedWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := c.limit - c.buf.Len(); room < len(p) {
+		if room < 0 {
+			room = 0
+		}
+		c.dropped += int64(len(p) - room)
+		p = p[:room]
+	}
+	c.buf.Write(p)
+	return n, nil
+}
+
+//output returns the decoded output, when anything was dropped cut after
+//the last whole line and followed by the marker
+func (c *cappedWriter) output() []byte {
+	data := c.buf.Bytes()
+	if c.dropped == 0 {
+		return decodeOutput(data)
+	}
+
+	cut := bytes.LastIndexByte(data, '\n') + 1
+	dropped := c.dropped + int64(len(data)-cut)
+	output := append([]byte{}, decodeOutput(data[:cut])...)
+	return append(output, fmt.Sprintf(truncatedMarker, dropped)...)
+}
+
+//lineDecoder writes the output to w decoded line by line, the console
+//codepages never use a line feed within a character
+type lineDecoder struct {
+	w    io.Writer
+	line []byte
+}
+
+//Write implements io.Writer
+func (d *lineDecoder) Write(p []byte) (int, error) {
+	d.line = append(d.line, p...)
+	if i := bytes.LastIndexByte(d.line, '\n'); i >= 0 {
+		if _, err := d.w.Write(decodeOutput(d.line[:i+1])); err != nil {
+			return 0, err
+		}
+		d.line = append(d.line[:0], d.line[i+1:]...)
+	}
+	return len(p), nil
+}
+
+//flush writes the last line when it has no line feed
+func (d *lineDecoder) flush() error {
+	if len(d.line) == 0 {
+		return nil
+	}
+	_, err := d.w.Write(decodeOutput(d.line))
+	d.line = d.line[:0]
+	return err
+}
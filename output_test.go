@@ -0,0 +1,57 @@
+package tasker
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCappedWriter(t *testing.T) {
+	capped := &cappedWriter{limit: 20}
+	for _, line := range []string{"\"A\",\"Ready\"\r\n", "\"B\",\"Running\"\r\n", "\"C\",\"Ready\"\r\n"} {
+		if n, err := capped.Write([]byte(line)); n != len(line) || err != nil {
+			t.Fatalf("Write() = %d, %v", n, err)
+		}
+	}
+	want := "\"A\",\"Ready\"\r\n[tasker: output truncated, 28 bytes dropped]\r\n"
+	if got := string(capped.output()); got != want {
+		t.Errorf("output() = %q, want %q", got, want)
+	}
+
+	whole := &cappedWriter{limit: len(summaryCSV)}
+	whole.Write([]byte(summaryCSV))
+	if got := string(whole.output()); got != summaryCSV {
+		t.Errorf("output() = %q", got)
+	}
+}
+
+func TestLineDecoder(t *testing.T) {
+	var out bytes.Buffer
+	decoder := &lineDecoder{w: &out}
+	decoder.Write([]byte("\"A\",\"Re"))
+	if out.Len() != 0 {
+		t.Errorf("partial line written: %q", out.String())
+	}
+	decoder.Write([]byte("ady\"\r\n\"B\""))
+	decoder.flush()
+	if got := out.String(); got != "\"A\",\"Ready\"\r\n\"B\"" {
+		t.Errorf("lineDecoder wrote %q", got)
+	}
+}
+
+func TestQueryTo(t *testing.T) {
+	var ran []string
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		ran = args
+		return []byte(summaryCSV), nil
+	}))
+
+	var out strings.Builder
+	if err := task.QueryTo(&out, true); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != summaryCSV || argValue(ran, _Query.format) != _Query.formatCSV || ran[len(ran)-1] != _Query.verbose {
+		t.Errorf("QueryTo() ran %v, wrote %q", ran, out.String())
+	}
+}
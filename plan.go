@@ -0,0 +1,128 @@
+package tasker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os/user"
+	"strings"
+)
+
+//Plan preview of a create or change, for review tooling to show and
+//store before the operation is performed
+type Plan struct {
+	//Operation schtasks command, e.g. /CREATE
+	Operation string `json:"operation"`
+
+	//Name registered task path
+	Name string `json:"name"`
+
+	//Args schtasks command line, passwords masked
+	Args []string `json:"args"`
+
+	//XML definition registered after the command for the settings
+	//schtasks has no switch for, empty when not needed. Changes patch
+	//the registered definition, creates a skeleton holding the patched
+	//parts only.
+	XML string `json:"xml,omitempty"`
+
+	//RunAs account the task runs as
+	RunAs string `json:"runAs"`
+}
+
+//String renders the plan for review
+func (plan Plan) String() string {
+	out := fmt.Sprintf("%s %s as %s\n\t%s\n", plan.Operation, plan.Name, plan.RunAs, strings.Join(plan.Args, " "))
+	if plan.XML != "" {
+		out += plan.XML + "\n"
+	}
+	return out
+}
+
+//PlanCreate previews CreateTask, nothing is registered
+func (task SchTask) PlanCreate(taskcreate TaskCreate) (Plan, error) {
+	return task.plan(taskcreate, _Create.Command, true)
+}
+
+//PlanChange previews ChangeTask, the registered definition is read to
+//predict the XML but nothing is changed
+func (task SchTask) PlanChange(taskcreate TaskCreate, own bool) (Plan, error) {
+	return task.plan(taskcreate, _Change.Command, own)
+}
+
+func (task SchTask) plan(taskcreate TaskCreate, command string, own bool) (Plan, error) {
+	credential := taskcreate.Credential
+	if credential != "" {
+		taskcreate = taskcreate.maskCredential()
+	}
+	if err := task.checkCreate(taskcreate, own); err != nil {
+		return Plan{}, err
+	}
+
+	name := task.fullName(taskcreate.Taskname, own)
+	plan := Plan{
+		Operation: command,
+		Name:      name,
+		Args:      maskPasswords(task.TaskMake(taskcreate, command, own)),
+		RunAs:     runAs(taskcreate.Username, credential),
+	}
+	if !taskcreate.needsPatch() {
+		return plan, nil
+	}
+
+	root := skeletonDefinition(name)
+	if command == _Change.Command {
+		output, err := task.execute(_Query.Command, _Query.taskname, name, _Query.xml)
+		if err != nil {
+			return Plan{}, err
+		}
+		if root, err = parseNode(output); err != nil {
+			return Plan{}, err
+		}
+	}
+	taskcreate.patchDefinition(root)
+
+	data, err := root.marshal()
+	if err != nil {
+		return Plan{}, err
+	}
+	plan.XML = data
+	return plan, nil
+}
+
+//skeletonDefinition empty definition of a task to be created
+func skeletonDefinition(name string) *xmlNode {
+	root := &xmlNode{
+		XMLName: xml.Name{Local: "Task"},
+		Attrs: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: "http://schemas.microsoft.com/windows/2004/02/mit/task"},
+			{Name: xml.Name{Local: "version"}, Value: "1.2"},
+		},
+	}
+	root.set(name, "RegistrationInfo", "URI")
+	return root
+}
+
+//maskPasswords replaces the /RP values of a command line
+func maskPasswords(args []string) []string {
+	masked := append([]string{}, args...)
+	for i := 1; i < len(masked); i++ {
+		if strings.EqualFold(masked[i-1], _Create.password) && masked[i] != passwordPrompt {
+			masked[i] = credentialMask
+		}
+	}
+	return masked
+}
+
+//runAs describes the account a task runs as
+func runAs(username, credential string) string {
+	switch {
+	case username != "":
+		return username
+	case credential != "":
+		return "user of credential " + credential
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "current user"
+}
@@ -0,0 +1,70 @@
+package tasker
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	task := New(false).WithDebug(true)
+
+	plan, err := task.PlanCreate(TaskCreate{
+		Taskname: taskName,
+		Taskrun:  executable,
+		Schedule: Schedules.DAILY,
+		Username: "bob",
+		Password: "secret",
+		Tags:     []string{"app"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Operation != "/CREATE" || plan.Name != "\\go-wintask-Test" || plan.RunAs != "bob" {
+		t.Errorf("PlanCreate() = %+v", plan)
+	}
+	args := strings.Join(plan.Args, " ")
+	if strings.Contains(args, "secret") || !strings.Contains(args, "/RP "+credentialMask) {
+		t.Errorf("password not masked: %s", args)
+	}
+	def, err := ParseDefinition([]byte(plan.XML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.RegistrationInfo.URI != plan.Name || def.RegistrationInfo.Description != "tags:app" {
+		t.Errorf("predicted XML = %s", plan.XML)
+	}
+	if data, err := json.Marshal(plan); err != nil || !strings.Contains(string(data), `"runAs":"bob"`) {
+		t.Errorf("json = %s, %v", data, err)
+	}
+
+	plan, err = task.PlanCreate(TaskCreate{Taskname: taskName, Taskrun: executable, Credential: "app"})
+	if err != nil || plan.XML != "" || plan.RunAs != "user of credential app" {
+		t.Errorf("PlanCreate() with credential = %+v, %v", plan, err)
+	}
+}
+
+func TestPlanChange(t *testing.T) {
+	var commands []string
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		commands = append(commands, args[0])
+		if args[0] == _Query.Command {
+			return []byte(singletonXML), nil
+		}
+		return nil, nil
+	}))
+
+	plan, err := task.PlanChange(TaskCreate{Taskname: taskName, Taskrun: executable, Description: "changed"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plan.XML, "<Description>changed</Description>") || !strings.Contains(plan.XML, "<RunLevel>LeastPrivilege</RunLevel>") {
+		t.Errorf("predicted XML = %s", plan.XML)
+	}
+	for _, command := range commands {
+		if command != _Query.Command {
+			t.Errorf("PlanChange() ran %s", command)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package tasker
+
+const (
+	//supported whether the task scheduler is available
+	supported = true
+)
@@ -0,0 +1,235 @@
+package tasker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//psDays day names of New-ScheduledTaskTrigger by /D day
+var psDays = map[string]string{
+	Days.MON: "Monday", Days.TUE: "Tuesday", Days.WED: "Wednesday", Days.THU: "Thursday",
+	Days.FRI: "Friday", Days.SAT: "Saturday", Days.SUN: "Sunday",
+}
+
+//ToPowerShell returns the Register-ScheduledTask script creating the
+//owned task, so it can be reviewed by change management or run where the
+//library isn't installed. Passwords are never written, the script prompts
+//for them. Options without an equivalent in the ScheduledTasks module,
+//e.g. the MONTHLY, ONIDLE and ONEVENT schedules, fail with
+//ErrNotSupported.
+func (task SchTask) ToPowerShell(taskcreate TaskCreate) (string, error) {
+	taskcreate = task.withDefaults(taskcreate)
+	name, err := task.resolveName(taskcreate.Taskname, true)
+	if err != nil {
+		return "", err
+	}
+	if err := taskcreate.checkLogonMode(); err != nil {
+		return "", err
+	}
+	if err := taskcreate.checkWeekly(); err != nil {
+		return "", err
+	}
+	taskcreate = taskcreate.withLogonMode().withBoot().withWeekly().withDurations()
+	if err := taskcreate.checkPowerShell(); err != nil {
+		return "", err
+	}
+
+	script := &strings.Builder{}
+	line := func(format string, args ...interface{}) {
+		fmt.Fprintf(script, format+"\n", args...)
+	}
+
+	run, args := taskcreate.action()
+	if args != "" {
+		line("$action = New-ScheduledTaskAction -Execute %s -Argument %s", powershellQuote(run), powershellQuote(args))
+	} else {
+		line("$action = New-ScheduledTaskAction -Execute %s", powershellQuote(run))
+	}
+
+	trigger, err := taskcreate.psTrigger()
+	if err != nil {
+		return "", err
+	}
+	line("$trigger = New-ScheduledTaskTrigger %s", trigger)
+	if taskcreate.Delay > 0 {
+		line("$trigger.Delay = %s", powershellQuote(xmlDuration(taskcreate.Delay.Truncate(time.Second))))
+	}
+	if !taskcreate.EndBoundary.IsZero() {
+		line("$trigger.EndBoundary = %s", powershellQuote(formatBoundary(taskcreate.EndBoundary)))
+	}
+
+	settings := []string{"New-ScheduledTaskSettingsSet"}
+	if taskcreate.AllowHardTerminate != nil && !*taskcreate.AllowHardTerminate {
+		settings = append(settings, "-DisallowHardTerminate")
+	}
+	if taskcreate.StopOnIdleEnd != nil && !*taskcreate.StopOnIdleEnd {
+		settings = append(settings, "-DontStopOnIdleEnd")
+	}
+	if taskcreate.StartWhenAvailable != nil && *taskcreate.StartWhenAvailable {
+		settings = append(settings, "-StartWhenAvailable")
+	}
+	if taskcreate.Preempt > 0 {
+		settings = append(settings, "-ExecutionTimeLimit", psTimeSpan(taskcreate.Preempt))
+	}
+	line("$settings = %s", strings.Join(settings, " "))
+	if taskcreate.Preempt > 0 {
+		line("#StopExisting has no parameter of its own")
+		line("$settings.CimInstanceProperties.Item('MultipleInstances').Value = 3")
+	}
+
+	level := "Limited"
+	if strings.EqualFold(taskcreate.Level, Level.HIGHEST) {
+		level = "Highest"
+	}
+	p := ParseTaskPath(name)
+	register := []string{"Register-ScheduledTask", "-TaskName", powershellQuote(p.Name),
+		"-TaskPath", powershellQuote(strings.TrimSuffix(p.Folder, "\\") + "\\"),
+		"-Action", "$action", "-Trigger", "$trigger", "-Settings", "$settings"}
+
+	user := taskcreate.Username
+	switch {
+	case taskcreate.LogonMode == LogonModes.ALLUSERS:
+		line("$principal = New-ScheduledTaskPrincipal -GroupId %s -RunLevel %s", powershellQuote(usersGroup), level)
+		register = append(register, "-Principal", "$principal")
+	case isServiceAccount(user):
+		line("$principal = New-ScheduledTaskPrincipal -UserId %s -LogonType ServiceAccount -RunLevel %s", powershellQuote(user), level)
+		register = append(register, "-Principal", "$principal")
+	case taskcreate.Password != "" || taskcreate.Credential != "" || taskcreate.PromptPassword:
+		prompt := "-UserName " + powershellQuote(user)
+		if user == "" {
+			prompt = "-Message " + powershellQuote("credential "+taskcreate.Credential)
+		}
+		line("$credential = Get-Credential %s", prompt)
+		register = append(register, "-User", "$credential.UserName", "-Password", "$credential.GetNetworkCredential().Password",
+			"-RunLevel", level)
+	default:
+		logon := "Interactive"
+		if taskcreate.NoPassword {
+			logon = "S4U"
+		}
+		id := `"$env:USERDOMAIN\$env:USERNAME"`
+		if user != "" {
+			id = powershellQuote(user)
+		}
+		line("$principal = New-ScheduledTaskPrincipal -UserId %s -LogonType %s -RunLevel %s", id, logon, level)
+		register = append(register, "-Principal", "$principal")
+	}
+
+	if description := joinTags(taskcreate.Description, taskcreate.Tags); description != "" {
+		register = append(register, "-Description", powershellQuote(description))
+	}
+	if taskcreate.Force {
+		register = append(register, "-Force")
+	}
+	line("%s", strings.Join(register, " "))
+
+	return script.String(), nil
+}
+
+//checkPowerShell reports the options without an equivalent in the
+//ScheduledTasks module
+func (taskcreate TaskCreate) checkPowerShell() error {
+	var option string
+	switch {
+	case taskcreate.Endtime != "" || taskcreate.Duration != "" || taskcreate.Terminate:
+		option = "end time or duration"
+	case taskcreate.Interval != "":
+		option = "repetition interval"
+	case taskcreate.Enddate != "" || len(taskcreate.Months) > 0 || taskcreate.Idletime != "" || taskcreate.ChannelName != "":
+		option = "end date, months, idle time or event channel"
+	case taskcreate.V1 || taskcreate.MarkDelete:
+		option = "/V1 or /Z"
+	case taskcreate.ComHandler != nil || taskcreate.Email != nil || taskcreate.Message != nil || taskcreate.Maintenance != nil:
+		option = "COM handler, e-mail, message or maintenance"
+	default:
+		return nil
+	}
+	return fmt.Errorf("%w: %s in Register-ScheduledTask scripts", ErrNotSupported, option)
+}
+
+//psTrigger returns the New-ScheduledTaskTrigger parameters of the
+//schedule
+func (taskcreate TaskCreate) psTrigger() (string, error) {
+	modifier := 1
+	if taskcreate.Modifier != "" {
+		n, err := strconv.Atoi(taskcreate.Modifier)
+		if err != nil || n < 1 {
+			return "", fmt.Errorf("%w: modifier %q", ErrInvalidValue, taskcreate.Modifier)
+		}
+		modifier = n
+	}
+
+	params := []string{}
+	schedule := strings.ToUpper(taskcreate.Schedule)
+	switch schedule {
+	case Schedules.ONCE:
+		params = append(params, "-Once", "-At", taskcreate.psStart())
+	case Schedules.MINUTE:
+		params = append(params, "-Once", "-At", taskcreate.psStart(), "-RepetitionInterval",
+			psTimeSpan(time.Duration(modifier)*time.Minute))
+	case Schedules.HOURLY:
+		params = append(params, "-Once", "-At", taskcreate.psStart(), "-RepetitionInterval",
+			psTimeSpan(time.Duration(modifier)*time.Hour))
+	case Schedules.DAILY:
+		params = append(params, "-Daily", "-At", taskcreate.psStart(), "-DaysInterval", strconv.Itoa(modifier))
+	case Schedules.WEEKLY:
+		days := []string{}
+		for _, day := range taskcreate.Days {
+			if day == Days.ALL {
+				days = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+				break
+			}
+			name, ok := psDays[strings.ToUpper(day)]
+			if !ok {
+				return "", fmt.Errorf("%w: day %q", ErrInvalidValue, day)
+			}
+			days = append(days, name)
+		}
+		daysOfWeek := "(Get-Date).DayOfWeek"
+		if len(days) > 0 {
+			daysOfWeek = strings.Join(days, ",")
+		}
+		params = append(params, "-Weekly", "-At", taskcreate.psStart(), "-WeeksInterval", strconv.Itoa(modifier),
+			"-DaysOfWeek", daysOfWeek)
+	case Schedules.ONSTART:
+		params = append(params, "-AtStartup")
+	case Schedules.ONLOGON:
+		params = append(params, "-AtLogOn")
+	default:
+		return "", fmt.Errorf("%w: %q schedule in Register-ScheduledTask scripts", ErrNotSupported, taskcreate.Schedule)
+	}
+
+	if taskcreate.RandomDelay > 0 {
+		params = append(params, "-RandomDelay", psTimeSpan(taskcreate.RandomDelay))
+	}
+	return strings.Join(params, " "), nil
+}
+
+//psStart returns the expression of the first start, schtasks defaults
+//to the current date and time
+func (taskcreate TaskCreate) psStart() string {
+	if !taskcreate.StartBoundary.IsZero() {
+		return "([datetime]" + powershellQuote(formatBoundary(taskcreate.StartBoundary)) + ")"
+	}
+
+	date := ""
+	if t, err := time.Parse("01/02/2006", taskcreate.Startdate); err == nil {
+		date = t.Format("2006-01-02")
+	}
+	switch {
+	case date != "" && taskcreate.Starttime != "":
+		return "([datetime]" + powershellQuote(date+"T"+taskcreate.Starttime) + ")"
+	case date != "":
+		return "([datetime]" + powershellQuote(date) + ").Add((Get-Date).TimeOfDay)"
+	case taskcreate.Starttime != "":
+		return "([datetime]" + powershellQuote(taskcreate.Starttime) + ")"
+	}
+	return "(Get-Date)"
+}
+
+//psTimeSpan returns the New-TimeSpan expression of d
+func psTimeSpan(d time.Duration) string {
+	return fmt.Sprintf("(New-TimeSpan -Seconds %d)", int64(d/time.Second))
+}
@@ -0,0 +1,69 @@
+package tasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToPowerShell(t *testing.T) {
+	script, err := tasker.WithFolder("MyApp").ToPowerShell(TaskCreate{
+		Taskname:    "Backup",
+		Taskrun:     executable,
+		Arguments:   []string{"a", "b c"},
+		Schedule:    Schedules.WEEKLY,
+		Days:        []string{Days.MON, Days.FRI},
+		Modifier:    "2",
+		Startdate:   "12/31/2026",
+		Starttime:   "02:00",
+		Preempt:     time.Hour,
+		Level:       Level.HIGHEST,
+		Description: "nightly 'backup'",
+		Force:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"-Execute 'notepad.exe'",
+		"New-ScheduledTaskTrigger -Weekly -At ([datetime]'2026-12-31T02:00') -WeeksInterval 2 -DaysOfWeek Monday,Friday",
+		"-ExecutionTimeLimit (New-TimeSpan -Seconds 3600)",
+		"Item('MultipleInstances').Value = 3",
+		"-RunLevel Highest",
+		"-TaskName 'Backup' -TaskPath '\\MyApp\\'",
+		"-Description 'nightly ''backup''' -Force",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script lacks %q:\n%s", want, script)
+		}
+	}
+}
+
+func TestToPowerShellPassword(t *testing.T) {
+	script, err := tasker.ToPowerShell(TaskCreate{Taskname: taskName, Taskrun: executable, Schedule: Schedules.ONSTART,
+		Delay: 90 * time.Second, Username: "bob", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(script, "secret") {
+		t.Errorf("script contains the password:\n%s", script)
+	}
+	for _, want := range []string{"-AtStartup", "$trigger.Delay = 'PT1M30S'", "Get-Credential -UserName 'bob'"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script lacks %q:\n%s", want, script)
+		}
+	}
+}
+
+func TestToPowerShellNotSupported(t *testing.T) {
+	for _, taskcreate := range []TaskCreate{
+		{Taskname: taskName, Taskrun: executable, Schedule: Schedules.MONTHLY},
+		{Taskname: taskName, Taskrun: executable, Schedule: Schedules.ONIDLE, Idletime: "10"},
+		{Taskname: taskName, Taskrun: executable, Schedule: Schedules.DAILY, Enddate: "12/31/2026"},
+	} {
+		if _, err := tasker.ToPowerShell(taskcreate); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("ToPowerShell(%+v) = %v, want ErrNotSupported", taskcreate, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package tasker
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+//WithSysProcAttr returns a copy of the tasker calling attr to customize
+//the process attributes of every schtasks process after the console
+//window was hidden, e.g. to run it under another token. attr may be
+//called concurrently.
+func (task SchTask) WithSysProcAttr(attr func(*syscall.SysProcAttr)) SchTask {
+	task.procAttr = attr
+	return task
+}
+
+//WithCommand returns a copy of the tasker calling customize on every
+//schtasks process before it starts, e.g. to set its environment, working
+//directory or SysProcAttr token. It runs after the WithSysProcAttr hook,
+//standard input and output are set by the tasker afterwards. customize
+//may be called concurrently.
+func (task SchTask) WithCommand(customize func(*exec.Cmd)) SchTask {
+	task.customize = customize
+	return task
+}
+
+//newCommand prepares a process without a console window, so GUI
+//applications don't flash one on every call
+func newCommand(bin string, args ...string) *exec.Cmd {
+	cmd := exec.Command(bin, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+	hideWindow(cmd.SysProcAttr)
+	return cmd
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package tasker
+
+import (
+	"syscall"
+)
+
+func hideWindow(attr *syscall.SysProcAttr) {}
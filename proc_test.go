@@ -0,0 +1,39 @@
+package tasker
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+)
+
+func TestWithSysProcAttr(t *testing.T) {
+	if cmd := tasker.command(helpSwitch); cmd.SysProcAttr == nil {
+		t.Fatal("command() must set the process attributes")
+	}
+
+	var got *syscall.SysProcAttr
+	cmd := tasker.WithSysProcAttr(func(attr *syscall.SysProcAttr) {
+		got = attr
+	}).command(helpSwitch)
+	if got == nil || got != cmd.SysProcAttr {
+		t.Error("WithSysProcAttr hook not applied to the command")
+	}
+}
+
+func TestWithCommand(t *testing.T) {
+	var order []string
+	cmd := tasker.WithSysProcAttr(func(*syscall.SysProcAttr) {
+		order = append(order, "attr")
+	}).WithCommand(func(cmd *exec.Cmd) {
+		order = append(order, "command")
+		cmd.Dir = "C:\\"
+		cmd.Env = append(cmd.Environ(), "TZ=UTC")
+	}).command(helpSwitch)
+
+	if len(order) != 2 || order[0] != "attr" || order[1] != "command" {
+		t.Errorf("hooks ran in order %v, want [attr command]", order)
+	}
+	if cmd.Dir != "C:\\" || cmd.Env[len(cmd.Env)-1] != "TZ=UTC" {
+		t.Errorf("WithCommand hook not applied: dir %q env %v", cmd.Dir, cmd.Env)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package tasker
+
+import (
+	"syscall"
+)
+
+const (
+	createNoWindow = 0x08000000
+)
+
+//hideWindow starts console programs without a console window
+func hideWindow(attr *syscall.SysProcAttr) {
+	attr.HideWindow = true
+	attr.CreationFlags |= createNoWindow
+}
@@ -0,0 +1,86 @@
+package tasker
+
+import (
+	"context"
+)
+
+const (
+	//userSwitch and passwordSwitch remote credentials
+	userSwitch     = "/U"
+	passwordSwitch = "/P"
+)
+
+//CredentialProvider resolves the user and password stored under a
+//target name when a command is made, so secrets never live in structs
+//and rotated passwords are picked up by the next call. Plug in secrets
+//managers like Vault or DPAPI protected files with WithCredentials.
+type CredentialProvider interface {
+	Credential(ctx context.Context, target string) (user, password string, err error)
+}
+
+//CredentialFunc adapts a function to the CredentialProvider interface
+type CredentialFunc func(ctx context.Context, target string) (string, string, error)
+
+//Credential implements CredentialProvider
+func (f CredentialFunc) Credential(ctx context.Context, target string) (string, string, error) {
+	return f(ctx, target)
+}
+
+//CredentialManager provider reading generic credentials of the Windows
+//Credential Manager, the default, see ReadCredential.
+var CredentialManager CredentialProvider = CredentialFunc(func(ctx context.Context, target string) (string, string, error) {
+	return ReadCredential(target)
+})
+
+//WithCredentials returns a copy of the tasker resolving the credential
+//targets of TaskCreate.Credential and WithRemote through provider.
+func (task SchTask) WithCredentials(provider CredentialProvider) SchTask {
+	task.credentials = provider
+	return task
+}
+
+//Credentials returns the credential provider of the tasker
+func (task SchTask) Credentials() CredentialProvider {
+	if task.credentials == nil {
+		return CredentialManager
+	}
+	return task.credentials
+}
+
+//WithRemote returns a copy of the tasker managing the tasks of host
+//(/S). The user and password (/U /P) are resolved from the credential
+//target through the credential provider on every command, an empty
+//target connects as the current user. The copy doesn't share the cache
+//nor the snapshot of the local tasks.
+func (task SchTask) WithRemote(host, credential string) SchTask {
+	if host != task.host {
+		task.cache = nil
+		task.snapshot = nil
+	}
+	task.host = host
+	task.hostCredential = credential
+	return task
+}
+
+//withRemote inserts the remote system switches after the command, help
+//requests stay local
+func (task SchTask) withRemote(args []string) ([]string, error) {
+	if task.host == "" || len(args) == 0 || argValue(args, hostSwitch) != "" {
+		return args, nil
+	}
+	for _, arg := range args {
+		if arg == helpSwitch {
+			return args, nil
+		}
+	}
+
+	remote := []string{args[0], hostSwitch, task.host}
+	if task.hostCredential != "" {
+		user, password, err := task.Credentials().Credential(task.context(), task.hostCredential)
+		if err != nil {
+			return nil, err
+		}
+		remote = append(remote, userSwitch, user, passwordSwitch, password)
+	}
+	return append(remote, args[1:]...), nil
+}
@@ -0,0 +1,59 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCredentialProvider(t *testing.T) {
+	rotations := 0
+	provider := CredentialFunc(func(ctx context.Context, target string) (string, string, error) {
+		if target == "missing" {
+			return "", "", errors.New("no such secret")
+		}
+		rotations++
+		return "svc-" + target, "pass" + strconv.Itoa(rotations), nil
+	})
+
+	var calls [][]string
+	task := tasker.WithCredentials(provider).WithRemote("host1", "remote").WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		calls = append(calls, args)
+		return nil, nil
+	}))
+
+	task.RunTask(taskName, true)
+	task.RunTask(taskName, true)
+	want := []string{"/RUN", "/S", "host1", "/U", "svc-remote", "/P", "pass2", "/TN", "\\go-wintask-Test", "/I"}
+	if len(calls) != 2 || !reflect.DeepEqual(calls[1], want) {
+		t.Errorf("RunTask() ran %v, want %v", calls, want)
+	}
+
+	calls = nil
+	if _, err := task.CreateTask(TaskCreate{Taskname: taskName, Taskrun: executable, Credential: "runas"}); err != nil {
+		t.Fatal(err)
+	}
+	create := strings.Join(calls[len(calls)-1], " ")
+	if !strings.Contains(create, "/RU svc-runas /RP pass") || !strings.HasPrefix(create, "/CREATE /S host1") {
+		t.Errorf("CreateTask() ran %s", create)
+	}
+
+	calls = nil
+	if _, err := task.CreateTask(TaskCreate{Taskname: taskName, Taskrun: executable, Credential: "missing"}); err == nil || len(calls) != 0 {
+		t.Errorf("CreateTask() with an unresolved credential = %v, ran %v", err, calls)
+	}
+	if cmds := strings.Join(task.TaskMake(TaskCreate{Taskname: taskName, Credential: "missing"}, _Create.Command, true), " "); !strings.Contains(cmds, "/RP "+credentialMask) {
+		t.Errorf("TaskMake() with an unresolved credential = %s", cmds)
+	}
+
+	if _, err := task.WithRemote("host1", "missing").RunTask(taskName, true); err == nil {
+		t.Error("RunTask() with an unresolved remote credential succeeded")
+	}
+	if args, _ := task.withRemote([]string{"/CREATE", "/?"}); len(args) != 2 {
+		t.Errorf("help request made remote: %v", args)
+	}
+}
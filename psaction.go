@@ -0,0 +1,73 @@
+package tasker
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	//powershellCommand Windows PowerShell as the task action sees it
+	powershellCommand = `%SystemRoot%\System32\` + powershellFile
+	//pwshCommand PowerShell 7, found through PATH
+	pwshCommand = "pwsh.exe"
+	//defaultExecutionPolicy policy of the script sessions, the machine
+	//policy often forbids running scripts at all
+	defaultExecutionPolicy = "Bypass"
+)
+
+//PowerShellAction runs a PowerShell script file as the action of a task
+//with the switches it needs there: -NoProfile, -NonInteractive, an
+//execution policy letting the script run and -File with the script path
+//and arguments quoted, which is easy to get wrong by hand.
+type PowerShellAction struct {
+	//Script path of the .ps1 file
+	Script string
+
+	//Arguments passed to the script, each received unchanged
+	Arguments []string
+
+	//Core runs PowerShell 7 (pwsh.exe) instead of Windows PowerShell.
+	Core bool
+
+	//ExecutionPolicy of the session, defaults to Bypass.
+	ExecutionPolicy string
+
+	//Hidden starts the console window hidden, for tasks running in the
+	//session of a logged on user.
+	Hidden bool
+}
+
+//command returns the program and arguments running the script
+func (action PowerShellAction) command() (string, []string) {
+	run := powershellCommand
+	if action.Core {
+		run = pwshCommand
+	}
+	policy := action.ExecutionPolicy
+	if policy == "" {
+		policy = defaultExecutionPolicy
+	}
+
+	args := []string{"-NoProfile", "-NonInteractive", "-ExecutionPolicy", policy}
+	if action.Hidden {
+		args = append(args, "-WindowStyle", "Hidden")
+	}
+	//-File takes the rest of the command line
+	args = append(args, "-File", action.Script)
+	return run, append(args, action.Arguments...)
+}
+
+//checkPowerShellAction validates the PowerShell action, which replaces
+//Taskrun and Arguments
+func (taskcreate TaskCreate) checkPowerShellAction() error {
+	action := taskcreate.PowerShell
+	switch {
+	case action == nil:
+		return nil
+	case strings.TrimSpace(action.Script) == "":
+		return fmt.Errorf("%w: PowerShell action without a script", ErrInvalidValue)
+	case taskcreate.Taskrun != "" || len(taskcreate.Arguments) > 0 || taskcreate.ComHandler != nil:
+		return fmt.Errorf("%w: PowerShell action combined with Taskrun, Arguments or a COM handler", ErrInvalidValue)
+	}
+	return nil
+}
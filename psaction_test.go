@@ -0,0 +1,34 @@
+package tasker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPowerShellAction(t *testing.T) {
+	tc := TaskCreate{Taskname: taskName, PowerShell: &PowerShellAction{Script: `C:\My Scripts\backup.ps1`,
+		Arguments: []string{"-Target", `D:\Backup dir\`}}}
+	if err := tasker.checkCreate(tc, true); err != nil {
+		t.Fatal(err)
+	}
+	want := `"%SystemRoot%\System32\WindowsPowerShell\v1.0\powershell.exe" -NoProfile -NonInteractive -ExecutionPolicy Bypass ` +
+		`-File "C:\My Scripts\backup.ps1" -Target "D:\Backup dir\\"`
+	if cmds := tasker.TaskMake(tc, _Create.Command, true); !containsPair(cmds, _Create.taskrun, want) {
+		t.Errorf("TaskMake() = %v, want /TR %s", cmds, want)
+	}
+
+	run, args := TaskCreate{PowerShell: &PowerShellAction{Script: "job.ps1", Core: true, ExecutionPolicy: "RemoteSigned",
+		Hidden: true}}.action()
+	if run != pwshCommand || args != "-NoProfile -NonInteractive -ExecutionPolicy RemoteSigned -WindowStyle Hidden -File job.ps1" {
+		t.Errorf("action() = %s %s", run, args)
+	}
+
+	for _, tc := range []TaskCreate{
+		{Taskname: taskName, PowerShell: &PowerShellAction{}},
+		{Taskname: taskName, Taskrun: executable, PowerShell: &PowerShellAction{Script: "job.ps1"}},
+	} {
+		if err := tasker.checkCreate(tc, true); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("checkCreate(%+v) = %v, want ErrInvalidValue", tc, err)
+		}
+	}
+}
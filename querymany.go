@@ -0,0 +1,37 @@
+package tasker
+
+import "strings"
+
+//QueryMany looks up several tasks by their exact name with a single
+//enumeration, instead of one schtasks invocation per name. The map is
+//keyed by the requested names, tasks which are not registered are left
+//out.
+func (task SchTask) QueryMany(names []string, own bool) (map[string]Task, error) {
+	wanted := make(map[string][]string, len(names))
+	for _, name := range names {
+		path, err := task.resolveName(name, own)
+		if err != nil {
+			return nil, err
+		}
+		path = strings.ToLower(path)
+		wanted[path] = append(wanted[path], name)
+	}
+
+	found := make(map[string]Task, len(names))
+	if len(names) == 0 {
+		return found, nil
+	}
+
+	all, err := task.enumerate(own)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range all {
+		for _, name := range wanted[strings.ToLower(taskPath(t.name))] {
+			if _, ok := found[name]; !ok {
+				found[name] = t
+			}
+		}
+	}
+	return found, nil
+}
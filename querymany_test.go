@@ -0,0 +1,35 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestQueryMany(t *testing.T) {
+	calls := 0
+	task := tasker.WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		calls++
+		return []byte(summaryCSV), nil
+	}))
+
+	found, err := task.QueryMany([]string{"A", "b", "Missing"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("QueryMany() ran schtasks %d times, want 1", calls)
+	}
+	if len(found) != 2 || found["A"].name != "\\go-wintask-A" || found["b"].name != "\\go-wintask-B" {
+		t.Errorf("QueryMany() = %+v", found)
+	}
+
+	found, err = task.QueryMany([]string{"\\MyApp\\Sync"}, false)
+	if err != nil || found["\\MyApp\\Sync"].Status() != StatusDisabled {
+		t.Errorf("QueryMany() not owned = %+v, %v", found, err)
+	}
+
+	if _, err := task.QueryMany([]string{"A", "bad|name"}, true); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("QueryMany() invalid name = %v, want ErrInvalidName", err)
+	}
+}
@@ -0,0 +1,40 @@
+package tasker
+
+import (
+	"strings"
+)
+
+//quoteArg quotes an argument so a program parsing its command line with
+//the usual rules (CommandLineToArgvW, the C runtime) receives it
+//unchanged: arguments with blanks or quotes are wrapped in quotes,
+//inner quotes are escaped with a backslash and backslashes preceding a
+//quote are doubled. Batch files run through cmd.exe which doesn't follow
+//these rules.
+func quoteArg(arg string) string {
+	if arg == "" {
+		return "\"\""
+	}
+	if !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+
+	quoted := strings.Builder{}
+	quoted.WriteByte('"')
+	slashes := 0
+	for i := 0; i < len(arg); i++ {
+		switch arg[i] {
+		case '\\':
+			slashes++
+		case '"':
+			quoted.WriteString(strings.Repeat("\\", slashes+1))
+			slashes = 0
+		default:
+			slashes = 0
+		}
+		quoted.WriteByte(arg[i])
+	}
+	quoted.WriteString(strings.Repeat("\\", slashes))
+	quoted.WriteByte('"')
+
+	return quoted.String()
+}
@@ -0,0 +1,147 @@
+package tasker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//splitArgs splits a command line the way CommandLineToArgvW does
+func splitArgs(line string) []string {
+	args := []string{}
+	arg, quoted, started := strings.Builder{}, false, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\':
+			slashes := 0
+			for ; i < len(line) && line[i] == '\\'; i++ {
+				slashes++
+			}
+			if i < len(line) && line[i] == '"' {
+				arg.WriteString(strings.Repeat("\\", slashes/2))
+				if slashes%2 == 1 {
+					arg.WriteByte('"')
+				} else {
+					quoted = !quoted
+				}
+			} else {
+				arg.WriteString(strings.Repeat("\\", slashes))
+				i--
+			}
+			started = true
+		case c == '"':
+			quoted = !quoted
+			started = true
+		case (c == ' ' || c == '\t') && !quoted:
+			if started {
+				args = append(args, arg.String())
+				arg.Reset()
+				started = false
+			}
+		default:
+			arg.WriteByte(c)
+			started = true
+		}
+	}
+	if started {
+		args = append(args, arg.String())
+	}
+	return args
+}
+
+func TestQuoteArg(t *testing.T) {
+	cases := map[string]string{
+		"":                   `""`,
+		"plain":              `plain`,
+		`C:\dir\file.txt`:    `C:\dir\file.txt`,
+		`C:\Program Files\x`: `"C:\Program Files\x"`,
+		`C:\Program Files\`:  `"C:\Program Files\\"`,
+		`say "hi"`:           `"say \"hi\""`,
+		`"`:                  `"\""`,
+		`a\"b`:               `"a\\\"b"`,
+		"tab\there":          "\"tab\there\"",
+		`--opt=a b`:          `"--opt=a b"`,
+		`%PATH%`:             `%PATH%`,
+		`a&b|c`:              `a&b|c`,
+	}
+	for arg, want := range cases {
+		if got := quoteArg(arg); got != want {
+			t.Errorf("quoteArg(%q) = %s, want %s", arg, got, want)
+		}
+	}
+}
+
+func TestActionRoundTrip(t *testing.T) {
+	paths := []string{
+		`C:\app\app.exe`,
+		`C:\Program Files\app\app.exe`,
+		`\\server\share name\app.exe`,
+	}
+	argSets := [][]string{
+		{},
+		{""},
+		{"--flag"},
+		{"two words", "--x"},
+		{`C:\Program Files\`, `end\`},
+		{`say "hi"`, `"`, `""`},
+		{`a\"b`, `a\\"b`, `\\`},
+		{"tab\tand space", " lead", "trail "},
+		{`--out="C:\some dir\"`},
+		{"ünïcödé", "日本語 テキスト"},
+	}
+
+	for _, run := range paths {
+		for _, args := range argSets {
+			tc := TaskCreate{Taskname: taskName, Taskrun: run, Arguments: args}
+			cmds := tasker.TaskMake(tc, _Create.Command, true)
+			line := cmds[len(cmds)-1]
+
+			want := append([]string{run}, args...)
+			if got := splitArgs(line); !reflect.DeepEqual(got, want) {
+				t.Errorf("/TR %s parsed as %q, want %q", line, got, want)
+			}
+		}
+	}
+}
+
+func TestActionXML(t *testing.T) {
+	tc := TaskCreate{
+		Taskname:  taskName,
+		Taskrun:   `C:\Program Files\app\app.exe`,
+		Arguments: []string{`say "hi"`, "--x"},
+		ActionXML: true,
+	}
+	cmds := tasker.TaskMake(tc, _Create.Command, true)
+	if run := cmds[len(cmds)-1]; run != `"C:\Program Files\app\app.exe"` {
+		t.Errorf("/TR = %s, want the program only", run)
+	}
+
+	root, err := parseNode([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tc.patchDefinition(root) {
+		t.Fatal("patchDefinition reported no change")
+	}
+
+	data, err := root.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := ParseDefinition([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec := def.Actions.Exec[0]
+	if exec.Command != tc.Taskrun || exec.Arguments != `"say \"hi\"" --x` {
+		t.Errorf("patched action = %+v", exec)
+	}
+	if def.Principal().RunLevel != "LeastPrivilege" || def.RegistrationInfo.URI != "\\go-wintask-Test" {
+		t.Errorf("patched definition lost elements: %s", data)
+	}
+
+	if (TaskCreate{}).patchDefinition(root) {
+		t.Error("patchDefinition without ActionXML reported a change")
+	}
+}
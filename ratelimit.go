@@ -0,0 +1,53 @@
+package tasker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+//rateLimiter minimum interval between schtasks invocations shared by the
+//copies of a SchTask
+type rateLimiter struct {
+	sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+//WithRateLimit returns a copy of the tasker starting schtasks at most
+//once per interval, callers wait for their turn. Bulk operations and
+//aggressive pollers then don't flood shared hosts with processes.
+func (task SchTask) WithRateLimit(interval time.Duration) SchTask {
+	task.limiter = &rateLimiter{interval: interval}
+	return task
+}
+
+//wait blocks until the next invocation may start or ctx is done
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+	if limiter == nil || limiter.interval <= 0 {
+		return nil
+	}
+
+	//reserve a slot, later callers queue behind it
+	limiter.Lock()
+	now := time.Now()
+	at := limiter.next
+	if at.Before(now) {
+		at = now
+	}
+	limiter.next = at.Add(limiter.interval)
+	limiter.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,36 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	var starts []time.Time
+	task := tasker.WithRateLimit(20 * time.Millisecond).WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		starts = append(starts, time.Now())
+		return nil, nil
+	}))
+
+	for i := 0; i < 3; i++ {
+		if _, err := task.RunTask(taskName, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 1; i < len(starts); i++ {
+		if gap := starts[i].Sub(starts[i-1]); gap < 15*time.Millisecond {
+			t.Errorf("invocation %d started %v after the previous one", i, gap)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := task.WithRateLimit(time.Hour).WithContext(ctx)
+	slow.limiter.next = time.Now().Add(time.Hour)
+	if _, err := slow.RunTask(taskName, true); !errors.Is(err, context.Canceled) {
+		t.Errorf("RunTask() with a cancelled context = %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+)
+
+//Target system to query, see MergedQuery
+type Target struct {
+	//Host remote system (/S), "" for the local one.
+	Host string
+
+	//Credential target of the credential to connect with, see WithRemote.
+	Credential string
+}
+
+//WithTarget returns a copy of the tasker managing the tasks of target,
+//see WithRemote.
+func (task SchTask) WithTarget(target Target) SchTask {
+	return task.WithRemote(target.Host, target.Credential)
+}
+
+//MergedQuery queries the tasks matching name, see Query, on every target
+//and merges them into a single list, each task labeled with its Host.
+//The tasks of the reachable targets are returned even when others fail,
+//together with the failures.
+func (task SchTask) MergedQuery(name string, own bool, targets []Target) ([]Task, error) {
+	merged := []Task{}
+	var errs []error
+
+	for _, target := range targets {
+		remote := task.WithTarget(target)
+		all, err := remote.candidates(Filter{name, own})
+		if err != nil {
+			host := target.Host
+			if host == "" {
+				host = "local host"
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", host, err))
+			continue
+		}
+
+		filter := Filter{name, own}
+		for _, t := range all {
+			if remote.match(filter, t.name) {
+				merged = append(merged, t)
+			}
+		}
+	}
+
+	return merged, errors.Join(errs...)
+}
@@ -0,0 +1,62 @@
+package tasker
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergedQuery(t *testing.T) {
+	task := New(false).WithCache(time.Minute).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		host := argValue(args, hostSwitch)
+		if host == "down" {
+			return []byte("ERROR: The RPC server is unavailable.\r\n"), exitError(0x800706BA)
+		}
+		return []byte(strings.Replace(summaryCSV, "go-wintask-A", "go-wintask-"+host, 1)), nil
+	}))
+
+	tasks, err := task.MergedQuery("*", true, []Target{{}, {Host: "srv01"}, {Host: "down"}})
+	if err == nil || !strings.Contains(err.Error(), "down") {
+		t.Errorf("MergedQuery() error = %v, want the failure of down", err)
+	}
+	hosts := []string{}
+	for _, t := range tasks {
+		hosts = append(hosts, t.Host()+":"+t.Name())
+	}
+	want := ":\\go-wintask-,:\\go-wintask-B,srv01:\\go-wintask-srv01,srv01:\\go-wintask-B"
+	if got := strings.Join(hosts, ","); got != want {
+		t.Errorf("MergedQuery() = %s, want %s", got, want)
+	}
+
+	data, err := json.Marshal(tasks[2])
+	if err != nil || !strings.Contains(string(data), `"host":"srv01"`) {
+		t.Errorf("MarshalJSON() = %s, %v", data, err)
+	}
+	if data, _ := json.Marshal(tasks[0]); strings.Contains(string(data), "host") {
+		t.Errorf("MarshalJSON() local = %s", data)
+	}
+}
+
+func TestWithRemoteCache(t *testing.T) {
+	calls := 0
+	local := New(false).WithCache(time.Minute).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[1] == helpSwitch {
+			return nil, exitError(1)
+		}
+		calls++
+		return []byte(summaryCSV), nil
+	}))
+
+	if _, err := local.enumerate(false); err != nil {
+		t.Fatal(err)
+	}
+	all, err := local.WithRemote("srv01", "").enumerate(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || all[0].Host() != "srv01" {
+		t.Errorf("remote enumeration served from the local cache, %d calls, host %q", calls, all[0].Host())
+	}
+}
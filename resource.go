@@ -0,0 +1,149 @@
+package tasker
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	//idSeparator separates the path and GUID of a ResourceID, it can't
+	//appear in task names
+	idSeparator = "|"
+)
+
+//ResourceID canonical identifier of a registered task for infrastructure
+//as code providers, the normalized full path and, where available, the
+//registration GUID which tells a re-created task from the original.
+type ResourceID struct {
+	Path string
+	GUID string
+}
+
+//String formats the id as "\folder\name|{GUID}", or the path alone
+func (id ResourceID) String() string {
+	if id.GUID == "" {
+		return id.Path
+	}
+	return id.Path + idSeparator + id.GUID
+}
+
+//ParseResourceID parses the String form of an id
+func ParseResourceID(s string) (ResourceID, error) {
+	path, guid, _ := strings.Cut(s, idSeparator)
+	if err := ValidateName(path); err != nil {
+		return ResourceID{}, err
+	}
+	return ResourceID{Path: NormalizeName(path), GUID: strings.ToUpper(strings.TrimSpace(guid))}, nil
+}
+
+//ResourceIDOf returns the id of a registered task
+func (task SchTask) ResourceIDOf(name string, own bool) (ResourceID, error) {
+	name, err := task.resolveName(name, own)
+	if err != nil {
+		return ResourceID{}, err
+	}
+
+	//the definition query reports a missing task as ErrNotFound
+	if _, err := task.execute(_Query.Command, _Query.taskname, name, _Query.xml); err != nil {
+		return ResourceID{}, err
+	}
+
+	guid, err := task.registrationGUID(name)
+	if err != nil {
+		return ResourceID{}, err
+	}
+	return ResourceID{Path: name, GUID: guid}, nil
+}
+
+//CreateResource creates the owned task and returns its id
+func (task SchTask) CreateResource(taskcreate TaskCreate) (ResourceID, error) {
+	if err := task.checkLocal(); err != nil {
+		return ResourceID{}, err
+	}
+	if _, err := task.CreateTask(taskcreate); err != nil {
+		return ResourceID{}, err
+	}
+	if task.debugging() {
+		return ResourceID{Path: task.fullName(taskcreate.Taskname, true)}, nil
+	}
+	return task.ResourceIDOf(taskcreate.Taskname, true)
+}
+
+//ReadResource returns the definition of the task identified by id, a task
+//registered again under the same path since reports ErrNotFound
+func (task SchTask) ReadResource(id ResourceID) (TaskDefinition, error) {
+	if err := task.checkResource(id); err != nil {
+		return TaskDefinition{}, err
+	}
+	return task.GetTask(id.Path, false)
+}
+
+//UpdateResource replaces the definition of the task identified by id
+//like CreateTask with Force, the Taskname of taskcreate is ignored. The
+//id of the updated task is returned.
+func (task SchTask) UpdateResource(id ResourceID, taskcreate TaskCreate) (ResourceID, error) {
+	if err := task.checkLocal(); err != nil {
+		return ResourceID{}, err
+	}
+	if err := task.checkResource(id); err != nil {
+		return ResourceID{}, err
+	}
+
+	taskcreate.Taskname = id.Path
+	taskcreate.Force = true
+	if _, err := task.create(taskcreate, false); err != nil {
+		return ResourceID{}, err
+	}
+	if task.debugging() {
+		return id, nil
+	}
+	return task.ResourceIDOf(id.Path, false)
+}
+
+//DeleteResource deletes the task identified by id
+func (task SchTask) DeleteResource(id ResourceID) error {
+	if err := task.checkResource(id); err != nil {
+		return err
+	}
+	_, err := task.DeleteTask(id.Path, false, true)
+	return err
+}
+
+//checkResource verifies the task at the id path is still the identified
+//one, ids without GUID always match
+func (task SchTask) checkResource(id ResourceID) error {
+	if err := ValidateName(id.Path); err != nil {
+		return err
+	}
+	if id.GUID == "" || task.debugging() {
+		return nil
+	}
+
+	current, err := task.ResourceIDOf(id.Path, false)
+	if err != nil {
+		return err
+	}
+	if current.GUID != "" && !strings.EqualFold(current.GUID, id.GUID) {
+		return fmt.Errorf("%w: %s was registered again as %s", ErrNotFound, id, current.GUID)
+	}
+	return nil
+}
+
+//checkLocal rejects the resources of remote hosts, their registration
+//GUID can't be read from the local registry
+func (task SchTask) checkLocal() error {
+	if task.host != "" {
+		return fmt.Errorf("%w: resource ids of the tasks of %s", ErrNotSupported, task.host)
+	}
+	return nil
+}
+
+//registrationGUID reads the registration GUID of a task, see
+//registrationID. Remote tasks are rejected rather than reading the GUID
+//of the local task at their path.
+func (task SchTask) registrationGUID(name string) (string, error) {
+	if err := task.checkLocal(); err != nil {
+		return "", err
+	}
+	return registrationID(name), nil
+}
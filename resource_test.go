@@ -0,0 +1,66 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestResourceID(t *testing.T) {
+	id := ResourceID{Path: "\\app\\Backup", GUID: "{0A5C2F4E-1B2D-4C3E-9F10-112233445566}"}
+	parsed, err := ParseResourceID(id.String())
+	if err != nil || parsed != id {
+		t.Errorf("ParseResourceID(%s) = %+v, %v", id, parsed, err)
+	}
+
+	if parsed, err := ParseResourceID("\\app\\Backup|{0a5c2f4e-1b2d-4c3e-9f10-112233445566}"); err != nil || parsed != id {
+		t.Errorf("ParseResourceID() lower case GUID = %+v, %v", parsed, err)
+	}
+
+	parsed, err = ParseResourceID("app/Backup")
+	if err != nil || parsed.String() != "\\app\\Backup" {
+		t.Errorf("ParseResourceID() without GUID = %s, %v", parsed, err)
+	}
+
+	if _, err := ParseResourceID("Te:st|{GUID}"); !errors.Is(err, ErrInvalidName) {
+		t.Errorf("ParseResourceID() = %v, want ErrInvalidName", err)
+	}
+}
+
+func TestCreateResource(t *testing.T) {
+	id, err := tasker.WithDebug(true).CreateResource(TaskCreate{Taskname: taskName, Taskrun: executable})
+	if err != nil || id.Path != "\\go-wintask-Test" {
+		t.Errorf("CreateResource() = %+v, %v", id, err)
+	}
+}
+
+func TestUpdateResource(t *testing.T) {
+	var created []string
+	hooked := 0
+	task := New(false).WithHooks(Hooks{
+		OnBeforeCreate: func(*TaskCreate) error { hooked++; return nil },
+	}).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[1] == helpSwitch:
+			return nil, exitError(1)
+		case args[0] == _Query.Command:
+			return []byte(singletonXML), nil
+		case args[0] == _Create.Command:
+			created = append(created, argValue(args, _Create.taskname)+" "+argValue(args, _Create.xml))
+		}
+		return nil, nil
+	}))
+
+	id, err := task.UpdateResource(ResourceID{Path: "\\app\\Backup"}, TaskCreate{Taskname: "ignored", Taskrun: executable, Description: "updated"})
+	if err != nil || id.Path != "\\app\\Backup" {
+		t.Fatalf("UpdateResource() = %+v, %v", id, err)
+	}
+	if hooked != 1 || len(created) != 2 || created[0] != "\\app\\Backup " || !strings.HasPrefix(created[1], "\\app\\Backup ") || strings.HasSuffix(created[1], " ") {
+		t.Errorf("UpdateResource() ran %d hooks, created %v", hooked, created)
+	}
+
+	if _, err := task.WithRemote("host1", "").ResourceIDOf("\\app\\Backup", false); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("ResourceIDOf() of a remote task = %v, want ErrNotSupported", err)
+	}
+}
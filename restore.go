@@ -0,0 +1,63 @@
+package tasker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//RestoreResult outcome of restoring a single backup file
+type RestoreResult struct {
+	File string
+	Name string
+	Err  error
+}
+
+//Restore registers every task of a backup directory written by Backup
+//under its original name through the hooks and machine lock of the
+//tasker, existing tasks are replaced when overwrite is set.
+func (task SchTask) Restore(dir string, overwrite bool) ([]RestoreResult, error) {
+	return task.RestoreInto(dir, "", overwrite)
+}
+
+//RestoreInto is like Restore but registers the tasks below folder, e.g.
+//"\Restored", instead of the root folder.
+func (task SchTask) RestoreInto(dir, folder string, overwrite bool) ([]RestoreResult, error) {
+	results := []RestoreResult{}
+
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(file), backupExt) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		rel = strings.TrimSuffix(rel, filepath.Ext(rel))
+		name := strings.TrimSuffix(folder, "\\") + "\\" + strings.Replace(filepath.ToSlash(rel), "/", "\\", -1)
+
+		result := RestoreResult{File: file, Name: name}
+		root, err := readDefinition(file)
+		if err == nil {
+			_, err = task.create(TaskCreate{Taskname: name, Force: overwrite, definition: root}, false)
+		}
+		result.Err = err
+		results = append(results, result)
+		return nil
+	})
+
+	return results, err
+}
+
+//readDefinition parses a task XML file of a backup
+func readDefinition(file string) (*xmlNode, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return parseNode([]byte(decodeUTF16(data)))
+}
@@ -0,0 +1,59 @@
+package tasker
+
+import (
+	"strings"
+)
+
+//warningPrefixes localized prefixes of the schtasks warning lines, upper
+//case
+var warningPrefixes = []string{
+	"WARNING:", "WARNUNG:", "AVERTISSEMENT :", "AVERTISSEMENT:", "ADVERTENCIA:", "AVVISO:", "AVISO:", "警告:",
+}
+
+//Result output of a successful schtasks command, warnings like
+//	WARNING: Task may not run because /ST is earlier than current time.
+//apart from the success message
+type Result struct {
+	Output   string
+	Warnings []string
+}
+
+//ParseResult splits the warnings off the output of a schtasks command,
+//they are listed without their prefix
+func ParseResult(output string) Result {
+	result := Result{Warnings: []string{}}
+	lines := []string{}
+	for _, line := range strings.Split(strings.Replace(output, "\r\n", "\n", -1), "\n") {
+		if warning, ok := cutWarning(line); ok {
+			result.Warnings = append(result.Warnings, warning)
+			continue
+		}
+		lines = append(lines, line)
+	}
+	result.Output = strings.TrimSpace(strings.Join(lines, "\n"))
+	return result
+}
+
+//cutWarning returns the text of a warning line
+func cutWarning(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	upper := strings.ToUpper(trimmed)
+	for _, prefix := range warningPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return strings.TrimSpace(trimmed[len(prefix):]), true
+		}
+	}
+	return "", false
+}
+
+//CreateResult is CreateTask with the warnings of schtasks apart.
+func (task SchTask) CreateResult(taskcreate TaskCreate, opts ...CallOption) (Result, error) {
+	output, err := task.CreateTask(taskcreate, opts...)
+	return ParseResult(output), err
+}
+
+//ChangeResult is ChangeTask with the warnings of schtasks apart.
+func (task SchTask) ChangeResult(taskcreate TaskCreate, own bool, opts ...CallOption) (Result, error) {
+	output, err := task.ChangeTask(taskcreate, own, opts...)
+	return ParseResult(output), err
+}
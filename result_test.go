@@ -0,0 +1,36 @@
+package tasker
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestParseResult(t *testing.T) {
+	output := "WARNING: Task may not run because /ST is earlier than current time.\r\nSUCCESS: The scheduled task \"go-wintask-Test\" has successfully been created.\r\n"
+	want := Result{
+		Output:   "SUCCESS: The scheduled task \"go-wintask-Test\" has successfully been created.",
+		Warnings: []string{"Task may not run because /ST is earlier than current time."},
+	}
+	if got := ParseResult(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseResult() = %+v, want %+v", got, want)
+	}
+
+	if got := ParseResult("Warnung: Die Aufgabe wird möglicherweise nicht ausgeführt.\nERFOLGREICH: erstellt.\n"); len(got.Warnings) != 1 || got.Output != "ERFOLGREICH: erstellt." {
+		t.Errorf("ParseResult() localized = %+v", got)
+	}
+	if got := ParseResult(""); got.Output != "" || len(got.Warnings) != 0 {
+		t.Errorf("ParseResult() empty = %+v", got)
+	}
+}
+
+func TestCreateResult(t *testing.T) {
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		return []byte("WARNING: Task may not run because /ST is earlier than current time.\r\nSUCCESS: created.\r\n"), nil
+	}))
+
+	result, err := task.CreateResult(TaskCreate{Taskname: taskName, Taskrun: executable, Schedule: Schedules.ONCE, Starttime: "00:00"})
+	if err != nil || result.Output != "SUCCESS: created." || len(result.Warnings) != 1 {
+		t.Errorf("CreateResult() = %+v, %v", result, err)
+	}
+}
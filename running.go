@@ -0,0 +1,32 @@
+package tasker
+
+import "time"
+
+//Running returns the registered tasks currently running, once each even
+//when they have several triggers, see Elapsed for how long they run.
+func (task SchTask) Running() ([]TaskDetail, error) {
+	details, err := task.QueryDetail("*", false)
+	if err != nil {
+		return nil, err
+	}
+
+	running := []TaskDetail{}
+	seen := map[string]bool{}
+	for _, detail := range details {
+		if detail.Status != StatusRunning || seen[detail.Name] {
+			continue
+		}
+		seen[detail.Name] = true
+		running = append(running, detail)
+	}
+	return running, nil
+}
+
+//Elapsed time since the task last started, the run time of a running
+//task. Zero when the task never ran.
+func (detail TaskDetail) Elapsed() time.Duration {
+	if detail.LastRunTime.IsZero() {
+		return 0
+	}
+	return time.Since(detail.LastRunTime)
+}
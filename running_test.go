@@ -0,0 +1,31 @@
+package tasker
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunning(t *testing.T) {
+	running := strings.Replace(detailCSV, `"Ready"`, `"Running"`, 1)
+	other := strings.Replace(detailCSV, `go-wintask-Test`, `go-wintask-Other`, 1)
+	task := New(false).WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		//a task with two triggers is listed twice
+		return []byte(running + running + other), nil
+	}))
+
+	details, err := task.Running()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details) != 1 || details[0].Name != "\\go-wintask-Test" {
+		t.Fatalf("Running() = %+v", details)
+	}
+	if elapsed := details[0].Elapsed(); elapsed < time.Since(details[0].LastRunTime)-time.Second || elapsed <= 0 {
+		t.Errorf("Elapsed() = %v", elapsed)
+	}
+	if (TaskDetail{}).Elapsed() != 0 {
+		t.Error("Elapsed() of a task which never ran is not zero")
+	}
+}
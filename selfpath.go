@@ -0,0 +1,48 @@
+package tasker
+
+import (
+	"os"
+	"strings"
+)
+
+//UpdateSelfPath repoints owned tasks whose action runs a previous
+//install location of the current executable to os.Executable(), e.g.
+//after an upgrade moved the install directory, like ChangeAction does.
+//Returns the names of the updated tasks, the ones which would be in
+//debug mode.
+func (task SchTask) UpdateSelfPath() ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := task.owned()
+	if err != nil {
+		return nil, err
+	}
+
+	updated := []string{}
+	for _, t := range owned {
+		def, err := task.GetTask(t.name, false)
+		if err != nil {
+			return updated, err
+		}
+		if len(def.Actions.Exec) != 1 {
+			continue
+		}
+
+		action := def.Actions.Exec[0]
+		command := strings.Trim(action.Command, "\"")
+		if !strings.EqualFold(baseName(command), baseName(exe)) || strings.EqualFold(command, exe) {
+			continue
+		}
+
+		taskcreate := TaskCreate{Taskname: t.name, Taskrun: exe, rawArguments: action.Arguments}
+		if _, err := task.changeAction(taskcreate, false); err != nil {
+			return updated, err
+		}
+		updated = append(updated, t.name)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,51 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestUpdateSelfPath(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := `C:\old\` + baseName(exe)
+	var changed []string
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[1] == helpSwitch:
+			return nil, exitError(1)
+		case args[0] == _Change.Command:
+			changed = args
+			return nil, nil
+		case strings.Contains(strings.Join(args, " "), _Query.xml):
+			return []byte(strings.Replace(singletonXML, `"notepad.exe"`, `"`+old+`"`, 1)), nil
+		}
+		return []byte("\"\\go-wintask-Test\",\"N/A\",\"Ready\"\r\n"), nil
+	}))
+
+	hooked := 0
+	updated, err := task.WithHooks(Hooks{OnBeforeChange: func(*TaskCreate, bool) error { hooked++; return nil }}).UpdateSelfPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated) != 1 || hooked != 1 || changed[4] != `"`+exe+`" a "b c"` {
+		t.Errorf("UpdateSelfPath() = %v, ran %v with %d hooks", updated, changed, hooked)
+	}
+
+	changed = nil
+	if updated, err := task.WithDebug(true).UpdateSelfPath(); err != nil || len(updated) != 1 || changed != nil {
+		t.Errorf("debug UpdateSelfPath() = %v, %v, ran %v", updated, err, changed)
+	}
+}
+
+func TestChangeActionLimit(t *testing.T) {
+	taskcreate := TaskCreate{Taskname: taskName, Taskrun: executable, rawArguments: strings.Repeat("x", maxTaskrun)}
+	if _, err := tasker.WithDebug(true).changeAction(taskcreate, true); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("changeAction() too long = %v, want ErrInvalidValue", err)
+	}
+}
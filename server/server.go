@@ -0,0 +1,158 @@
+//Package server serves a small REST API over the tasks owned by the
+//library, so remote orchestration can manage the scheduled tasks of an
+//agent without RPC or WinRM.
+//
+//	GET    /tasks?name=...      verbose task list, see SchTask.QueryJSON
+//	POST   /tasks               create a task from a TaskCreate JSON body
+//	POST   /tasks/{name}/run    run a task on demand
+//	POST   /tasks/{name}/end    stop a running task
+//	DELETE /tasks/{name}        delete a task
+//
+//Every request must carry "Authorization: Bearer <token>".
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	tasker "github.com/janmir/go-wintask"
+)
+
+const (
+	//maxBody largest accepted request body
+	maxBody = 1 << 20
+)
+
+//Server http.Handler managing the owned tasks of a tasker
+type Server struct {
+	task  tasker.SchTask
+	token string
+}
+
+//result response body, either the schtasks output or the error
+type result struct {
+	Output string `json:",omitempty"`
+	Error  string `json:",omitempty"`
+}
+
+//New creates a server accepting requests authenticated with token, an
+//empty token rejects every request.
+func New(task tasker.SchTask, token string) *Server {
+	return &Server{
+		task:  task,
+		token: token,
+	}
+}
+
+//ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		reply(w, http.StatusUnauthorized, result{Error: http.StatusText(http.StatusUnauthorized)})
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	if path != "tasks" && !strings.HasPrefix(path, "tasks/") {
+		reply(w, http.StatusNotFound, result{Error: http.StatusText(http.StatusNotFound)})
+		return
+	}
+	parts := strings.Split(path, "/")
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.query(w, r)
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.create(w, r)
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		s.delete(w, parts[1])
+	case len(parts) == 3 && parts[2] == "run" && r.Method == http.MethodPost:
+		s.run(w, parts[1])
+	case len(parts) == 3 && parts[2] == "end" && r.Method == http.MethodPost:
+		s.end(w, parts[1])
+	default:
+		reply(w, http.StatusMethodNotAllowed, result{Error: http.StatusText(http.StatusMethodNotAllowed)})
+	}
+}
+
+//authorized compares the bearer token in constant time
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && s.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func (s *Server) query(w http.ResponseWriter, r *http.Request) {
+	data, err := s.task.QueryJSON(r.URL.Query().Get("name"), true)
+	if err != nil {
+		fail(w, "", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	taskcreate := tasker.TaskCreate{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody)).Decode(&taskcreate); err != nil {
+		reply(w, http.StatusBadRequest, result{Error: err.Error()})
+		return
+	}
+
+	output, err := s.task.CreateTask(taskcreate)
+	respond(w, http.StatusCreated, output, err)
+}
+
+func (s *Server) run(w http.ResponseWriter, name string) {
+	output, err := s.task.RunTask(name, true)
+	respond(w, http.StatusOK, output, err)
+}
+
+func (s *Server) end(w http.ResponseWriter, name string) {
+	output, err := s.task.EndTask(name, true)
+	respond(w, http.StatusOK, output, err)
+}
+
+func (s *Server) delete(w http.ResponseWriter, name string) {
+	output, err := s.task.DeleteTask(name, true, true)
+	respond(w, http.StatusOK, output, err)
+}
+
+//respond replies the output of a command or its failure
+func respond(w http.ResponseWriter, status int, output string, err error) {
+	if err != nil {
+		fail(w, output, err)
+		return
+	}
+	reply(w, status, result{Output: strings.TrimSpace(output)})
+}
+
+//fail replies an error with the status matching its cause
+func fail(w http.ResponseWriter, output string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, tasker.ErrInvalidName), errors.Is(err, tasker.ErrInvalidValue),
+		errors.Is(err, tasker.ErrNotV1Compatible), errors.Is(err, tasker.ErrNotSupported):
+		status = http.StatusBadRequest
+	case errors.Is(err, tasker.ErrAccessDenied), errors.Is(err, tasker.ErrElevationRequired):
+		status = http.StatusForbidden
+	case errors.Is(err, tasker.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, tasker.ErrAlreadyExists), errors.Is(err, tasker.ErrAlreadyRunning),
+		errors.Is(err, tasker.ErrTaskNotRunning):
+		status = http.StatusConflict
+	case errors.Is(err, tasker.ErrUnsupportedPlatform):
+		status = http.StatusNotImplemented
+	}
+	reply(w, status, result{Output: strings.TrimSpace(output), Error: err.Error()})
+}
+
+//reply writes a JSON response
+func reply(w http.ResponseWriter, status int, body result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tasker "github.com/janmir/go-wintask"
+)
+
+func TestServer(t *testing.T) {
+	handler := New(tasker.New(false).WithDebug(true), "secret")
+
+	do := func(method, target, token, body string) (int, result) {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		res := result{}
+		json.NewDecoder(w.Body).Decode(&res)
+		return w.Code, res
+	}
+
+	if code, _ := do("POST", "/tasks/Test/run", "", ""); code != http.StatusUnauthorized {
+		t.Errorf("missing token: status %d", code)
+	}
+	if code, _ := do("POST", "/tasks/Test/run", "wrong", ""); code != http.StatusUnauthorized {
+		t.Errorf("wrong token: status %d", code)
+	}
+
+	code, res := do("POST", "/tasks", "secret", `{"Taskname":"Test","Taskrun":"notepad.exe","Schedule":"ONLOGON"}`)
+	if code != http.StatusCreated || res.Output == "" {
+		t.Errorf("create: status %d %+v", code, res)
+	}
+	if code, res := do("POST", "/tasks", "secret", `{"Taskname":"Te:st"}`); code != http.StatusBadRequest {
+		t.Errorf("invalid name: status %d %+v", code, res)
+	}
+	if code, _ := do("POST", "/tasks", "secret", `{`); code != http.StatusBadRequest {
+		t.Errorf("malformed body: status %d", code)
+	}
+	if code, _ := do("DELETE", "/tasks/Test", "secret", ""); code != http.StatusOK {
+		t.Errorf("delete: status %d", code)
+	}
+}
@@ -0,0 +1,135 @@
+package tasker
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	serviceFile = "sc.exe"
+	serviceName = "Schedule"
+
+	//servicePoll interval between state checks while starting
+	servicePoll = 250 * time.Millisecond
+)
+
+//ServiceState state of the Task Scheduler service, the SERVICE_* values
+//of the service control manager
+type ServiceState int
+
+//Service states
+const (
+	ServiceUnknown         ServiceState = 0
+	ServiceStopped         ServiceState = 1
+	ServiceStartPending    ServiceState = 2
+	ServiceStopPending     ServiceState = 3
+	ServiceRunning         ServiceState = 4
+	ServiceContinuePending ServiceState = 5
+	ServicePausePending    ServiceState = 6
+	ServicePaused          ServiceState = 7
+)
+
+var serviceStates = map[ServiceState]string{
+	ServiceUnknown:         "UNKNOWN",
+	ServiceStopped:         "STOPPED",
+	ServiceStartPending:    "START_PENDING",
+	ServiceStopPending:     "STOP_PENDING",
+	ServiceRunning:         "RUNNING",
+	ServiceContinuePending: "CONTINUE_PENDING",
+	ServicePausePending:    "PAUSE_PENDING",
+	ServicePaused:          "PAUSED",
+}
+
+func (s ServiceState) String() string {
+	if name, ok := serviceStates[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+//ServiceStatus returns the state of the Task Scheduler service
+func ServiceStatus() (ServiceState, error) {
+	output, err := serviceControl("query", serviceName)
+	if err != nil {
+		return ServiceUnknown, err
+	}
+	return parseServiceState(output), nil
+}
+
+//EnsureServiceRunning starts the Task Scheduler service when stopped and
+//waits up to timeout for it to run, starting it requires administrator
+//rights.
+func EnsureServiceRunning(timeout time.Duration) error {
+	state, err := ServiceStatus()
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case ServiceRunning:
+		return nil
+	case ServicePaused:
+		_, err = serviceControl("continue", serviceName)
+	case ServiceStopped:
+		_, err = serviceControl("start", serviceName)
+	}
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for state != ServiceRunning {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %s", ErrServiceNotRunning, state)
+		}
+		time.Sleep(servicePoll)
+
+		if state, err = ServiceStatus(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//serviceControl runs the service control utility
+func serviceControl(args ...string) ([]byte, error) {
+	if !supported {
+		return nil, ErrUnsupportedPlatform
+	}
+
+	cmd := newCommand(systemBinary(serviceFile), args...)
+	output, err := cmd.CombinedOutput()
+	output = decodeOutput(output)
+	if isMissingBinary(err) {
+		return nil, fmt.Errorf("%w: %s", ErrBinaryNotFound, cmd.Path)
+	}
+	if err != nil {
+		return output, fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return output, nil
+}
+
+//parseServiceState reads the numeric state of sc query, e.g.
+//"STATE              : 4  RUNNING", the labels are not localized
+func parseServiceState(output []byte) ServiceState {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		label, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(label) != "STATE" {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			break
+		}
+		state, _ := strconv.Atoi(fields[0])
+		return ServiceState(state)
+	}
+	return ServiceUnknown
+}
@@ -0,0 +1,28 @@
+package tasker
+
+import (
+	"testing"
+)
+
+const serviceOutput = `
+SERVICE_NAME: Schedule
+        TYPE               : 30  WIN32
+        STATE              : 4  RUNNING
+                                (STOPPABLE, NOT_PAUSABLE, ACCEPTS_SHUTDOWN)
+        WIN32_EXIT_CODE    : 0  (0x0)
+        SERVICE_EXIT_CODE  : 0  (0x0)
+        CHECKPOINT         : 0x0
+        WAIT_HINT          : 0x0
+`
+
+func TestParseServiceState(t *testing.T) {
+	if state := parseServiceState([]byte(serviceOutput)); state != ServiceRunning {
+		t.Errorf("parseServiceState() = %s, want RUNNING", state)
+	}
+	if state := parseServiceState([]byte("[SC] OpenService FAILED 1060")); state != ServiceUnknown {
+		t.Errorf("parseServiceState() = %s, want UNKNOWN", state)
+	}
+	if s := ServiceState(9).String(); s != "9" {
+		t.Errorf("String() = %s", s)
+	}
+}
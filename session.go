@@ -0,0 +1,47 @@
+package tasker
+
+import (
+	"fmt"
+	"strings"
+)
+
+//interactiveToken LogonType of TASK_LOGON_INTERACTIVE_TOKEN
+const interactiveToken = "InteractiveToken"
+
+//RunInSession runs the task on demand in the session of the logged on
+//user, e.g. so a service shows a UI helper on the desktop of the console
+//user. The task is registered again for the interactive token of user
+//(TASK_LOGON_INTERACTIVE_TOKEN), which needs administrator rights, and
+//keeps it for later runs. Fails with ErrUserNotLoggedOn when user has no
+//session.
+func (task SchTask) RunInSession(name string, own bool, user string) (string, error) {
+	user = strings.TrimSpace(user)
+	if user == "" || isServiceAccount(user) {
+		return "", fmt.Errorf("%w: interactive user %q", ErrInvalidValue, user)
+	}
+	if task.debugging() {
+		return dbgMessage, nil
+	}
+
+	if output, err := task.updateDefinition(TaskCreate{Taskname: name}, own, func(root *xmlNode) bool {
+		return patchInteractive(root, user)
+	}); err != nil {
+		return string(output), err
+	}
+	return task.RunTask(name, own)
+}
+
+//patchInteractive sets the principal to the interactive token of user,
+//reports whether anything changed
+func patchInteractive(root *xmlNode, user string) bool {
+	principal := root.ensure("Principals", "Principal")
+	if strings.EqualFold(principal.get("UserId"), user) && principal.get("LogonType") == interactiveToken &&
+		principal.child("GroupId") == nil {
+		return false
+	}
+
+	principal.remove("GroupId")
+	principal.insert("UserId", "LogonType", "DisplayName", "RunLevel", "ProcessTokenSidType", "RequiredPrivileges").Text = user
+	principal.insert("LogonType", "DisplayName", "RunLevel", "ProcessTokenSidType", "RequiredPrivileges").Text = interactiveToken
+	return true
+}
@@ -0,0 +1,59 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestRunInSession(t *testing.T) {
+	registered := `<?xml version="1.0" encoding="UTF-16"?>
+<Task xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <Principals>
+    <Principal id="Author">
+      <GroupId>S-1-5-32-545</GroupId>
+      <RunLevel>LeastPrivilege</RunLevel>
+    </Principal>
+  </Principals>
+</Task>`
+	var ran []string
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		ran = append(ran, args[0])
+		switch args[0] {
+		case _Query.Command:
+			return []byte(registered), nil
+		case _Create.Command:
+			data, err := os.ReadFile(args[4])
+			if err != nil {
+				t.Fatal(err)
+			}
+			registered = decodeUTF16(data)
+		}
+		return nil, nil
+	}))
+
+	if _, err := task.RunInSession(taskName, true, "PC\\alice"); err != nil {
+		t.Fatal(err)
+	}
+	def, err := ParseDefinition([]byte(registered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := def.Principal(); p.UserID != "PC\\alice" || p.LogonType != interactiveToken || p.GroupID != "" || p.RunLevel != "LeastPrivilege" {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	if len(ran) != 3 || ran[2] != _Run.Command {
+		t.Errorf("RunInSession() ran %v", ran)
+	}
+
+	//already set up for the user, only run
+	ran = nil
+	if _, err := task.RunInSession(taskName, true, "pc\\ALICE"); err != nil || len(ran) != 2 {
+		t.Errorf("RunInSession() again = %v, ran %v", err, ran)
+	}
+
+	if _, err := task.RunInSession(taskName, true, Accounts.SYSTEM); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RunInSession() service account = %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package tasker
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	//stopExisting instance policy stopping the running instance before
+	//starting the new one
+	stopExisting = "StopExisting"
+)
+
+//patchSettings applies the boolean task settings schtasks has no switch
+//for, reports whether anything changed
+func (taskcreate TaskCreate) patchSettings(root *xmlNode) bool {
+	changed := false
+
+	if taskcreate.AllowHardTerminate != nil {
+		root.ensure("Settings", "AllowHardTerminate").Text = strconv.FormatBool(*taskcreate.AllowHardTerminate)
+		changed = true
+	}
+	if taskcreate.StopOnIdleEnd != nil {
+		idle := root.ensure("Settings", "IdleSettings")
+		idle.insert("StopOnIdleEnd", "RestartOnIdle").Text = strconv.FormatBool(*taskcreate.StopOnIdleEnd)
+		changed = true
+	}
+
+	if taskcreate.StartWhenAvailable != nil {
+		root.ensure("Settings", "StartWhenAvailable").Text = strconv.FormatBool(*taskcreate.StartWhenAvailable)
+		changed = true
+	}
+
+	if taskcreate.Preempt > 0 {
+		root.ensure("Settings", "MultipleInstancesPolicy").Text = stopExisting
+		root.ensure("Settings", "ExecutionTimeLimit").Text = xmlDuration(taskcreate.Preempt)
+		changed = true
+	}
+
+	return changed
+}
+
+//checkPreempt validates the preemption of hung runs, the scheduler stops
+//them by killing the action which AllowHardTerminate must permit
+func (taskcreate TaskCreate) checkPreempt() error {
+	switch {
+	case taskcreate.Preempt < 0 || taskcreate.Preempt > 0 && taskcreate.Preempt < time.Second:
+		return fmt.Errorf("%w: preempt time limit %v", ErrInvalidValue, taskcreate.Preempt)
+	case taskcreate.Preempt > 0 && taskcreate.AllowHardTerminate != nil && !*taskcreate.AllowHardTerminate:
+		return fmt.Errorf("%w: preempting runs requires AllowHardTerminate", ErrInvalidValue)
+	}
+	return nil
+}
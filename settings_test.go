@@ -0,0 +1,81 @@
+package tasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatchSettings(t *testing.T) {
+	no := false
+	tc := TaskCreate{Taskname: taskName, AllowHardTerminate: &no, StopOnIdleEnd: &no}
+	if !tc.needsPatch() {
+		t.Fatal("needsPatch is false with settings")
+	}
+
+	root, err := parseNode([]byte(strings.Replace(singletonXML, "</Task>",
+		"<Settings><IdleSettings><Duration>PT10M</Duration><RestartOnIdle>false</RestartOnIdle></IdleSettings></Settings></Task>", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tc.patchDefinition(root) {
+		t.Fatal("patchDefinition reported no change")
+	}
+	idle := root.child("Settings").child("IdleSettings")
+	if names := []string{idle.Nodes[0].XMLName.Local, idle.Nodes[1].XMLName.Local, idle.Nodes[2].XMLName.Local}; strings.Join(names, " ") != "Duration StopOnIdleEnd RestartOnIdle" {
+		t.Errorf("IdleSettings order = %v", names)
+	}
+
+	data, err := root.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := ParseDefinition([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Settings.AllowHardTerminate != "false" || def.Settings.StopOnIdleEnd != "false" {
+		t.Errorf("Settings = %+v", def.Settings)
+	}
+
+	if (TaskCreate{}).patchSettings(root) {
+		t.Error("patchSettings without settings reported a change")
+	}
+}
+
+func TestPreempt(t *testing.T) {
+	tc := TaskCreate{Taskname: taskName, Schedule: Schedules.HOURLY, Preempt: 55 * time.Minute}
+	if !tc.needsPatch() {
+		t.Fatal("needsPatch is false with Preempt")
+	}
+	if err := tc.checkPreempt(); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := parseNode([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tc.patchDefinition(root) {
+		t.Fatal("patchDefinition reported no change")
+	}
+	data, err := root.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := ParseDefinition([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Settings.MultipleInstancesPolicy != "StopExisting" || def.Settings.ExecutionTimeLimit != "PT55M" {
+		t.Errorf("Settings = %+v", def.Settings)
+	}
+
+	no := false
+	for _, tc := range []TaskCreate{{Preempt: -time.Minute}, {Preempt: time.Minute, AllowHardTerminate: &no}} {
+		if err := tc.checkPreempt(); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("checkPreempt(%+v) = %v, want ErrInvalidValue", tc, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package tasker
+
+import (
+	"strings"
+)
+
+//EnsureSingleton guarantees exactly one registered task exists for the
+//logical name of taskcreate. Copies the namespace registered under other
+//versions (e.g. "go-wintask-v1-Backup" for "Backup") are deleted, the
+//owned task is re-created when its schedule, action or run level drifted
+//and created when missing. Tasks outside the namespace are left alone.
+func (task SchTask) EnsureSingleton(taskcreate TaskCreate) (string, error) {
+	taskcreate.Force = true
+	if task.debugging() {
+		return task.CreateTask(taskcreate)
+	}
+	if _, err := task.resolveName(taskcreate.Taskname, true); err != nil {
+		return "", err
+	}
+
+	owned, err := task.owned()
+	if err != nil {
+		return "", err
+	}
+
+	name := task.fullName(taskcreate.Taskname, true)
+	found := false
+	for _, t := range owned {
+		switch {
+		case strings.EqualFold(taskPath(t.name), taskPath(name)):
+			found = true
+		case isCopyOf(task.Namespace().Trim(t.name), taskcreate.Taskname):
+			if _, err := task.DeleteTask(t.name, false, true); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if found {
+		def, err := task.GetTask(taskcreate.Taskname, true)
+		if err != nil {
+			return "", err
+		}
+		if !drifted(def, taskcreate) {
+			return "", nil
+		}
+	}
+
+	return task.CreateTask(taskcreate)
+}
+
+//isCopyOf reports whether the name an owned task was registered for is
+//a versioned copy of name, e.g. "v1-Backup" of "Backup"
+func isCopyOf(registered, name string) bool {
+	registered, name = strings.ToLower(registered), strings.ToLower(name)
+	return registered == name || strings.HasSuffix(registered, "-"+name)
+}
+
+//drifted reports whether the registered definition differs from the
+//requested task, see compareDefinition
+func drifted(def TaskDefinition, taskcreate TaskCreate) bool {
+	return len(compareDefinition(def, taskcreate)) > 0
+}
@@ -0,0 +1,106 @@
+package tasker
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const singletonXML = `<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <RegistrationInfo>
+    <URI>\go-wintask-Test</URI>
+  </RegistrationInfo>
+  <Principals>
+    <Principal id="Author">
+      <RunLevel>LeastPrivilege</RunLevel>
+    </Principal>
+  </Principals>
+  <Actions Context="Author">
+    <Exec>
+      <Command>"notepad.exe"</Command>
+      <Arguments>a "b c"</Arguments>
+    </Exec>
+  </Actions>
+</Task>`
+
+func TestIsCopyOf(t *testing.T) {
+	cases := map[string]bool{
+		"v1-test": true,
+		"Test":    true,
+		"Testing": false,
+		"Tes":     false,
+	}
+	for registered, want := range cases {
+		if got := isCopyOf(registered, "Test"); got != want {
+			t.Errorf("isCopyOf(%q) = %v, want %v", registered, got, want)
+		}
+	}
+}
+
+func TestEnsureSingleton(t *testing.T) {
+	list := `"\go-wintask-Test","N/A","Ready"
+"\go-wintask-v1-Test","N/A","Ready"
+"\Update-Test","N/A","Ready"
+"\Microsoft\Windows\Test","N/A","Ready"
+`
+	var calls []string
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[1] == helpSwitch {
+			return nil, exitError(1)
+		}
+		calls = append(calls, strings.Join(args, " "))
+		switch {
+		case args[0] == _Query.Command && argValue(args, _Query.taskname) != "":
+			return []byte(singletonXML), nil
+		case args[0] == _Query.Command:
+			return []byte(list), nil
+		}
+		return nil, nil
+	}))
+
+	tc := TaskCreate{Taskname: taskName, Taskrun: executable, Description: "single"}
+	if _, err := task.EnsureSingleton(tc); err != nil {
+		t.Fatal(err)
+	}
+
+	var deletes, creates []string
+	for _, call := range calls {
+		switch {
+		case strings.HasPrefix(call, _Delete.Command):
+			deletes = append(deletes, strings.TrimSuffix(call, " "+hresultSwitch))
+		case strings.HasPrefix(call, _Create.Command):
+			creates = append(creates, call)
+		}
+	}
+	if want := []string{"/DELETE /TN \\go-wintask-v1-Test /F"}; !reflect.DeepEqual(deletes, want) {
+		t.Errorf("EnsureSingleton() deleted %v, want %v", deletes, want)
+	}
+	if len(creates) != 2 || !strings.Contains(creates[0], _Create.force) || !strings.Contains(creates[1], _Create.xml) {
+		t.Errorf("EnsureSingleton() created %v, want the task and its patched definition", creates)
+	}
+}
+
+func TestDrifted(t *testing.T) {
+	def, err := ParseDefinition([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tc := TaskCreate{Taskname: taskName, Taskrun: executable, Arguments: []string{"a", "b c"}}
+	if drifted(def, tc) {
+		t.Error("expected identical definition")
+	}
+
+	tc.Level = Level.HIGHEST
+	if !drifted(def, tc) {
+		t.Error("expected run level drift")
+	}
+
+	tc.Level = ""
+	tc.Arguments = nil
+	if !drifted(def, tc) {
+		t.Error("expected action drift")
+	}
+}
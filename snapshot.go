@@ -0,0 +1,124 @@
+package tasker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+//snapshot owned tasks kept current by a background refresher, shared by
+//the copies of a SchTask
+type snapshot struct {
+	sync.RWMutex
+	tasks []Task
+	fresh bool
+}
+
+//WithRefresh returns a copy of the tasker refreshing a snapshot of the
+//owned tasks every interval in the background until ctx is done. Owned
+//queries, Exists and Status are served from the snapshot, UIs polling
+//task state then no longer wait for schtasks. Changes made through the
+//tasker refresh the snapshot on the next read. An interval <= 0 turns
+//the snapshot off.
+func (task SchTask) WithRefresh(ctx context.Context, interval time.Duration) SchTask {
+	if interval <= 0 {
+		task.snapshot = nil
+		return task
+	}
+
+	task.snapshot = &snapshot{}
+	go task.refresh(ctx, interval)
+	return task
+}
+
+//refresh updates the snapshot every interval until ctx is done, failed
+//enumerations keep the previous snapshot
+func (task SchTask) refresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		task.snapshot.update(task.enumerateOwned())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+//get returns the snapshot when current
+func (snap *snapshot) get() ([]Task, bool) {
+	if snap == nil {
+		return nil, false
+	}
+
+	snap.RLock()
+	defer snap.RUnlock()
+	return snap.tasks, snap.fresh
+}
+
+//update stores a successful enumeration
+func (snap *snapshot) update(tasks []Task, err error) {
+	if snap == nil || err != nil {
+		return
+	}
+
+	snap.Lock()
+	defer snap.Unlock()
+	snap.tasks = tasks
+	snap.fresh = true
+}
+
+//invalidate marks the snapshot outdated after a change
+func (snap *snapshot) invalidate() {
+	if snap == nil {
+		return
+	}
+
+	snap.Lock()
+	defer snap.Unlock()
+	snap.fresh = false
+}
+
+//Exists reports whether the task is registered
+func (task SchTask) Exists(name string, own bool, opts ...CallOption) (bool, error) {
+	task = task.with(opts)
+	_, ok, err := task.find(name, own)
+	return ok, err
+}
+
+//Status returns the status of the task, ErrNotFound when it is not
+//registered
+func (task SchTask) Status(name string, own bool, opts ...CallOption) (Status, error) {
+	task = task.with(opts)
+	t, ok, err := task.find(name, own)
+	if err != nil {
+		return StatusUnknown, err
+	}
+	if !ok {
+		return StatusUnknown, ErrNotFound
+	}
+	return t.Status(), nil
+}
+
+//find looks up the registered task by its exact name
+func (task SchTask) find(name string, own bool) (Task, bool, error) {
+	path, err := task.resolveName(name, own)
+	if err != nil {
+		return Task{}, false, err
+	}
+
+	all, err := task.enumerate(own)
+	if err != nil {
+		return Task{}, false, err
+	}
+	for _, t := range all {
+		if strings.EqualFold(taskPath(t.name), path) {
+			return t, true, nil
+		}
+	}
+	return Task{}, false, nil
+}
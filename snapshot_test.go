@@ -0,0 +1,55 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefresh(t *testing.T) {
+	var queries int32
+	backend := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[0] == _Query.Command {
+			atomic.AddInt32(&queries, 1)
+			return []byte(summaryCSV), nil
+		}
+		return nil, nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	task := backend.WithRefresh(ctx, time.Hour)
+	for deadline := time.Now().Add(time.Second); atomic.LoadInt32(&queries) == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+
+	if ok, err := task.Exists("A", true); !ok || err != nil {
+		t.Errorf("Exists() = %v, %v", ok, err)
+	}
+	if status, err := task.Status("B", true); status != StatusRunning || err != nil {
+		t.Errorf("Status() = %v, %v", status, err)
+	}
+	if _, err := task.Status("C", true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Status() missing = %v, want ErrNotFound", err)
+	}
+	if len(task.Query("*", true)) != 2 {
+		t.Error("Query() not served from the snapshot")
+	}
+	if n := atomic.LoadInt32(&queries); n != 1 {
+		t.Errorf("%d enumerations, want the refresher's only", n)
+	}
+
+	//a change outdates the snapshot
+	task.RunTask("A", true)
+	task.Exists("A", true)
+	if n := atomic.LoadInt32(&queries); n != 2 {
+		t.Errorf("%d enumerations after a change, want 2", n)
+	}
+
+	if off := task.WithRefresh(ctx, 0); off.snapshot != nil {
+		t.Error("WithRefresh() without an interval kept a snapshot")
+	}
+}
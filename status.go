@@ -0,0 +1,99 @@
+package tasker
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Status canonical task status, independent of the display language
+type Status string
+
+//Task statuses
+const (
+	StatusUnknown       Status = "Unknown"
+	StatusReady         Status = "Ready"
+	StatusRunning       Status = "Running"
+	StatusDisabled      Status = "Disabled"
+	StatusQueued        Status = "Queued"
+	StatusCouldNotStart Status = "CouldNotStart"
+)
+
+var (
+	//statusCodes TASK_STATE values of the Task Scheduler API
+	statusCodes = map[int]Status{
+		0: StatusUnknown,
+		1: StatusDisabled,
+		2: StatusQueued,
+		3: StatusReady,
+		4: StatusRunning,
+	}
+
+	//statusNames localized statuses printed by schtasks, lower case
+	statusNames = map[string]Status{
+		//English
+		"ready": StatusReady, "running": StatusRunning, "disabled": StatusDisabled,
+		"queued": StatusQueued, "could not start": StatusCouldNotStart, "couldnotstart": StatusCouldNotStart,
+		//German
+		"bereit": StatusReady, "wird ausgeführt": StatusRunning, "deaktiviert": StatusDisabled,
+		"in warteschlange": StatusQueued, "konnte nicht gestartet werden": StatusCouldNotStart,
+		//French
+		"prêt": StatusReady, "en cours d'exécution": StatusRunning, "désactivé": StatusDisabled,
+		"en file d'attente": StatusQueued, "impossible de démarrer": StatusCouldNotStart,
+		//Spanish
+		"listo": StatusReady, "en ejecución": StatusRunning, "deshabilitado": StatusDisabled,
+		"en cola": StatusQueued, "no se pudo iniciar": StatusCouldNotStart,
+		//Italian, Portuguese
+		"pronto": StatusReady, "in esecuzione": StatusRunning, "disabilitato": StatusDisabled,
+		"in coda": StatusQueued, "em execução": StatusRunning, "desabilitado": StatusDisabled,
+		"na fila": StatusQueued,
+		//Japanese
+		"準備完了": StatusReady, "実行中": StatusRunning, "無効": StatusDisabled,
+		"キューに登録済み": StatusQueued,
+	}
+)
+
+//ParseStatus maps a localized schtasks status or a TASK_STATE code to its
+//canonical status, StatusUnknown when not recognized
+func ParseStatus(value string) Status {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if code, err := strconv.Atoi(value); err == nil {
+		if status, ok := statusCodes[code]; ok {
+			return status
+		}
+		return StatusUnknown
+	}
+
+	if status, ok := statusNames[value]; ok {
+		return status
+	}
+	return StatusUnknown
+}
+
+//Name registered task path
+func (t Task) Name() string {
+	return t.name
+}
+
+//Host remote system the task was queried from, "" for the local one,
+//see WithRemote and MergedQuery
+func (t Task) Host() string {
+	return t.host
+}
+
+//NextRunTime next scheduled run, zero when not scheduled
+func (t Task) NextRunTime() time.Time {
+	return parseTime(t.datetime)
+}
+
+//Status canonical status of the task, see RawStatus for the text printed
+//by schtasks
+func (t Task) Status() Status {
+	return ParseStatus(t.status)
+}
+
+//RawStatus status as printed by schtasks in the display language
+func (t Task) RawStatus() string {
+	return t.status
+}
@@ -0,0 +1,23 @@
+package tasker
+
+import (
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	cases := map[string]Status{
+		"Ready":            StatusReady,
+		" Wird ausgeführt": StatusRunning,
+		"Désactivé":        StatusDisabled,
+		"Could not start":  StatusCouldNotStart,
+		"2":                StatusQueued,
+		"4":                StatusRunning,
+		"9":                StatusUnknown,
+		"N/A":              StatusUnknown,
+	}
+	for value, want := range cases {
+		if got := ParseStatus(value); got != want {
+			t.Errorf("ParseStatus(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
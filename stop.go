@@ -0,0 +1,98 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const taskkillFile = "taskkill.exe"
+
+//StopMethod how EndWithTimeout stopped a task
+type StopMethod string
+
+var (
+	//StopMethods results of EndWithTimeout
+	StopMethods = struct {
+		//NOTRUNNING the task was not running
+		NOTRUNNING StopMethod
+
+		//ENDED the task stopped within the grace period after /END
+		ENDED StopMethod
+
+		//KILLED the processes of the task were killed after the grace
+		//period
+		KILLED StopMethod
+	}{
+		NOTRUNNING: "NOTRUNNING",
+		ENDED:      "ENDED",
+		KILLED:     "KILLED",
+	}
+)
+
+//EndWithTimeout ends the task like EndTask, which only requests the
+//termination, and waits up to grace for it to leave Running. Tasks still
+//running are stopped by killing the process trees of their instances,
+//looked up through the Task Scheduler COM API.
+func (task SchTask) EndWithTimeout(name string, own bool, grace time.Duration) (StopMethod, error) {
+	path, err := task.resolveName(name, own)
+	if err != nil {
+		return "", err
+	}
+	if task.debugging() {
+		return StopMethods.ENDED, nil
+	}
+
+	if _, err := task.execute(_End.Command, _End.taskname, path); err != nil {
+		if errors.Is(err, ErrTaskNotRunning) {
+			return StopMethods.NOTRUNNING, nil
+		}
+		return "", err
+	}
+
+	//the cache and snapshot would hide the change
+	task.cache = nil
+	task.snapshot = nil
+
+	deadline := time.Now().Add(grace)
+	for {
+		status, err := task.Status(name, own)
+		if errors.Is(err, ErrNotFound) || err == nil && status != StatusRunning {
+			return StopMethods.ENDED, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if !time.Now().Before(deadline) {
+			break
+		}
+		time.Sleep(waitInterval)
+	}
+
+	if err := task.kill(path); err != nil {
+		return "", err
+	}
+	return StopMethods.KILLED, nil
+}
+
+//kill kills the process trees of the running instances of the task path
+func (task SchTask) kill(path string) error {
+	instances, err := task.instances(path)
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		args := []string{"/F", "/T", "/PID", strconv.Itoa(instance.PID)}
+		if task.host != "" {
+			args = append([]string{"/S", task.host}, args...)
+		}
+		cmd := newCommand(systemBinary(taskkillFile), args...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(decodeOutput(output))))
+		}
+	}
+	return nil
+}
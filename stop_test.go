@@ -0,0 +1,50 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestEndWithTimeout(t *testing.T) {
+	defer func(interval time.Duration) { waitInterval = interval }(waitInterval)
+	waitInterval = time.Millisecond
+
+	status := "Running"
+	polls := 0
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch args[0] {
+		case _End.Command:
+			if status == "Ready" {
+				return []byte("ERROR: The task is not running.\r\n"), exitError(0x8004130B)
+			}
+		case _Query.Command:
+			polls++
+			if polls == 2 && status != "Stuck" {
+				status = "Ready"
+			}
+			state := status
+			if state == "Stuck" {
+				state = "Running"
+			}
+			return []byte("\"\\go-wintask-Test\",\"N/A\",\"" + state + "\"\r\n"), nil
+		}
+		return nil, nil
+	}))
+
+	if method, err := task.EndWithTimeout(taskName, true, time.Second); method != StopMethods.ENDED || err != nil {
+		t.Errorf("EndWithTimeout() = %v, %v, want ENDED", method, err)
+	}
+	if method, err := task.EndWithTimeout(taskName, true, time.Second); method != StopMethods.NOTRUNNING || err != nil {
+		t.Errorf("EndWithTimeout() = %v, %v, want NOTRUNNING", method, err)
+	}
+
+	if supported {
+		return
+	}
+	status = "Stuck"
+	if _, err := task.EndWithTimeout(taskName, true, 5*time.Millisecond); !errors.Is(err, ErrUnsupportedPlatform) {
+		t.Errorf("EndWithTimeout() stuck = %v, want the kill attempted", err)
+	}
+}
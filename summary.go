@@ -0,0 +1,46 @@
+package tasker
+
+//Summary task counts by status, overall and per folder
+type Summary struct {
+	Total    int
+	ByStatus map[Status]int
+
+	//ByFolder counts by status keyed by folder path, "\" for the root
+	//folder
+	ByFolder map[string]map[Status]int
+}
+
+//QuerySummary counts the registered tasks, only the library's with own,
+//from a single enumeration
+func (task SchTask) QuerySummary(own bool) (Summary, error) {
+	list := task.list
+	if own {
+		list = task.owned
+	}
+	tasks, err := list()
+	if err != nil {
+		return Summary{}, err
+	}
+	return summarize(tasks), nil
+}
+
+//summarize counts tasks by status and folder
+func summarize(tasks []Task) Summary {
+	summary := Summary{
+		ByStatus: map[Status]int{},
+		ByFolder: map[string]map[Status]int{},
+	}
+	for _, t := range tasks {
+		status := t.Status()
+		folder := t.Path().Folder
+
+		summary.Total++
+		summary.ByStatus[status]++
+		if summary.ByFolder[folder] == nil {
+			summary.ByFolder[folder] = map[Status]int{}
+		}
+		summary.ByFolder[folder][status]++
+	}
+	return summary
+}
+
@@ -0,0 +1,43 @@
+package tasker
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+const summaryCSV = `"TaskName","Next Run Time","Status"
+"\go-wintask-A","N/A","Ready"
+"\go-wintask-B","N/A","Running"
+"\MyApp\Backup","N/A","Ready"
+"\MyApp\Sync","N/A","Disabled"
+"\MyApp\Sub\Clean","N/A","Ready"
+`
+
+func TestQuerySummary(t *testing.T) {
+	task := tasker.WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		return []byte(summaryCSV), nil
+	}))
+
+	summary, err := task.QuerySummary(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Summary{
+		Total:    5,
+		ByStatus: map[Status]int{StatusReady: 3, StatusRunning: 1, StatusDisabled: 1},
+		ByFolder: map[string]map[Status]int{
+			"\\":           {StatusReady: 1, StatusRunning: 1},
+			"\\MyApp":      {StatusReady: 1, StatusDisabled: 1},
+			"\\MyApp\\Sub": {StatusReady: 1},
+		},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("QuerySummary() = %+v, want %+v", summary, want)
+	}
+
+	summary, err = task.QuerySummary(true)
+	if err != nil || summary.Total != 2 || summary.ByFolder["\\"][StatusRunning] != 1 {
+		t.Errorf("QuerySummary(own) = %+v, %v", summary, err)
+	}
+}
@@ -0,0 +1,169 @@
+package tasker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	//SyncOps operations of a sync plan
+	SyncOps = struct {
+		CREATE, UPDATE, DELETE string
+	}{
+		CREATE: "create", UPDATE: "update", DELETE: "delete",
+	}
+)
+
+//Manifest desired state of the owned tasks
+//	{"tasks": [{"Taskname": "backup", "Taskrun": "C:\\app\\backup.exe",
+//	  "Schedule": "DAILY", "Starttime": "02:00"}], "prune": true}
+type Manifest struct {
+	Tasks []TaskCreate `json:"tasks"`
+
+	//Prune deletes owned tasks which are not in the manifest
+	Prune bool `json:"prune"`
+}
+
+//SyncStep a single change of a sync plan
+type SyncStep struct {
+	Op      string
+	Name    string
+	Task    TaskCreate
+	Changes []Difference
+}
+
+//SyncPlan changes bringing the registered tasks to the manifest state
+type SyncPlan struct {
+	Steps []SyncStep
+}
+
+//LoadManifest reads a JSON manifest. YAML isn't read as the package
+//has no dependencies, convert YAML manifests to JSON first.
+func LoadManifest(r io.Reader) (Manifest, error) {
+	manifest := Manifest{}
+	err := json.NewDecoder(r).Decode(&manifest)
+	return manifest, err
+}
+
+//String renders the plan for review
+func (plan SyncPlan) String() string {
+	if len(plan.Steps) == 0 {
+		return "No changes.\n"
+	}
+
+	out := ""
+	for _, step := range plan.Steps {
+		out += fmt.Sprintf("%s %s\n", step.Op, step.Name)
+		for _, change := range step.Changes {
+			out += "\t" + change.String() + "\n"
+		}
+	}
+	return out
+}
+
+//Plan computes the steps bringing the owned tasks to the manifest state
+func (task SchTask) Plan(manifest Manifest) (SyncPlan, error) {
+	plan := SyncPlan{Steps: []SyncStep{}}
+
+	report, err := task.Audit(manifest.Tasks)
+	if err != nil {
+		return plan, err
+	}
+
+	missing := map[string]bool{}
+	for _, name := range report.Missing {
+		missing[strings.ToLower(name)] = true
+	}
+
+	for _, taskcreate := range manifest.Tasks {
+		name := task.fullName(taskcreate.Taskname, true)
+		if missing[strings.ToLower(name)] {
+			plan.Steps = append(plan.Steps, SyncStep{Op: SyncOps.CREATE, Name: name, Task: taskcreate})
+		} else if changes, ok := report.Drift[name]; ok {
+			plan.Steps = append(plan.Steps, SyncStep{Op: SyncOps.UPDATE, Name: name, Task: taskcreate, Changes: changes})
+		}
+	}
+
+	if manifest.Prune {
+		for _, name := range report.Extra {
+			plan.Steps = append(plan.Steps, SyncStep{Op: SyncOps.DELETE, Name: name})
+		}
+	}
+
+	return plan, nil
+}
+
+//Apply performs the steps of the plan through CreateTask and DeleteTask,
+//so the defaults, hooks and machine lock of the tasker apply. Updates
+//re-create the task as schtasks /CHANGE cannot modify triggers.
+func (task SchTask) Apply(plan SyncPlan) error {
+	for _, step := range plan.Steps {
+		var err error
+		switch step.Op {
+		case SyncOps.CREATE, SyncOps.UPDATE:
+			taskcreate := step.Task
+			taskcreate.Force = true
+			_, err = task.CreateTask(taskcreate)
+		case SyncOps.DELETE:
+			_, err = task.DeleteTask(step.Name, false, true)
+		default:
+			return fmt.Errorf("unknown sync operation %q", step.Op)
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", step.Op, step.Name, err)
+		}
+	}
+
+	return nil
+}
+
+//Sync reads a manifest, writes its plan to out when not nil and applies
+//it unless dryRun is set. Applying is idempotent, a second sync plans
+//no changes.
+func (task SchTask) Sync(r io.Reader, out io.Writer, dryRun bool) (SyncPlan, error) {
+	manifest, err := LoadManifest(r)
+	if err != nil {
+		return SyncPlan{}, err
+	}
+
+	plan, err := task.Plan(manifest)
+	if err != nil {
+		return plan, err
+	}
+
+	if out != nil {
+		io.WriteString(out, plan.String())
+	}
+	if dryRun {
+		return plan, nil
+	}
+
+	return plan, task.Apply(plan)
+}
+
+//ImportManifest registers the tasks of a JSON manifest in a single call,
+//e.g. to bootstrap every scheduled task of an application when it is
+//installed. With prune the owned tasks missing from the manifest are
+//deleted, whatever its Prune says. The tasks are validated before any is
+//registered, registered tasks which don't differ are left alone.
+func (task SchTask) ImportManifest(r io.Reader, prune bool) (SyncPlan, error) {
+	manifest, err := LoadManifest(r)
+	if err != nil {
+		return SyncPlan{}, err
+	}
+	manifest.Prune = prune
+
+	for _, taskcreate := range manifest.Tasks {
+		if err := task.checkCreate(taskcreate, true); err != nil {
+			return SyncPlan{}, fmt.Errorf("%s: %w", taskcreate.Taskname, err)
+		}
+	}
+
+	plan, err := task.Plan(manifest)
+	if err != nil {
+		return plan, err
+	}
+	return plan, task.Apply(plan)
+}
@@ -0,0 +1,94 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadManifest(t *testing.T) {
+	manifest, err := LoadManifest(strings.NewReader(`{"tasks": [{"Taskname": "Test", "Taskrun": "notepad.exe", "Schedule": "DAILY"}], "prune": true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !manifest.Prune || len(manifest.Tasks) != 1 || manifest.Tasks[0].Taskrun != executable {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+}
+
+func TestSyncPlanString(t *testing.T) {
+	plan := SyncPlan{Steps: []SyncStep{
+		{Op: SyncOps.UPDATE, Name: "go-wintask-Test", Changes: []Difference{{"Schedule", "DAILY", "WEEKLY"}}},
+		{Op: SyncOps.DELETE, Name: "\\go-wintask-Old"},
+	}}
+
+	want := "update go-wintask-Test\n\tSchedule: \"DAILY\" -> \"WEEKLY\"\ndelete \\go-wintask-Old\n"
+	if got := plan.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestImportManifest(t *testing.T) {
+	var ran []string
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[0] == _Query.Command && argValue(args, _Query.taskname) != "":
+			return []byte(singletonXML), nil
+		case args[0] == _Query.Command:
+			return []byte(summaryCSV), nil
+		case args[1] != helpSwitch:
+			ran = append(ran, args[0]+" "+argValue(args, _Create.taskname))
+		}
+		return nil, nil
+	}))
+
+	manifest := `{"tasks": [{"Taskname": "A", "Taskrun": "notepad.exe", "Arguments": ["a", "b c"]}, {"Taskname": "C", "Taskrun": "calc.exe"}]}`
+	plan, err := task.ImportManifest(strings.NewReader(manifest), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/CREATE \\go-wintask-C", "/DELETE \\go-wintask-B"}
+	if len(plan.Steps) != 2 || !reflect.DeepEqual(ran, want) {
+		t.Errorf("ImportManifest() ran %v, want %v, plan %v", ran, want, plan)
+	}
+
+	ran = nil
+	invalid := `{"tasks": [{"Taskname": "A", "Taskrun": "notepad.exe"}, {"Taskname": "B", "Taskrun": "calc.exe", "Schedule": "DAILY", "Delay": 60000000000}]}`
+	if _, err := task.ImportManifest(strings.NewReader(invalid), false); !errors.Is(err, ErrInvalidValue) || len(ran) != 0 {
+		t.Errorf("ImportManifest() invalid = %v, ran %v", err, ran)
+	}
+}
+
+func TestApply(t *testing.T) {
+	var ran []string
+	hooked := 0
+	task := New(false).WithHooks(Hooks{
+		OnBeforeCreate: func(*TaskCreate) error { hooked++; return nil },
+		OnBeforeDelete: func(string, bool) error { hooked++; return nil },
+	}).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch {
+		case args[1] == helpSwitch:
+			return nil, exitError(1)
+		case args[0] == _Query.Command:
+			return []byte(singletonXML), nil
+		}
+		ran = append(ran, args[0]+" "+argValue(args, _Create.taskname)+" "+argValue(args, _Create.xml))
+		return nil, nil
+	}))
+
+	plan := SyncPlan{Steps: []SyncStep{
+		{Op: SyncOps.UPDATE, Name: "\\go-wintask-A", Task: TaskCreate{Taskname: "A", Taskrun: executable, Description: "synced"}},
+		{Op: SyncOps.DELETE, Name: "\\go-wintask-B"},
+	}}
+	if err := task.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(ran) != 3 || !strings.HasPrefix(ran[1], "/CREATE \\go-wintask-A ") || strings.HasSuffix(ran[1], " ") {
+		t.Errorf("Apply() ran %v, want the create, its patched definition and the delete", ran)
+	}
+	if hooked != 2 {
+		t.Errorf("Apply() ran %d hooks, want 2", hooked)
+	}
+}
@@ -0,0 +1,46 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	//systemFolder folder of the tasks shipped with windows
+	systemFolder = "\\Microsoft"
+)
+
+var (
+	//ErrSystemTask the operation would modify a task of the operating
+	//system, see WithSystemGuard
+	ErrSystemTask = errors.New("tasker: system tasks are read-only")
+)
+
+//WithSystemGuard returns a copy of the tasker browsing the \Microsoft
+//folders of the operating system tasks read-only: queries work as usual
+//but creating, changing or deleting a task below \Microsoft fails with
+//ErrSystemTask, protecting automation from clobbering OS tasks by
+//accident. WithSystemGuard(false) explicitly allows it again.
+func (task SchTask) WithSystemGuard(enabled bool) SchTask {
+	task.systemGuard = enabled
+	return task
+}
+
+//IsSystemTask reports whether the task path lies in the \Microsoft
+//folders of the operating system tasks
+func IsSystemTask(name string) bool {
+	path := strings.ToLower(NormalizeName(name))
+	return strings.HasPrefix(path, strings.ToLower(systemFolder)+"\\")
+}
+
+//guardSystem refuses the commands modifying a system task when guarded
+func (task SchTask) guardSystem(args []string) error {
+	if !task.systemGuard || !mutates(args) {
+		return nil
+	}
+	if name := argValue(args, _Create.taskname); name != "" && IsSystemTask(name) {
+		return fmt.Errorf("%w: %s %s", ErrSystemTask, args[0], name)
+	}
+	return nil
+}
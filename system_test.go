@@ -0,0 +1,50 @@
+package tasker
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestWithSystemGuard(t *testing.T) {
+	if !IsSystemTask("Microsoft/Windows/Defrag/ScheduledDefrag") || IsSystemTask("\\MicrosoftEdgeUpdate") {
+		t.Error("IsSystemTask() misclassified")
+	}
+
+	var ran []string
+	backend := backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if argValue(args, _Query.taskname) != "" {
+			ran = append(ran, args[0])
+		}
+		return []byte(summaryCSV), nil
+	})
+	guarded := New(false).WithBackend(backend).WithSystemGuard(true)
+	system := "\\Microsoft\\Windows\\Defrag\\ScheduledDefrag"
+
+	if _, err := guarded.ExportXML(system, false); err != nil {
+		t.Errorf("ExportXML() = %v", err)
+	}
+	if _, err := guarded.DeleteTask(system, false, true); !errors.Is(err, ErrSystemTask) {
+		t.Errorf("DeleteTask() = %v, want ErrSystemTask", err)
+	}
+	if _, err := guarded.ChangeTask(TaskCreate{Taskname: system, Taskrun: executable}, false); !errors.Is(err, ErrSystemTask) {
+		t.Errorf("ChangeTask() = %v, want ErrSystemTask", err)
+	}
+	if len(ran) != 1 || ran[0] != _Query.Command {
+		t.Errorf("ran %v, want only the query", ran)
+	}
+
+	//registering an edited definition is refused as well
+	guarded = guarded.WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		return []byte(calendarXML), nil
+	}))
+	logon := Trigger{XMLName: xml.Name{Local: "LogonTrigger"}}
+	if _, err := guarded.EnsureTrigger(system, false, logon); !errors.Is(err, ErrSystemTask) {
+		t.Errorf("EnsureTrigger() = %v, want ErrSystemTask", err)
+	}
+
+	if _, err := guarded.WithSystemGuard(false).DeleteTask(system, false, true); err != nil {
+		t.Errorf("DeleteTask() allowed = %v", err)
+	}
+}
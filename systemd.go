@@ -0,0 +1,181 @@
+package tasker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//SystemdUnits systemd equivalent of a task, a best-effort mapping for
+//documentation and migration
+type SystemdUnits struct {
+	//Service oneshot unit running the actions
+	Service string
+
+	//Timer unit starting the service, empty without time or boot
+	//triggers
+	Timer string
+
+	//Warnings parts of the task without an exact equivalent
+	Warnings []string
+}
+
+//xmlMonths month element names of the task XML in calendar order
+var xmlMonths = []string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+//ExportSystemd converts the definition to unit files named after unit,
+//e.g. "backup" for backup.service and backup.timer. Windows paths of the
+//actions are kept as is.
+func ExportSystemd(def TaskDefinition, unit string) SystemdUnits {
+	units := SystemdUnits{Warnings: []string{}}
+	warn := func(format string, args ...interface{}) {
+		units.Warnings = append(units.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	description, _ := splitTags(def.RegistrationInfo.Description)
+	if description == "" {
+		description = unit
+	}
+
+	service := &strings.Builder{}
+	fmt.Fprintf(service, "[Unit]\nDescription=%s\n\n[Service]\nType=oneshot\n", firstLine(description))
+	for i, exec := range def.Actions.Exec {
+		fmt.Fprintf(service, "ExecStart=%s\n", strings.TrimSpace(exec.Command+" "+exec.Arguments))
+		if exec.WorkingDirectory != "" {
+			if i == 0 {
+				fmt.Fprintf(service, "WorkingDirectory=%s\n", exec.WorkingDirectory)
+			} else {
+				warn("working directory of action %d dropped", i+1)
+			}
+		}
+	}
+	if len(def.Actions.Exec) == 0 {
+		warn("no program actions")
+	}
+	if n := len(def.Actions.ComHandler) + len(def.Actions.SendEmail) + len(def.Actions.ShowMessage); n > 0 {
+		warn("%d COM handler, e-mail or message actions dropped", n)
+	}
+	if user := def.Principal().UserID; user != "" && !isServiceAccount(user) {
+		fmt.Fprintf(service, "User=%s\n", baseName(user))
+	}
+	if limit, err := parseXMLDuration(def.Settings.ExecutionTimeLimit); err == nil && limit > 0 {
+		fmt.Fprintf(service, "TimeoutStartSec=%d\n", int(limit.Seconds()))
+	}
+	units.Service = service.String()
+
+	timer := &strings.Builder{}
+	for _, trigger := range def.Triggers.Items {
+		for _, line := range systemdTrigger(trigger, warn) {
+			timer.WriteString(line + "\n")
+		}
+	}
+	if timer.Len() == 0 {
+		warn("no time or boot triggers, the service has no timer")
+		return units
+	}
+	if def.Settings.StartWhenAvailable == "true" {
+		timer.WriteString("Persistent=true\n")
+	}
+	units.Timer = fmt.Sprintf("[Unit]\nDescription=%s\n\n[Timer]\n%sUnit=%s.service\n\n[Install]\nWantedBy=timers.target\n",
+		firstLine(description), timer.String(), unit)
+	return units
+}
+
+//systemdTrigger returns the timer directives of a trigger
+func systemdTrigger(trigger Trigger, warn func(string, ...interface{})) []string {
+	date, clock := splitBoundary(trigger.StartBoundary)
+	lines := []string{}
+
+	switch trigger.Kind() {
+	case "BootTrigger":
+		delay, _ := parseXMLDuration(trigger.Delay)
+		return []string{"OnBootSec=" + strconv.Itoa(int(delay.Seconds()))}
+	case "TimeTrigger":
+		lines = append(lines, "OnCalendar="+date+" "+clock)
+	case "CalendarTrigger":
+		switch {
+		case trigger.ScheduleByDay != nil:
+			calendar := "*-*-*"
+			if n := trigger.ScheduleByDay.DaysInterval; n > 1 {
+				calendar = "*-*-01/" + strconv.Itoa(n)
+				warn("every %d days restarts each month", n)
+			}
+			lines = append(lines, "OnCalendar="+calendar+" "+clock)
+		case trigger.ScheduleByWeek != nil:
+			days := []string{}
+			for _, day := range trigger.ScheduleByWeek.DaysOfWeek.Names() {
+				days = append(days, day[:3])
+			}
+			if trigger.ScheduleByWeek.WeeksInterval > 1 {
+				warn("every %d weeks runs weekly", trigger.ScheduleByWeek.WeeksInterval)
+			}
+			lines = append(lines, "OnCalendar="+strings.Join(days, ",")+" *-*-* "+clock)
+		case trigger.ScheduleByMonth != nil:
+			months := []string{}
+			for _, month := range trigger.ScheduleByMonth.Months.Names() {
+				for i, name := range xmlMonths {
+					if name == month {
+						months = append(months, fmt.Sprintf("%02d", i+1))
+					}
+				}
+			}
+			month := "*"
+			if len(months) > 0 && len(months) < len(xmlMonths) {
+				month = strings.Join(months, ",")
+			}
+			days := []string{}
+			for _, day := range trigger.ScheduleByMonth.DaysOfMonth.Day {
+				if day == "Last" {
+					lines = append(lines, "OnCalendar=*-"+month+"~01 "+clock)
+					continue
+				}
+				days = append(days, day)
+			}
+			if len(days) > 0 {
+				lines = append(lines, "OnCalendar=*-"+month+"-"+strings.Join(days, ",")+" "+clock)
+			}
+		default:
+			warn("%s with a day of week in month has no equivalent", trigger.Kind())
+			return nil
+		}
+	default:
+		warn("%s has no equivalent", trigger.Kind())
+		return nil
+	}
+
+	if trigger.Repetition != nil && trigger.Repetition.Interval != "" {
+		interval, err := parseXMLDuration(trigger.Repetition.Interval)
+		if err == nil {
+			lines = append(lines, "OnUnitActiveSec="+strconv.Itoa(int(interval.Seconds())))
+			if trigger.Repetition.Duration != "" {
+				warn("repetition duration %s dropped", trigger.Repetition.Duration)
+			}
+		}
+	}
+	if delay := trigger.RandomDelay; delay != "" {
+		if d, err := parseXMLDuration(delay); err == nil {
+			lines = append(lines, "RandomizedDelaySec="+strconv.Itoa(int(d.Seconds())))
+		}
+	}
+	return lines
+}
+
+//splitBoundary splits a trigger boundary into its systemd date and time,
+//the time zone is dropped
+func splitBoundary(boundary string) (string, string) {
+	date, clock, _ := strings.Cut(boundary, "T")
+	if len(clock) > 8 {
+		clock = clock[:8]
+	}
+	if clock == "" {
+		clock = "00:00:00"
+	}
+	return date, clock
+}
+
+//firstLine returns the first line of text
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,81 @@
+package tasker
+
+import (
+	"strings"
+	"testing"
+)
+
+const systemdXML = `<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <RegistrationInfo>
+    <URI>\go-wintask-backup</URI>
+    <Description>Nightly backup
+tags:app</Description>
+  </RegistrationInfo>
+  <Triggers>
+    <CalendarTrigger>
+      <StartBoundary>2026-10-15T02:30:00+02:00</StartBoundary>
+      <ScheduleByWeek>
+        <WeeksInterval>1</WeeksInterval>
+        <DaysOfWeek><Monday /><Friday /></DaysOfWeek>
+      </ScheduleByWeek>
+      <RandomDelay>PT5M</RandomDelay>
+    </CalendarTrigger>
+    <CalendarTrigger>
+      <StartBoundary>2026-10-15T04:00:00</StartBoundary>
+      <ScheduleByMonth>
+        <DaysOfMonth><Day>1</Day><Day>Last</Day></DaysOfMonth>
+        <Months><January /><July /></Months>
+      </ScheduleByMonth>
+    </CalendarTrigger>
+    <BootTrigger>
+      <Delay>PT1M</Delay>
+    </BootTrigger>
+    <LogonTrigger />
+  </Triggers>
+  <Principals>
+    <Principal id="Author">
+      <UserId>HOST\backup</UserId>
+    </Principal>
+  </Principals>
+  <Settings>
+    <StartWhenAvailable>true</StartWhenAvailable>
+    <ExecutionTimeLimit>PT1H</ExecutionTimeLimit>
+  </Settings>
+  <Actions Context="Author">
+    <Exec>
+      <Command>C:\app\backup.exe</Command>
+      <Arguments>--full</Arguments>
+      <WorkingDirectory>C:\app</WorkingDirectory>
+    </Exec>
+  </Actions>
+</Task>`
+
+func TestExportSystemd(t *testing.T) {
+	def, err := ParseDefinition([]byte(systemdXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	units := ExportSystemd(def, "backup")
+
+	for _, line := range []string{"Description=Nightly backup", "Type=oneshot", `ExecStart=C:\app\backup.exe --full`,
+		`WorkingDirectory=C:\app`, "User=backup", "TimeoutStartSec=3600"} {
+		if !strings.Contains(units.Service, line+"\n") {
+			t.Errorf("service misses %q:\n%s", line, units.Service)
+		}
+	}
+	for _, line := range []string{"OnCalendar=Mon,Fri *-*-* 02:30:00", "RandomizedDelaySec=300", "OnCalendar=*-01,07-1 04:00:00",
+		"OnCalendar=*-01,07~01 04:00:00", "OnBootSec=60", "Persistent=true", "Unit=backup.service", "WantedBy=timers.target"} {
+		if !strings.Contains(units.Timer, line+"\n") {
+			t.Errorf("timer misses %q:\n%s", line, units.Timer)
+		}
+	}
+	if len(units.Warnings) != 1 || !strings.Contains(units.Warnings[0], "LogonTrigger") {
+		t.Errorf("Warnings = %v", units.Warnings)
+	}
+
+	def.Triggers.Items = nil
+	if units := ExportSystemd(def, "backup"); units.Timer != "" || len(units.Warnings) != 1 {
+		t.Errorf("ExportSystemd() without triggers = %+v", units)
+	}
+}
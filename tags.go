@@ -0,0 +1,103 @@
+package tasker
+
+import "strings"
+
+const (
+	//tagsPrefix starts the last line of the task description listing
+	//its tags
+	tagsPrefix = "tags:"
+)
+
+//splitTags separates the tags line from a task description
+func splitTags(description string) (string, []string) {
+	description = strings.TrimRight(description, "\r\n")
+	i := strings.LastIndex(description, "\n")
+	line := description[i+1:]
+	if !strings.HasPrefix(strings.TrimSpace(line), tagsPrefix) {
+		return description, nil
+	}
+
+	tags := []string{}
+	for _, tag := range strings.Split(strings.TrimPrefix(strings.TrimSpace(line), tagsPrefix), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if i < 0 {
+		return "", tags
+	}
+	return strings.TrimRight(description[:i], "\r"), tags
+}
+
+//joinTags appends the tags line to a task description
+func joinTags(description string, tags []string) string {
+	if len(tags) == 0 {
+		return description
+	}
+	line := tagsPrefix + strings.Join(tags, ",")
+	if description == "" {
+		return line
+	}
+	return description + "\n" + line
+}
+
+//hasTag reports whether tags holds tag, ignoring case
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+//Tags of the task, kept on the last line of its description
+func (detail TaskDetail) Tags() []string {
+	_, tags := splitTags(detail.Comment)
+	return tags
+}
+
+//Tags of the task, kept on the last line of its description
+func (info RegistrationInfo) Tags() []string {
+	_, tags := splitTags(info.Description)
+	return tags
+}
+
+//patchDescription sets the description and tags of the task, the
+//description in place keeps its text when only the tags change
+func (taskcreate TaskCreate) patchDescription(root *xmlNode) bool {
+	if taskcreate.Description == "" && len(taskcreate.Tags) == 0 {
+		return false
+	}
+
+	node := root.ensure("RegistrationInfo").insert("Description", "Documentation")
+	description, tags := splitTags(node.Text)
+	if taskcreate.Description != "" {
+		description = taskcreate.Description
+	}
+	if len(taskcreate.Tags) > 0 {
+		tags = taskcreate.Tags
+	}
+	node.Text = joinTags(description, tags)
+	return true
+}
+
+//QueryByTag returns the verbose information of the tasks carrying tag,
+//only the library's with own. Tags compare case-insensitively.
+func (task SchTask) QueryByTag(tag string, own bool) ([]TaskDetail, error) {
+	details, err := task.QueryDetail("*", own)
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := []TaskDetail{}
+	seen := map[string]bool{}
+	for _, detail := range details {
+		if seen[detail.Name] || !hasTag(detail.Tags(), tag) {
+			continue
+		}
+		seen[detail.Name] = true
+		tagged = append(tagged, detail)
+	}
+	return tagged, nil
+}
@@ -0,0 +1,60 @@
+package tasker
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitTags(t *testing.T) {
+	cases := []struct {
+		description, text string
+		tags              []string
+	}{
+		{"", "", nil},
+		{"nightly backup", "nightly backup", nil},
+		{"tags:app, v2.3", "", []string{"app", "v2.3"}},
+		{"nightly backup\r\ntags:app,,v2.3\r\n", "nightly backup", []string{"app", "v2.3"}},
+	}
+	for _, c := range cases {
+		text, tags := splitTags(c.description)
+		if text != c.text || !reflect.DeepEqual(tags, c.tags) {
+			t.Errorf("splitTags(%q) = %q, %v", c.description, text, tags)
+		}
+		if len(c.tags) > 0 {
+			if _, again := splitTags(joinTags(text, tags)); !reflect.DeepEqual(again, c.tags) {
+				t.Errorf("joinTags() round trip = %v", again)
+			}
+		}
+	}
+}
+
+func TestPatchDescription(t *testing.T) {
+	root, err := parseNode([]byte(singletonXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	TaskCreate{Description: "nightly backup", Tags: []string{"app"}}.patchDefinition(root)
+	TaskCreate{Tags: []string{"app", "v2.3"}}.patchDefinition(root)
+
+	if got := root.get("RegistrationInfo", "Description"); got != "nightly backup\ntags:app,v2.3" {
+		t.Errorf("Description = %q", got)
+	}
+}
+
+func TestQueryByTag(t *testing.T) {
+	tagged := strings.Replace(detailCSV, `"N/A","N/A","Enabled"`, `"N/A","backup`+"\n"+`tags:App,v2.3","Enabled"`, 1)
+	other := strings.Replace(detailCSV, `go-wintask-Test`, `go-wintask-Other`, 1)
+	task := New(false).WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		return []byte(tagged + tagged + other), nil
+	}))
+
+	details, err := task.QueryByTag("app", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(details) != 1 || details[0].Name != "\\go-wintask-Test" || !reflect.DeepEqual(details[0].Tags(), []string{"App", "v2.3"}) {
+		t.Errorf("QueryByTag() = %+v", details)
+	}
+}
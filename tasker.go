@@ -3,28 +3,42 @@ package tasker
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"syscall"
+	"time"
 )
 
 //Task common task definition
 type Task struct {
 	name, datetime, status string
+
+	//host remote system the task is registered on, "" for the local one
+	host string
+
+	//uri and id identity of the task, see WithIdentity
+	uri, id string
 }
 
 //TaskCreate used in creating tasks
 //Examples
 //==> Creates a scheduled task "doc" on the remote machine "ABC"
+//
 //	which runs notepad.exe every hour under user "runasuser".
 //	SCHTASKS /Create /S ABC /U user /P password /RU runasuser
 //		 /RP runaspassword /SC HOURLY /TN doc /TR notepad
 //
 //==> Creates a scheduled task "accountant" on the remote machine
+//
 //	"ABC" to run calc.exe every five minutes from the specified
 //	start time to end time between the start date and end date.
 //	SCHTASKS /Create /S ABC /U domain\user /P password /SC MINUTE
@@ -32,16 +46,19 @@ type Task struct {
 //		 /SD 06/06/2006 /ED 06/06/2006 /RU runasuser /RP userpassword
 //
 //==> Creates a scheduled task "gametime" to run freecell on the
+//
 //	first Sunday of every month.
 //	SCHTASKS /Create /SC MONTHLY /MO first /D SUN /TN gametime
 //		 /TR c:\windows\system32\freecell
 //
 //==> Creates a scheduled task "report" on remote machine "ABC"
+//
 //	to run notepad.exe every week.
 //	SCHTASKS /Create /S ABC /U user /P password /RU runasuser
 //		 /RP runaspassword /SC WEEKLY /TN report /TR notepad.exe
 //
 //==> Creates a scheduled task "logtracker" on remote machine "ABC"
+//
 //	to run notepad.exe every five minutes starting from the
 //	specified start time with no end time. The /RP password will be
 //	prompted for.
@@ -51,22 +68,25 @@ type Task struct {
 //		 /RU runasuser /RP
 //
 //==> Creates a scheduled task "gaming" to run freecell.exe starting
+//
 //	at 12:00 and automatically terminating at 14:00 hours every day
 //	SCHTASKS /Create /SC DAILY /TN gaming /TR c:\freecell /ST 12:00
 //		 /ET 14:00 /K
 //
 //==> Creates a scheduled task "EventLog" to run wevtvwr.msc starting
+//
 //	whenever event 101 is published in the System channel
 //	SCHTASKS /Create /TN EventLog /TR wevtvwr.msc /SC ONEVENT
 //		 /EC System /MO *[System/EventID=101]
 //
 //==> Spaces in file paths can be used by using two sets of quotes, one
-//	set for CMD.EXE and one for SchTasks.exe.  The outer quotes for CMD
-//	need to be double quotes; the inner quotes can be single quotes or
-//	escaped double quotes:
-//	SCHTASKS /Create
-//   /tr "'c:\program files\internet explorer\iexplorer.exe'
-//   \"c:\log data\today.xml\"" ...
+//
+//		set for CMD.EXE and one for SchTasks.exe.  The outer quotes for CMD
+//		need to be double quotes; the inner quotes can be single quotes or
+//		escaped double quotes:
+//		SCHTASKS /Create
+//	  /tr "'c:\program files\internet explorer\iexplorer.exe'
+//	  \"c:\log data\today.xml\"" ...
 type TaskCreate struct {
 	// /RU  username      Specifies the "run as" user account (user context)
 	// 					  under which the task runs. For the system account,
@@ -75,6 +95,7 @@ type TaskCreate struct {
 	//					  For v2 tasks, "NT AUTHORITY\LOCALSERVICE" and
 	//					  "NT AUTHORITY\NETWORKSERVICE" are also available as well
 	//					  as the well known SIDs for all three.
+	Username string
 
 	// /RP  [password]    Specifies the password for the "run as" user.
 	//					  To prompt for the password, the value must be either
@@ -95,6 +116,18 @@ type TaskCreate struct {
 	Taskrun   string
 	Arguments []string
 
+	// Expand             Expands %NAME% environment variables of Taskrun and
+	//                    Arguments when the task is registered, by default
+	//                    they are stored literally and expanded by the Task
+	//                    Scheduler each time the task runs.
+	Expand bool
+
+	// PowerShell         Runs a PowerShell script file, see PowerShellAction.
+	//                    Replaces Taskrun and Arguments, which must not be
+	//                    given. Command lines longer than /TR accepts need
+	//                    ActionXML.
+	PowerShell *PowerShellAction
+
 	// /SC   schedule     Specifies the schedule frequency.
 	//                    Valid schedule types: MINUTE, HOURLY, DAILY, WEEKLY,
 	//                    MONTHLY, ONCE, ONSTART, ONLOGON, ONIDLE, ONEVENT.
@@ -106,7 +139,8 @@ type TaskCreate struct {
 	// MINUTE:  1 - 1439 minutes.
 	// HOURLY:  1 - 23 hours.
 	// DAILY:   1 - 365 days.
-	// WEEKLY:  weeks 1 - 52.
+	// WEEKLY:  weeks 1 - 52, the task runs on the /D days of every
+	//          that many weeks, see also WeeklyTrigger.
 	// ONCE:    No modifiers.
 	// ONSTART: No modifiers.
 	// ONLOGON: No modifiers.
@@ -117,7 +151,8 @@ type TaskCreate struct {
 	// /D    days         Specifies the day of the week to run the task. Valid
 	//                    values: MON, TUE, WED, THU, FRI, SAT, SUN and for
 	//                    MONTHLY schedules 1 - 31 (days of the month).
-	//                    Wildcard "*" specifies all days.
+	//                    Wildcard "*" specifies all days. WEEKLY schedules
+	//                    without days run on the weekday of the start date.
 	Days []string
 
 	// /M    months       Specifies month(s) of the year. Defaults to the first
@@ -151,6 +186,9 @@ type TaskCreate struct {
 	//                    ONLOGON, ONIDLE, ONEVENT.
 	Endtime string
 
+	// EndAfter           Sets Endtime this long after Starttime, see EndTime.
+	EndAfter time.Duration
+
 	// /DU   duration     Specifies the duration to run the task. The time
 	//                    format is HH:mm. This is not applicable with /ET and
 	//                    for schedule types: ONSTART, ONLOGON, ONIDLE, ONEVENT.
@@ -158,6 +196,10 @@ type TaskCreate struct {
 	//                    to 1 hour.
 	Duration string
 
+	// RunFor             Sets Duration, formatted as HHHH:mm, see
+	//                    FormatDuration.
+	RunFor time.Duration
+
 	// /K     terminate   Terminates the task at the endtime or duration time.
 	//                    This is not applicable for schedule types: ONSTART,
 	//                    ONLOGON, ONIDLE, ONEVENT. Either /ET or /DU must be
@@ -182,30 +224,162 @@ type TaskCreate struct {
 	//                    as the given user.  Only local resources are available.
 	NoPassword bool
 
+	// /IT   interactive  Enables the task to run interactively only if the
+	//                    /RU user is currently logged on at the time the job
+	//                    runs, so it can show UI on the user's desktop. The
+	//                    task runs only if the user is logged in.
+	Interactive bool
+
 	// /Z     markDelete  Marks the task for deletion after its final run.
+	//                    Implies V1.
 	MarkDelete bool
 
+	// /V1   preVista     Creates a task visible to pre-Vista platforms, see
+	//                    ValidateV1 for the options such tasks support. Not
+	//                    compatible with /XML.
+	V1 bool
+
 	// /F                 Forcefully creates the task and suppresses warnings if
-	//                    the specified task already exists.
+	//                    the specified task already exists. See OnConflict
+	//                    for the other ways to handle an existing task.
 	Force bool
 
+	// OnConflict         What CreateTask does when the task already exists,
+	//                    see ConflictStrategies. Empty leaves it to Force.
+	OnConflict ConflictStrategy
+
 	// /RL   level        Sets the Run Level for the job. Valid values are
 	//                    LIMITED and HIGHEST. The default is LIMITED.
 	Level string
 
 	// /DELAY delaytime   Specifies the wait time to delay the running of the
-	//                    task after the trigger is fired.  The time format is
-	//                    mmmm:ss.  This option is only valid for schedule types
-	//                    ONSTART, ONLOGON, ONEVENT.
-	Delaytime string
+	//                    task after the trigger is fired, at most 9999:59
+	//                    minutes and truncated to the second.  This option is
+	//                    only valid for schedule types ONSTART, ONLOGON,
+	//                    ONEVENT.
+	Delay time.Duration
+
+	// Boot               Runs the task when the system starts with a typed
+	//                    delay, see BootTrigger. Implies the ONSTART
+	//                    schedule and sets Delay unless given.
+	Boot *BootTrigger
+
+	// Weekly             Runs the task on a set of weekdays every few weeks,
+	//                    see WeeklyTrigger. Sets the WEEKLY schedule, its
+	//                    modifier and days, which must not be given.
+	Weekly *WeeklyTrigger
+
+	// RandomDelay        Random wait of up to RandomDelay added to every start
+	//                    of the MINUTE, HOURLY, DAILY, WEEKLY, MONTHLY and
+	//                    ONCE schedules, so a task deployed to many machines
+	//                    doesn't start everywhere at the same second. Set
+	//                    through the task XML.
+	RandomDelay time.Duration
+
+	// StartBoundary      Activation timestamp of the triggers, date, time and
+	//                    time zone at once, see formatBoundary. Replaces
+	//                    Startdate and Starttime. Set through the task XML.
+	StartBoundary time.Time
+
+	// EndBoundary        Expiration timestamp of the triggers like
+	//                    StartBoundary, replaces Enddate.
+	EndBoundary time.Time
+
+	// RequireElevation   When the task needs administrator rights (see
+	//                    NeedsElevation) and creating it is denied, the
+	//                    current process is re-launched elevated through the
+	//                    UAC prompt to complete the registration. Creating
+	//                    fails when the elevated process does.
+	RequireElevation bool
+
+	// LogonMode          Whether the task runs only while the user is logged
+	//                    on or also when not, see LogonModes. Validated
+	//                    against the credentials given, empty leaves the
+	//                    choice to the Password and NoPassword fields.
+	LogonMode LogonMode
+
+	// Credential         Target name of a generic Windows Credential Manager
+	//                    entry holding the "run as" password (and user when
+	//                    Username is empty). It is read when the command is
+	//                    made so the password never lives in source or config.
+	Credential string
+
+	// PromptPassword     Passes "/RP *" and types the password into the
+	//                    schtasks prompt through stdin, keeping it out of the
+	//                    process command line visible to other local users.
+	PromptPassword bool
+
+	// ActionXML          Registers the program and arguments through the task
+	//                    XML after creating the task, avoiding the /TR quoting
+	//                    rules and its 261 character limit altogether.
+	ActionXML bool
+
+	// Description        Description of the task, set through the task XML.
+	Description string
+
+	// Tags               Labels to find related tasks by, see QueryByTag.
+	//                    Kept on the last line of the description.
+	Tags []string
+
+	// Email              Additional legacy SendEmail action, set through the
+	//                    task XML. Deprecated since Windows 8 but still
+	//                    honored by older systems.
+	Email *EmailAction
+
+	// Message            Additional legacy ShowMessage action, set through the
+	//                    task XML. Deprecated like Email.
+	Message *MessageAction
+
+	// ComHandler         COM handler action, set through the task XML. When
+	//                    Taskrun is empty it is the only action of the task.
+	ComHandler *ComHandlerAction
+
+	// Maintenance        Runs the task within the Automatic Maintenance
+	//                    windows, set through the task XML.
+	Maintenance *MaintenanceSettings
+
+	// AllowHardTerminate Whether the scheduler may kill the action when the
+	//                    task is ended or exceeds its time limit, nil keeps
+	//                    the default (true). Set through the task XML.
+	AllowHardTerminate *bool
+
+	// StopOnIdleEnd      Whether an ONIDLE task stops when the computer is
+	//                    no longer idle, nil keeps the default (true). Set
+	//                    through the task XML.
+	StopOnIdleEnd *bool
+
+	// StartWhenAvailable Whether a run missed while the computer was off or
+	//                    the service busy starts as soon as possible, nil
+	//                    keeps the default (false). Set through the task XML.
+	StartWhenAvailable *bool
+
+	// Preempt            Lets a new run preempt a hung previous one: a still
+	//                    running instance is stopped when the task starts
+	//                    again (StopExisting) and every run is limited to
+	//                    Preempt. Usually the trigger interval or a little
+	//                    less. Set through the task XML.
+	Preempt time.Duration
+
+	//rawArguments argument string passed verbatim, for programs like
+	//cmd.exe which don't follow the usual quoting rules
+	rawArguments string
+
+	//runLimit action counting the runs, see CreateLimited
+	runLimit *RunLimit
+
+	//definition task XML registered as is instead of the fields, see
+	//Restore and MigrateNamespace
+	definition *xmlNode
 }
 
 const (
-	taskerFile = "SCHTASKS"
+	taskerFile = "schtasks.exe"
 )
 
 var (
-	//Debug Enables debugging, commands won't be performed just logged.
+	//Debug Enables debugging for every tasker, commands won't be performed
+	//just logged. Prefer WithDebug which doesn't affect other users of the
+	//package.
 	Debug      = false
 	dbgMessage = "You are currently in debug mode."
 
@@ -244,11 +418,20 @@ var (
 	}{
 		LIMITED: "LIMITED", HIGHEST: "HIGHEST",
 	}
+	//Accounts built-in service accounts, these run without a password
+	Accounts = struct {
+		SYSTEM, LOCALSERVICE, NETWORKSERVICE string
+	}{
+		SYSTEM:         "NT AUTHORITY\\SYSTEM",
+		LOCALSERVICE:   "NT AUTHORITY\\LOCALSERVICE",
+		NETWORKSERVICE: "NT AUTHORITY\\NETWORKSERVICE",
+	}
 
 	//Commands
 	/*************Create**************/
 	_Create = struct {
 		Command     string
+		username    string
 		password    string
 		schedule    string
 		modifier    string
@@ -266,13 +449,16 @@ var (
 		enddate     string
 		channelName string
 		noPassword  string
+		interactive string
 		markDelete  string
 		force       string
 		preVista    string
 		level       string
 		delaytime   string
+		xml         string
 	}{
 		Command:     "/CREATE",
+		username:    "/RU",
 		password:    "/RP",
 		schedule:    "/SC",
 		modifier:    "/MO",
@@ -290,11 +476,13 @@ var (
 		enddate:     "/ED",
 		channelName: "/EC",
 		noPassword:  "/NP",
+		interactive: "/IT",
 		markDelete:  "/Z",
 		preVista:    "/V1",
 		force:       "/F",
 		level:       "/RL",
 		delaytime:   "/DELAY",
+		xml:         "/XML",
 	}
 	/*************Delete**************/
 	_Delete = struct {
@@ -314,6 +502,9 @@ var (
 		formatLIST  string
 		formatTABLE string
 		noHeader    string
+		taskname    string
+		xml         string
+		verbose     string
 	}{
 		Command:     "/QUERY",
 		format:      "/FO",
@@ -321,12 +512,27 @@ var (
 		formatLIST:  "LIST",
 		formatTABLE: "TABLE",
 		noHeader:    "/NH",
+		taskname:    "/TN",
+		xml:         "/XML",
+		verbose:     "/V",
 	}
 	/*************Change**************/
 	_Change = struct {
-		Command string
+		Command  string
+		taskname string
+		taskrun  string
+		username string
+		password string
+		enable   string
+		disable  string
 	}{
-		Command: "/CHANGE",
+		Command:  "/CHANGE",
+		taskname: "/TN",
+		taskrun:  "/TR",
+		username: "/RU",
+		password: "/RP",
+		enable:   "/ENABLE",
+		disable:  "/DISABLE",
 	}
 	/*************Run**************/
 	_Run = struct {
@@ -356,28 +562,276 @@ var (
 	}
 )
 
-//SchTask definitions
+//SchTask definitions, safe for concurrent use by multiple goroutines.
+//Options like WithCache return configured copies, the package level
+//Debug is the only shared mutable state and should be set before use.
 type SchTask struct {
-	bin           string
-	prefix        string
-	compatibility bool
+	bin            string
+	namespace      Namespace
+	compatibility  bool
+	cache          *queryCache
+	snapshot       *snapshot
+	limiter        *rateLimiter
+	caps           *capsProbe
+	procAttr       func(*syscall.SysProcAttr)
+	customize      func(*exec.Cmd)
+	eventSource    string
+	tracer         Tracer
+	ctx            context.Context
+	backend        Backend
+	credentials    CredentialProvider
+	host           string
+	hostCredential string
+	hooks          Hooks
+	cleanupFolders bool
+	systemGuard    bool
+	verify         bool
+	outputLimit    int
+	defaults       *TaskCreate
+	machineLock    *machineLock
+	lockHeld       bool
+	identity       bool
+	debug          bool
 }
 
-//New creates a new tasker object
-func New(com bool) SchTask {
-	return SchTask{
-		bin:           taskerFile,
-		prefix:        "go-wintask-",
+//New creates a new tasker object, its owned tasks carry DefaultPrefix
+//unless a namespace is given, NoPrefix keeps the names as given. The
+//own argument of each call overrides it, false always using the name as
+//given. Field values shared by every task are given with WithDefaults.
+func New(com bool, namespace ...Namespace) SchTask {
+	task := SchTask{
+		bin:           systemBinary(taskerFile),
+		namespace:     DefaultPrefix,
 		compatibility: com,
+		caps:          &capsProbe{},
+	}
+	if len(namespace) > 0 {
+		task.namespace = namespace[0]
 	}
+	return task
+}
+
+//WithDebug returns a copy of the tasker which only logs its commands
+//instead of performing them, see Debug.
+func (task SchTask) WithDebug(debug bool) SchTask {
+	task.debug = debug
+	return task
+}
+
+//debugging reports whether commands are only logged
+func (task SchTask) debugging() bool {
+	return task.debug || Debug
 }
 
 func catch(out []byte, e error) {
-	if e != nil {
+	if e != nil && !errors.Is(e, ErrUnsupportedPlatform) {
+		if len(bytes.TrimSpace(out)) == 0 {
+			log.Fatal(e)
+		}
 		log.Fatal(string(out))
 	}
 }
 
+//execute runs schtasks with the given arguments, returning the error
+//together with the tool output instead of exiting like catch does.
+func (task SchTask) execute(args ...string) ([]byte, error) {
+	return task.executeInput(nil, args...)
+}
+
+//executeInput runs schtasks like execute, feeding stdin to its prompts
+func (task SchTask) executeInput(stdin io.Reader, args ...string) (output []byte, err error) {
+	if !supported && task.backend == nil {
+		return unsupported()
+	}
+	if err := task.guardSystem(args); err != nil {
+		return nil, err
+	}
+	if mutates(args) {
+		locked, unlock, err := task.lock()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+		task = locked
+	}
+
+	args, err = task.withRemote(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.tracer != nil {
+		end := task.tracer.Start(task.context(), operationOf(args))
+		defer func() {
+			end(exitCode(err), err)
+		}()
+	}
+
+	//anything but a query may change the registered tasks
+	if len(args) > 0 && args[0] != _Query.Command {
+		task.Invalidate()
+		defer func() {
+			task.logEvent(args, err)
+		}()
+	}
+
+	if err := task.limiter.wait(task.context()); err != nil {
+		return nil, err
+	}
+	output, err = task.Backend().Execute(task.withHRESULT(args), stdin)
+	if err != nil && !errors.Is(err, ErrBinaryNotFound) {
+		return output, newError(args, output, err)
+	}
+
+	return output, err
+}
+
+//command prepares a schtasks process, see WithSysProcAttr and WithCommand
+func (task SchTask) command(args ...string) *exec.Cmd {
+	cmd := newCommand(task.bin, args...)
+	if task.procAttr != nil {
+		task.procAttr(cmd.SysProcAttr)
+	}
+	if task.customize != nil {
+		task.customize(cmd)
+	}
+	return cmd
+}
+
+//fullName applies the namespace to owned task names and normalizes
+//them, see NormalizeName
+func (task SchTask) fullName(name string, own bool) string {
+	if own {
+		name = task.Namespace().Path(name)
+	}
+	return NormalizeName(name)
+}
+
+//owns reports whether the registered task name belongs to the library
+func (task SchTask) owns(name string) bool {
+	return task.Namespace().Owns(name)
+}
+
+//folder returns the folder holding every owned task, "" when the
+//namespace has none
+func (task SchTask) folder() string {
+	return task.Namespace().Folder()
+}
+
+//taskPath returns name with the leading backslash of registered task
+//paths, so names given with and without it compare equal
+func taskPath(name string) string {
+	return "\\" + strings.TrimPrefix(name, "\\")
+}
+
+//list enumerates every registered task on the system, served from the
+//cache when enabled
+func (task SchTask) list() ([]Task, error) {
+	if tasks, ok := task.cache.get(); ok {
+		return tasks, nil
+	}
+
+	args := task.queryArgs(_Query.Command, _Query.format, _Query.formatCSV)
+
+	output, err := task.execute(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := task.parseList(output)
+	task.cache.set(tasks)
+	return tasks, nil
+}
+
+//owned enumerates the tasks registered by the library, served from the
+//snapshot when refreshed in the background. NoPrefix is rejected, see
+//checkEnumerable.
+func (task SchTask) owned() ([]Task, error) {
+	if err := checkEnumerable(task.Namespace()); err != nil {
+		return nil, err
+	}
+	if tasks, ok := task.snapshot.get(); ok {
+		return tasks, nil
+	}
+	tasks, err := task.enumerateOwned()
+	task.snapshot.update(tasks, err)
+	return tasks, err
+}
+
+//enumerateOwned lists the tasks registered by the library, only the
+//owned folder is queried when set. Compatibility mode lacks the HRESULT
+//telling a missing folder apart, the full list is filtered instead.
+func (task SchTask) enumerateOwned() ([]Task, error) {
+	if task.folder() != "" && !task.compatibility {
+		return task.listFolder()
+	}
+
+	all, err := task.list()
+	if err != nil {
+		return nil, err
+	}
+
+	taskList := make([]Task, 0)
+	for _, t := range all {
+		if task.owns(t.name) {
+			taskList = append(taskList, t)
+		}
+	}
+
+	return taskList, nil
+}
+
+//listFolder enumerates the tasks of the owned folder, a folder which
+//does not exist yet holds no tasks
+func (task SchTask) listFolder() ([]Task, error) {
+	args := task.queryArgs(_Query.Command, _Query.taskname, task.folder()+"\\", _Query.format, _Query.formatCSV)
+
+	output, err := task.execute(args...)
+	if err != nil {
+		if isNotFound(err) {
+			return []Task{}, nil
+		}
+		return nil, err
+	}
+
+	return task.parseList(output), nil
+}
+
+//parseList parses the CSV enumeration output of /QUERY
+func (task SchTask) parseList(output []byte) []Task {
+	taskList := make([]Task, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if t, ok := task.parseLine(scanner.Text()); ok {
+			taskList = append(taskList, t)
+		}
+	}
+
+	return taskList
+}
+
+//parseLine parses a single row of the /QUERY enumeration, reporting
+//false for headers and blank lines
+func (task SchTask) parseLine(line string) (Task, bool) {
+	tx := strings.Replace(line, "\"", "", -1)
+
+	//skip
+	if task.compatibility && strings.HasPrefix(tx, "TaskName") {
+		return Task{}, false
+	}
+
+	ts := strings.Split(tx, ",")
+	if len(ts) < 3 {
+		return Task{}, false
+	}
+
+	tname := strings.TrimSpace(ts[0])
+	dtime := strings.TrimSpace(ts[1])
+	stat := strings.TrimSpace(ts[2])
+	return Task{name: tname, datetime: dtime, status: stat, host: task.host}, true
+}
+
 func getCurrDir() string {
 	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
 	if err != nil {
@@ -392,16 +846,47 @@ func getCurrExe() string {
 	return file
 }
 
+//baseName returns the last element of a windows or slash separated path
+func baseName(p string) string {
+	if i := strings.LastIndexAny(p, "\\/"); i >= 0 {
+		return p[i+1:]
+	}
+	return p
+}
+
 //TaskMake for generating tasks
 func (task SchTask) TaskMake(taskcreate TaskCreate, command string, own bool) []string {
 	cmds := []string{}
 	/****make commands****/
 	//Append the command
 	cmds = append(cmds, command)
-	//password string
-	if taskcreate.Password != "" {
+	//credential string, resolved at call time, masked when it can't be
+	//so the command fails instead of running as another user
+	if taskcreate.Credential != "" {
+		resolved, err := task.resolveCredential(taskcreate)
+		if err != nil {
+			resolved = taskcreate.maskCredential()
+		}
+		taskcreate = resolved
+	}
+	taskcreate = taskcreate.withLogonMode()
+	taskcreate = taskcreate.withBoot()
+	taskcreate = taskcreate.withWeekly()
+	taskcreate = taskcreate.withDurations()
+	//username string
+	if taskcreate.Username != "" {
+		cmds = append(cmds, _Create.username)
+		cmds = append(cmds, taskcreate.Username)
+	}
+	//password string, never given for service accounts
+	service := isServiceAccount(taskcreate.Username)
+	if taskcreate.Password != "" && !service {
 		cmds = append(cmds, _Create.password)
-		cmds = append(cmds, taskcreate.Password)
+		if taskcreate.PromptPassword {
+			cmds = append(cmds, passwordPrompt)
+		} else {
+			cmds = append(cmds, taskcreate.Password)
+		}
 	}
 	//Schedule
 	if taskcreate.Schedule != "" {
@@ -482,9 +967,13 @@ func (task SchTask) TaskMake(taskcreate TaskCreate, command string, own bool) []
 		cmds = append(cmds, taskcreate.ChannelName)
 	}
 	//No Password
-	if taskcreate.NoPassword {
+	if taskcreate.NoPassword && !service {
 		cmds = append(cmds, _Create.noPassword)
 	}
+	//Interactive
+	if taskcreate.Interactive && !service {
+		cmds = append(cmds, _Create.interactive)
+	}
 	//Force
 	if taskcreate.Force {
 		cmds = append(cmds, _Create.force)
@@ -494,203 +983,437 @@ func (task SchTask) TaskMake(taskcreate TaskCreate, command string, own bool) []
 		cmds = append(cmds, _Create.level)
 		cmds = append(cmds, taskcreate.Level)
 	}
-	//delaytime string
-	if taskcreate.Delaytime != "" {
+	//delay time.Duration
+	if taskcreate.Delay > 0 {
 		cmds = append(cmds, _Create.delaytime)
-		cmds = append(cmds, taskcreate.Delaytime)
+		cmds = append(cmds, formatDelaytime(taskcreate.Delay))
 	}
 	//Add taskname
 	cmds = append(cmds, _Create.taskname)
-	name := taskcreate.Taskname
-	if own {
-		name = task.prefix + name
-	}
-	cmds = append(cmds, name)
-	//Add taskrun
+	cmds = append(cmds, task.fullName(taskcreate.Taskname, own))
+	//Add taskrun, the arguments are set through the XML with ActionXML
 	cmds = append(cmds, _Create.taskrun)
-	run := taskcreate.Taskrun
-	if run == "" {
-		run = path.Join(getCurrDir(), getCurrExe())
-	}
-	args := ""
-	//append the args
-	for _, arg := range taskcreate.Arguments {
-		if strings.IndexRune(arg, ' ') >= 0 {
-			args += "\"" + arg + "\" "
-		} else {
-			args += arg + " "
-		}
+	run, args := taskcreate.action()
+	if taskcreate.ActionXML {
+		args = ""
 	}
-	run = "\"" + run + "\" " + strings.TrimSpace(args)
+	run = "\"" + run + "\" " + args
 	run = strings.TrimSpace(run)
-	//run = "\"" + run + "\""
 	cmds = append(cmds, run)
+	//preVista bool
+	if taskcreate.V1 || taskcreate.MarkDelete {
+		cmds = append(cmds, _Create.preVista)
+	}
 	//markDelete bool
 	if taskcreate.MarkDelete {
-		cmds = append(cmds, _Create.preVista)
 		cmds = append(cmds, _Create.markDelete)
 	}
 
-	if Debug {
+	if task.debugging() {
 		fmt.Println("Commands:", cmds)
 	}
 	return cmds
 }
 
+//action returns the program and the argument string to run, each
+//argument quoted so the program receives it unchanged, see quoteArg.
+//Environment variables are left for the Task Scheduler unless Expand.
+func (taskcreate TaskCreate) action() (string, string) {
+	if taskcreate.PowerShell != nil {
+		taskcreate.Taskrun, taskcreate.Arguments = taskcreate.PowerShell.command()
+	}
+	run := taskcreate.Taskrun
+	if run == "" && taskcreate.ComHandler != nil {
+		return comPlaceholder, ""
+	}
+	if run == "" {
+		run = path.Join(getCurrDir(), getCurrExe())
+	}
+	if taskcreate.Expand {
+		run = expandEnv(run)
+	}
+	if taskcreate.rawArguments != "" {
+		return run, taskcreate.rawArguments
+	}
+	args := []string{}
+	//append the args
+	for _, arg := range taskcreate.Arguments {
+		if taskcreate.Expand {
+			arg = expandEnv(arg)
+		}
+		args = append(args, quoteArg(arg))
+	}
+	return run, strings.Join(args, " ")
+}
+
 //Create  Enables an administrator to create scheduled tasks on a local or
 //remote system.
-func (task SchTask) Create(taskcreate TaskCreate) string {
-	cmds := task.TaskMake(taskcreate, _Create.Command, true)
+func (task SchTask) Create(taskcreate TaskCreate, opts ...CallOption) string {
+	task = task.with(opts)
+	output, err := task.CreateTask(taskcreate)
+	catch([]byte(output), err)
 
-	if Debug {
-		return dbgMessage
+	return output
+}
+
+//createTask performs create without the hooks
+func (task SchTask) createTask(taskcreate TaskCreate, own bool) (string, error) {
+	if taskcreate.definition != nil {
+		return task.createDefinition(taskcreate, own)
+	}
+	if taskcreate.Credential != "" {
+		resolved, err := task.resolveCredential(taskcreate)
+		if err != nil {
+			return "", err
+		}
+		taskcreate = resolved
 	}
+	if err := task.checkCreate(taskcreate, own); err != nil {
+		return "", err
+	}
+	taskcreate, skip, err := task.resolveConflict(taskcreate, own)
+	if err != nil || skip {
+		return "", err
+	}
+	cmds := task.TaskMake(taskcreate, _Create.Command, own)
 
-	cmd := exec.Command(task.bin, cmds...)
+	if task.debugging() {
+		return dbgMessage, nil
+	}
 
-	output, err := cmd.CombinedOutput()
-	catch(output, err)
+	output, err := task.executeInput(taskcreate.passwordInput(), cmds...)
+	if err != nil && taskcreate.RequireElevation && NeedsElevation(taskcreate) &&
+		isAccessDenied(err) && !IsElevated() {
+		if err := relaunchElevated(); err != nil {
+			return "", err
+		}
+		return relaunchMessage, nil
+	}
+	if err != nil {
+		return string(output), err
+	}
 
-	return string(output)
+	if taskcreate.needsPatch() {
+		if patched, err := task.updateDefinition(taskcreate, own, taskcreate.patchDefinition); err != nil {
+			return string(patched), err
+		}
+	}
+	if task.verify {
+		if err := task.verifyCreate(taskcreate, own); err != nil {
+			return string(output), err
+		}
+	}
+
+	return string(output), nil
+}
+
+//checkCreate validates the name and options of a task to create or
+//change
+func (task SchTask) checkCreate(taskcreate TaskCreate, own bool) error {
+	if _, err := task.resolveName(taskcreate.Taskname, own); err != nil {
+		return err
+	}
+	if err := taskcreate.checkLogonMode(); err != nil {
+		return err
+	}
+	if err := taskcreate.checkDelay(); err != nil {
+		return err
+	}
+	if err := taskcreate.checkWeekly(); err != nil {
+		return err
+	}
+	if err := taskcreate.checkPowerShellAction(); err != nil {
+		return err
+	}
+	if err := taskcreate.checkRandomDelay(); err != nil {
+		return err
+	}
+	if err := taskcreate.checkBoundaries(); err != nil {
+		return err
+	}
+	if err := taskcreate.checkPreempt(); err != nil {
+		return err
+	}
+	if err := taskcreate.checkDurations(); err != nil {
+		return err
+	}
+	taskcreate = taskcreate.withBoot().withWeekly()
+	if taskcreate.V1 || taskcreate.MarkDelete {
+		if err := task.ValidateV1(taskcreate, own); err != nil {
+			return err
+		}
+	}
+	return task.Capabilities().Validate(taskcreate)
 }
 
 //Delete Deletes one or more scheduled tasks.
-func (task SchTask) Delete(taskname string, own, force bool) string {
-	cmd := &exec.Cmd{}
+func (task SchTask) Delete(taskname string, own, force bool, opts ...CallOption) string {
+	task = task.with(opts)
+	output, err := task.DeleteTask(taskname, own, force)
+	catch([]byte(output), err)
 
-	if Debug {
-		return dbgMessage
-	}
+	return output
+}
 
-	if own {
-		taskname = task.prefix + taskname
+//deleteTask performs DeleteTask without the hooks
+func (task SchTask) deleteTask(taskname string, own, force bool) (string, error) {
+	if task.debugging() {
+		return dbgMessage, nil
 	}
 
-	if !force {
-		cmd = exec.Command(task.bin, _Delete.Command, _Delete.taskname, taskname)
-	} else {
-		cmd = exec.Command(task.bin, _Delete.Command, _Delete.taskname, taskname, _Delete.force)
+	taskname, err := task.resolveName(taskname, own)
+	if err != nil {
+		return "", err
 	}
 
-	output, err := cmd.CombinedOutput()
-	catch(output, err)
+	cmds := []string{_Delete.Command, _Delete.taskname, taskname}
+	if force {
+		cmds = append(cmds, _Delete.force)
+	}
 
-	return string(output)
+	output, err := task.execute(cmds...)
+	if err == nil && own && task.cleanupFolders && task.folder() != "" {
+		err = task.cleanupAfterDelete(taskname)
+	}
+	return string(output), err
 }
 
-//Query Enables an administrator to display the scheduled tasks on the
-//local or remote system.
-func (task SchTask) Query(name string, own bool) []Task {
-	taskList := make([]Task, 0)
+//DeleteIfExists deletes the task without confirmation, reporting whether
+//it existed. A missing task is not an error, so cleanup code can run
+//repeatedly. Compatibility mode lacks the HRESULT telling a missing task
+//apart, the task is looked up first.
+func (task SchTask) DeleteIfExists(taskname string, own bool) (existed bool, err error) {
+	if task.compatibility && !task.debugging() {
+		if exists, err := task.Exists(taskname, own); err != nil || !exists {
+			return false, err
+		}
+	}
 
-	cmd := &exec.Cmd{}
-	if task.compatibility {
-		cmd = exec.Command(task.bin, _Query.Command, _Query.format, _Query.formatCSV)
-	} else {
-		cmd = exec.Command(task.bin, _Query.Command, _Query.format, _Query.formatCSV, _Query.noHeader)
+	output, err := task.DeleteTask(taskname, own, true)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
 	}
+	return output != dbgMessage, nil
+}
 
-	output, err := cmd.CombinedOutput()
-	catch(output, err)
+//Filter selects tasks by name, case-insensitively: "*" or "" match all,
+//a Name with the * and ? wildcards matches as a pattern and any other
+//Name matches the exact task. Names and patterns without a folder match
+//the task name only, with one the full path. With Own only the tasks of
+//the namespace are considered, matching the name they were registered for.
+type Filter struct {
+	Name string
+	Own  bool
+}
 
-	if own {
-		tmp := name
-		if name == "*" {
-			tmp = ""
+//exact reports whether the filter selects a single task by its name
+func (filter Filter) exact() bool {
+	return filter.Name != "" && !strings.ContainsAny(filter.Name, "*?")
+}
+
+//match reports whether the registered task name is selected by filter
+func (task SchTask) match(filter Filter, name string) bool {
+	pattern := filter.Name
+	if filter.Own {
+		if !task.owns(name) {
+			return false
 		}
-		name = task.prefix + tmp
+		name = task.Namespace().Trim(name)
 	}
 
-	scanner := bufio.NewScanner(bytes.NewReader(output))
-	for scanner.Scan() {
-		tx := strings.Replace(scanner.Text(), "\"", "", -1)
+	switch {
+	case pattern == "" || pattern == "*":
+		return true
+	case filter.exact():
+		return strings.EqualFold(NormalizeName(name), NormalizeName(pattern))
+	case !strings.ContainsAny(pattern, "\\/"):
+		name = ParseTaskPath(name).Name
+	default:
+		name, pattern = NormalizeName(name), NormalizeName(pattern)
+	}
+	return globPattern(pattern).MatchString(name)
+}
+
+//globPattern compiles a wildcard pattern, * matching any run of
+//characters and ? a single one
+func globPattern(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.Replace(expr, "\\*", ".*", -1)
+	expr = strings.Replace(expr, "\\?", ".", -1)
+	return regexp.MustCompile("(?is)^" + expr + "$")
+}
+
+//lookup queries a single task with /QUERY /TN, a missing task yields
+//an empty list
+func (task SchTask) lookup(name string, own bool) ([]Task, error) {
+	path, err := task.resolveName(name, own)
+	if err != nil {
+		return nil, err
+	}
 
-		//skip
-		if task.compatibility && strings.HasPrefix(tx, "TaskName") {
-			continue
+	args := task.queryArgs(_Query.Command, _Query.taskname, path, _Query.format, _Query.formatCSV)
+	output, err := task.execute(args...)
+	if err != nil {
+		if isNotFound(err) {
+			return []Task{}, nil
 		}
+		return nil, err
+	}
 
-		ts := strings.Split(tx, ",")
+	return task.parseList(output), nil
+}
+
+//candidates lists the tasks filter may select: an exact name is looked
+//up server-side unless the enumeration is cached, or compatibility mode
+//can't tell a missing task from a failure, everything is enumerated
+//otherwise
+func (task SchTask) candidates(filter Filter) ([]Task, error) {
+	if filter.exact() && task.cache == nil && task.snapshot == nil && !task.compatibility {
+		return task.lookup(filter.Name, filter.Own)
+	}
+	return task.enumerate(filter.Own)
+}
+
+//enumerate lists the owned tasks only for owned queries with a folder
+//or snapshot, every task otherwise, the names of NoPrefix are matched
+//against every task
+func (task SchTask) enumerate(own bool) ([]Task, error) {
+	if own && (task.folder() != "" || task.snapshot != nil) && checkEnumerable(task.Namespace()) == nil {
+		return task.owned()
+	}
+	return task.list()
+}
+
+//Query Enables an administrator to display the scheduled tasks on the
+//local or remote system.
+func (task SchTask) Query(name string, own bool, opts ...CallOption) []Task {
+	task = task.with(opts)
+	taskList := make([]Task, 0)
 
-		tname := strings.TrimSpace(ts[0])
+	filter := Filter{name, own}
+	all, err := task.candidates(filter)
+	if err != nil && !errors.Is(err, ErrUnsupportedPlatform) {
+		log.Fatal(err)
+	}
 
-		if name == "*" || name == "" || strings.Contains(strings.ToLower(tname), strings.ToLower(name)) {
-			dtime := strings.TrimSpace(ts[1])
-			stat := strings.TrimSpace(ts[2])
-			taskList = append(taskList, Task{tname, dtime, stat})
+	for _, t := range all {
+		if task.match(filter, t.name) {
+			taskList = append(taskList, t)
 		}
 	}
 
+	if task.identity {
+		if err := task.identifyTasks(taskList); err != nil {
+			log.Fatal(err)
+		}
+	}
 	return taskList
 }
 
 //Change Changes the program to run, or user account and password used
 //by a scheduled task.
-func (task SchTask) Change(taskcreate TaskCreate, own bool) string {
+func (task SchTask) Change(taskcreate TaskCreate, own bool, opts ...CallOption) string {
+	task = task.with(opts)
+	output, err := task.ChangeTask(taskcreate, own)
+	catch([]byte(output), err)
+
+	return output
+}
+
+//changeTask performs ChangeTask without the hooks
+func (task SchTask) changeTask(taskcreate TaskCreate, own bool) (string, error) {
+	if taskcreate.Credential != "" {
+		resolved, err := task.resolveCredential(taskcreate)
+		if err != nil {
+			return "", err
+		}
+		taskcreate = resolved
+	}
+	if err := task.checkCreate(taskcreate, own); err != nil {
+		return "", err
+	}
 	cmds := task.TaskMake(taskcreate, _Change.Command, own)
 
-	if Debug {
-		return dbgMessage
+	if task.debugging() {
+		return dbgMessage, nil
 	}
 
-	cmd := exec.Command(task.bin, cmds...)
+	output, err := task.executeInput(taskcreate.passwordInput(), cmds...)
+	if err != nil {
+		return string(output), err
+	}
 
-	output, err := cmd.CombinedOutput()
-	catch(output, err)
+	if taskcreate.needsPatch() {
+		if patched, err := task.updateDefinition(taskcreate, own, taskcreate.patchDefinition); err != nil {
+			return string(patched), err
+		}
+	}
 
-	return string(output)
+	return string(output), nil
 }
 
 //Run Runs a scheduled task on demand.
-func (task SchTask) Run(taskName string, own bool) string {
+func (task SchTask) Run(taskName string, own bool, opts ...CallOption) string {
+	task = task.with(opts)
+	output, err := task.RunTask(taskName, own)
+	catch([]byte(output), err)
 
-	if Debug {
-		return dbgMessage
-	}
+	return output
+}
 
-	if own {
-		taskName = task.prefix + taskName
+//RunTask is Run reporting failures as an error instead of exiting.
+func (task SchTask) RunTask(taskName string, own bool, opts ...CallOption) (string, error) {
+	task = task.with(opts)
+	if task.debugging() {
+		return dbgMessage, nil
 	}
-	cmd := exec.Command(task.bin, _Run.Command, _Run.taskname, taskName, _Run.immediate)
 
-	output, err := cmd.CombinedOutput()
-	catch(output, err)
+	taskName, err := task.resolveName(taskName, own)
+	if err != nil {
+		return "", err
+	}
 
-	return string(output)
+	output, err := task.execute(_Run.Command, _Run.taskname, taskName, _Run.immediate)
+	return string(output), err
 }
 
 //End Stops a running scheduled task.
-func (task SchTask) End(taskName string, own bool) string {
+func (task SchTask) End(taskName string, own bool, opts ...CallOption) string {
+	task = task.with(opts)
+	output, err := task.EndTask(taskName, own)
+	catch([]byte(output), err)
 
-	if Debug {
-		return dbgMessage
-	}
+	return output
+}
 
-	if own {
-		taskName = task.prefix + taskName
+//EndTask is End reporting failures as an error instead of exiting.
+func (task SchTask) EndTask(taskName string, own bool, opts ...CallOption) (string, error) {
+	task = task.with(opts)
+	if task.debugging() {
+		return dbgMessage, nil
 	}
-	cmd := exec.Command(task.bin, _End.Command, _End.taskname, taskName)
 
-	output, err := cmd.CombinedOutput()
-	catch(output, err)
+	taskName, err := task.resolveName(taskName, own)
+	if err != nil {
+		return "", err
+	}
 
-	return string(output)
+	output, err := task.execute(_End.Command, _End.taskname, taskName)
+	return string(output), err
 }
 
 //ShowSid Shows the SID for the task's dedicated user.
 func (task SchTask) ShowSid(taskName string, own bool) string {
 
-	if Debug {
+	if task.debugging() {
 		return dbgMessage
 	}
 
-	if own {
-		taskName = task.prefix + taskName
-	}
-	taskName = "\\" + taskName
-	cmd := exec.Command(task.bin, _ShowSid.Command, _ShowSid.taskname, taskName)
-
-	output, err := cmd.CombinedOutput()
+	taskName = task.mustName(taskName, own)
+	output, err := task.execute(_ShowSid.Command, _ShowSid.taskname, taskName)
 	catch(output, err)
 
 	return string(output)
@@ -698,9 +1421,7 @@ func (task SchTask) ShowSid(taskName string, own bool) string {
 
 //ShowHelp displays help for the command
 func (task SchTask) ShowHelp(command string) string {
-	cmd := exec.Command(task.bin, command, "/?")
-
-	output, err := cmd.CombinedOutput()
+	output, err := task.execute(command, helpSwitch)
 	catch(output, err)
 
 	return string(output)
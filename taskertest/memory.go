@@ -0,0 +1,651 @@
+package taskertest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	tasker "github.com/janmir/go-wintask"
+)
+
+const (
+	timeLayout    = "1/2/2006 3:04:05 PM"
+	dateLayout    = "01/02/2006"
+	notApplicable = "N/A"
+
+	//last results reported by the Task Scheduler
+	resultNeverRun   = 0x41303 //SCHED_S_TASK_HAS_NOT_RUN
+	resultRunning    = 0x41301 //SCHED_S_TASK_RUNNING
+	resultTerminated = 0x41306 //SCHED_S_TASK_TERMINATED
+)
+
+//HRESULT values of the emulated failures
+const (
+	hrNotFound      = 0x80070002
+	hrAlreadyExists = 0x800700B7
+	hrInvalidArg    = 0x80070057
+	hrNotRunning    = 0x8004130B
+	hrDisabled      = 0x80041326
+)
+
+//Memory tasker.Backend emulating schtasks on an in-memory Task Scheduler,
+//for integration-style tests of applications on any OS. The clock only
+//moves through Advance, which runs the tasks that became due.
+//
+//	memory := taskertest.NewMemory(time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local))
+//	task := tasker.New(false).WithBackend(memory)
+type Memory struct {
+	mu    sync.Mutex
+	now   time.Time
+	tasks map[string]*memoryTask
+}
+
+//memoryTask registered task
+type memoryTask struct {
+	path     string
+	run      string
+	schedule string
+	modifier int
+	start    time.Time
+	user     string
+	level    string
+	xml      string
+	enabled  bool
+	running  bool
+	lastRun  time.Time
+	result   int
+	runs     int
+}
+
+//NewMemory creates an empty Task Scheduler whose clock starts at now
+func NewMemory(now time.Time) *Memory {
+	return &Memory{
+		now:   now,
+		tasks: map[string]*memoryTask{},
+	}
+}
+
+//Now current time of the scheduler clock
+func (m *Memory) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+//Advance moves the clock, every enabled task due in the meantime runs
+//and completes successfully. Tasks still running from /RUN are skipped.
+func (m *Memory) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	end := m.now.Add(d)
+	for _, t := range m.tasks {
+		for next := t.next(m.now); !next.IsZero() && !next.After(end); next = t.next(next) {
+			if !t.running {
+				t.runs++
+				t.lastRun = next
+				t.result = 0
+			}
+			if t.schedule == tasker.Schedules.ONCE {
+				break
+			}
+		}
+	}
+	m.now = end
+}
+
+//Complete finishes a running task with result, the exit code of its
+//program
+func (m *Memory) Complete(name string, result int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tasks[key(name)]
+	if !ok || !t.running {
+		return fmt.Errorf("taskertest: %s is not running", name)
+	}
+	t.running = false
+	t.result = result
+	return nil
+}
+
+//Runs number of times a task ran, -1 when not registered
+func (m *Memory) Runs(name string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if t, ok := m.tasks[key(name)]; ok {
+		return t.runs
+	}
+	return -1
+}
+
+//Execute implements tasker.Backend
+func (m *Memory) Execute(args []string, stdin io.Reader) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cmd := parseCommand(args)
+	if cmd.has("/?") {
+		return []byte(help[cmd.name]), nil
+	}
+
+	switch cmd.name {
+	case "/CREATE":
+		return m.create(cmd)
+	case "/DELETE":
+		return m.delete(cmd)
+	case "/CHANGE":
+		return m.change(cmd)
+	case "/RUN":
+		return m.run(cmd)
+	case "/END":
+		return m.end(cmd)
+	case "/QUERY":
+		return m.query(cmd)
+	case "/SHOWSID":
+		t, err := m.find(cmd)
+		if err != nil {
+			return cmd.fail(hrNotFound, "The system cannot find the file specified.")
+		}
+		return cmd.success("The SID \"S-1-5-87-%d\" for the user name \"%s\" has been computed successfully.",
+			len(t.path), baseName(t.path))
+	}
+
+	return cmd.fail(hrInvalidArg, "Invalid argument/option - '%s'.", cmd.name)
+}
+
+func (m *Memory) create(cmd command) ([]byte, error) {
+	path := normalize(cmd.value("/TN"))
+	if _, ok := m.tasks[key(path)]; ok && !cmd.has("/F") {
+		return cmd.fail(hrAlreadyExists, "Cannot create a file when that file already exists.")
+	}
+
+	t := &memoryTask{
+		path:     path,
+		enabled:  true,
+		result:   resultNeverRun,
+		start:    m.now,
+		modifier: 1,
+		user:     cmd.value("/RU"),
+		level:    strings.ToUpper(cmd.value("/RL")),
+	}
+
+	if file := cmd.value("/XML"); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return cmd.fail(hrNotFound, "The system cannot find the file specified.")
+		}
+		t.xml = decodeText(data)
+		def, err := tasker.ParseDefinition([]byte(t.xml))
+		if err != nil {
+			return cmd.fail(0x8004131A, "The task XML is malformed.")
+		}
+		if len(def.Actions.Exec) > 0 {
+			exec := def.Actions.Exec[0]
+			t.run = strings.TrimSpace("\"" + strings.Trim(exec.Command, "\"") + "\" " + exec.Arguments)
+		}
+		t.enabled = def.Settings.Enabled != "false"
+		if def.Principal().RunLevel == "HighestAvailable" {
+			t.level = tasker.Level.HIGHEST
+		}
+	} else {
+		t.run = cmd.value("/TR")
+		t.schedule = strings.ToUpper(cmd.value("/SC"))
+		if t.run == "" || t.schedule == "" {
+			return cmd.fail(hrInvalidArg, "Invalid syntax. Mandatory option '/TR' or '/SC' is missing.")
+		}
+		if err := t.configure(cmd, m.now); err != nil {
+			return cmd.fail(hrInvalidArg, "%v", err)
+		}
+	}
+
+	m.tasks[key(path)] = t
+	return cmd.success("The scheduled task \"%s\" has successfully been created.", baseName(path))
+}
+
+func (m *Memory) delete(cmd command) ([]byte, error) {
+	t, err := m.find(cmd)
+	if err != nil {
+		return cmd.fail(hrNotFound, "The system cannot find the file specified.")
+	}
+	delete(m.tasks, key(t.path))
+	return cmd.success("The scheduled task \"%s\" was successfully deleted.", baseName(t.path))
+}
+
+func (m *Memory) change(cmd command) ([]byte, error) {
+	t, err := m.find(cmd)
+	if err != nil {
+		return cmd.fail(hrNotFound, "The system cannot find the file specified.")
+	}
+
+	if run := cmd.value("/TR"); run != "" {
+		t.run = run
+		t.xml = ""
+	}
+	if user := cmd.value("/RU"); user != "" {
+		t.user = user
+	}
+	if level := cmd.value("/RL"); level != "" {
+		t.level = strings.ToUpper(level)
+	}
+	if err := t.configure(cmd, m.now); err != nil {
+		return cmd.fail(hrInvalidArg, "%v", err)
+	}
+	switch {
+	case cmd.has("/ENABLE"):
+		t.enabled = true
+	case cmd.has("/DISABLE"):
+		t.enabled = false
+	}
+
+	return cmd.success("The parameters of scheduled task \"%s\" have been changed.", baseName(t.path))
+}
+
+func (m *Memory) run(cmd command) ([]byte, error) {
+	t, err := m.find(cmd)
+	if err != nil {
+		return cmd.fail(hrNotFound, "The system cannot find the file specified.")
+	}
+	if !t.enabled {
+		return cmd.fail(hrDisabled, "The task is disabled.")
+	}
+
+	if !t.running {
+		t.running = true
+		t.runs++
+		t.lastRun = m.now
+		t.result = resultRunning
+	}
+	return cmd.success("Attempted to run the scheduled task \"%s\".", baseName(t.path))
+}
+
+func (m *Memory) end(cmd command) ([]byte, error) {
+	t, err := m.find(cmd)
+	if err != nil {
+		return cmd.fail(hrNotFound, "The system cannot find the file specified.")
+	}
+	if !t.running {
+		return cmd.fail(hrNotRunning, "The task is not running.")
+	}
+
+	t.running = false
+	t.result = resultTerminated
+	return cmd.success("The scheduled task \"%s\" has been terminated successfully.", baseName(t.path))
+}
+
+func (m *Memory) query(cmd command) ([]byte, error) {
+	name := cmd.value("/TN")
+
+	if cmd.has("/XML") {
+		t, err := m.find(cmd)
+		if err != nil {
+			return cmd.fail(hrNotFound, "The system cannot find the file specified.")
+		}
+		return []byte(t.definition()), nil
+	}
+
+	tasks := []*memoryTask{}
+	switch {
+	case name == "":
+		for _, t := range m.tasks {
+			tasks = append(tasks, t)
+		}
+	case strings.HasSuffix(name, "\\"):
+		folder := strings.ToLower(normalize(name))
+		for k, t := range m.tasks {
+			if strings.HasPrefix(k, folder) {
+				tasks = append(tasks, t)
+			}
+		}
+		if len(tasks) == 0 {
+			return cmd.fail(hrNotFound, "The system cannot find the path specified.")
+		}
+	default:
+		t, err := m.find(cmd)
+		if err != nil {
+			return cmd.fail(hrNotFound, "The system cannot find the file specified.")
+		}
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return strings.ToLower(tasks[i].path) < strings.ToLower(tasks[j].path)
+	})
+
+	verbose := cmd.has("/V")
+	lines := []string{}
+	if !cmd.has("/NH") {
+		if verbose {
+			lines = append(lines, csvLine(verboseHeader))
+		} else {
+			lines = append(lines, csvLine([]string{"TaskName", "Next Run Time", "Status"}))
+		}
+	}
+	for _, t := range tasks {
+		if verbose {
+			lines = append(lines, csvLine(t.verbose(m.now)))
+		} else {
+			lines = append(lines, csvLine([]string{t.path, t.nextRun(m.now), t.status()}))
+		}
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n"), nil
+}
+
+//find looks up the task named by /TN
+func (m *Memory) find(cmd command) (*memoryTask, error) {
+	t, ok := m.tasks[key(cmd.value("/TN"))]
+	if !ok {
+		return nil, fmt.Errorf("taskertest: %s not found", cmd.value("/TN"))
+	}
+	return t, nil
+}
+
+//configure applies the schedule switches
+func (t *memoryTask) configure(cmd command, now time.Time) error {
+	if modifier := cmd.value("/MO"); modifier != "" {
+		n, err := strconv.Atoi(modifier)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("Invalid value for /MO option.")
+		}
+		t.modifier = n
+	}
+
+	date, clock := cmd.value("/SD"), cmd.value("/ST")
+	if date == "" && clock == "" {
+		return nil
+	}
+
+	start := t.start
+	if date != "" {
+		d, err := time.ParseInLocation(dateLayout, date, now.Location())
+		if err != nil {
+			return fmt.Errorf("Invalid Start Date (Date should be in \"mm/dd/yyyy\" format).")
+		}
+		start = time.Date(d.Year(), d.Month(), d.Day(), start.Hour(), start.Minute(), 0, 0, now.Location())
+	}
+	if clock != "" {
+		c, err := time.Parse("15:04", clock)
+		if err != nil {
+			return fmt.Errorf("Invalid Start Time (Time should be in \"HH:mm\" format).")
+		}
+		start = time.Date(start.Year(), start.Month(), start.Day(), c.Hour(), c.Minute(), 0, 0, now.Location())
+	}
+	t.start = start
+	return nil
+}
+
+//next returns the first scheduled run after from, zero when the task has
+//no time based schedule or is disabled
+func (t *memoryTask) next(from time.Time) time.Time {
+	if !t.enabled {
+		return time.Time{}
+	}
+
+	step := func(at time.Time) time.Time {
+		switch t.schedule {
+		case tasker.Schedules.MINUTE:
+			return at.Add(time.Duration(t.modifier) * time.Minute)
+		case tasker.Schedules.HOURLY:
+			return at.Add(time.Duration(t.modifier) * time.Hour)
+		case tasker.Schedules.DAILY:
+			return at.AddDate(0, 0, t.modifier)
+		case tasker.Schedules.WEEKLY:
+			return at.AddDate(0, 0, 7*t.modifier)
+		case tasker.Schedules.MONTHLY:
+			return at.AddDate(0, t.modifier, 0)
+		}
+		return time.Time{}
+	}
+
+	switch t.schedule {
+	case tasker.Schedules.ONCE:
+		if t.start.After(from) {
+			return t.start
+		}
+		return time.Time{}
+	case tasker.Schedules.MINUTE, tasker.Schedules.HOURLY, tasker.Schedules.DAILY,
+		tasker.Schedules.WEEKLY, tasker.Schedules.MONTHLY:
+		at := t.start
+		for !at.After(from) {
+			at = step(at)
+		}
+		return at
+	}
+	return time.Time{}
+}
+
+func (t *memoryTask) nextRun(now time.Time) string {
+	if next := t.next(now); !next.IsZero() {
+		return next.Format(timeLayout)
+	}
+	return notApplicable
+}
+
+func (t *memoryTask) status() string {
+	switch {
+	case !t.enabled:
+		return "Disabled"
+	case t.running:
+		return "Running"
+	}
+	return "Ready"
+}
+
+//verboseHeader columns of /QUERY /V
+var verboseHeader = []string{"HostName", "TaskName", "Next Run Time", "Status", "Logon Mode", "Last Run Time",
+	"Last Result", "Author", "Task To Run", "Start In", "Comment", "Scheduled Task State", "Idle Time",
+	"Power Management", "Run As User", "Delete Task If Not Rescheduled", "Stop Task If Runs X Hours and X Mins",
+	"Schedule", "Schedule Type", "Start Time", "Start Date", "End Date", "Days", "Months", "Repeat: Every"}
+
+//verbose returns the /QUERY /V row of the task
+func (t *memoryTask) verbose(now time.Time) []string {
+	lastRun := notApplicable
+	if !t.lastRun.IsZero() {
+		lastRun = t.lastRun.Format(timeLayout)
+	}
+	state := "Enabled"
+	if !t.enabled {
+		state = "Disabled"
+	}
+	user := t.user
+	if user == "" {
+		user = "taskertest"
+	}
+	schedule, startTime, startDate := notApplicable, notApplicable, notApplicable
+	if t.schedule != "" {
+		schedule = t.schedule
+		startTime = t.start.Format("3:04:05 PM")
+		startDate = t.start.Format("1/2/2006")
+	}
+
+	return []string{"MEMORY", t.path, t.nextRun(now), t.status(), "Interactive/Background", lastRun,
+		strconv.Itoa(t.result), "taskertest", t.run, notApplicable, notApplicable, state, "Disabled",
+		"Stop On Battery Mode", user, "Disabled", "72:00:00", "Scheduling data is not available in this format.",
+		schedule, startTime, startDate, notApplicable, notApplicable, notApplicable, "Disabled"}
+}
+
+//definition returns the task XML, the registered one or one describing
+//the command line options
+func (t *memoryTask) definition() string {
+	if t.xml != "" {
+		return t.xml
+	}
+
+	command, arguments := splitRun(t.run)
+	level := "LeastPrivilege"
+	if t.level == tasker.Level.HIGHEST {
+		level = "HighestAvailable"
+	}
+
+	trigger := ""
+	start := t.start.Format("2006-01-02T15:04:05")
+	switch t.schedule {
+	case tasker.Schedules.MINUTE, tasker.Schedules.HOURLY:
+		unit := "M"
+		if t.schedule == tasker.Schedules.HOURLY {
+			unit = "H"
+		}
+		trigger = fmt.Sprintf("<TimeTrigger><Repetition><Interval>PT%d%s</Interval></Repetition><StartBoundary>%s</StartBoundary></TimeTrigger>",
+			t.modifier, unit, start)
+	case tasker.Schedules.ONCE:
+		trigger = fmt.Sprintf("<TimeTrigger><StartBoundary>%s</StartBoundary></TimeTrigger>", start)
+	case tasker.Schedules.DAILY:
+		trigger = fmt.Sprintf("<CalendarTrigger><StartBoundary>%s</StartBoundary><ScheduleByDay><DaysInterval>%d</DaysInterval></ScheduleByDay></CalendarTrigger>",
+			start, t.modifier)
+	case tasker.Schedules.WEEKLY:
+		trigger = fmt.Sprintf("<CalendarTrigger><StartBoundary>%s</StartBoundary><ScheduleByWeek><WeeksInterval>%d</WeeksInterval></ScheduleByWeek></CalendarTrigger>",
+			start, t.modifier)
+	case tasker.Schedules.MONTHLY:
+		trigger = fmt.Sprintf("<CalendarTrigger><StartBoundary>%s</StartBoundary><ScheduleByMonth></ScheduleByMonth></CalendarTrigger>", start)
+	case tasker.Schedules.ONSTART:
+		trigger = "<BootTrigger />"
+	case tasker.Schedules.ONLOGON:
+		trigger = "<LogonTrigger />"
+	case tasker.Schedules.ONIDLE:
+		trigger = "<IdleTrigger />"
+	case tasker.Schedules.ONEVENT:
+		trigger = "<EventTrigger />"
+	}
+
+	arguments = xmlEscape(arguments)
+	if arguments != "" {
+		arguments = "<Arguments>" + arguments + "</Arguments>"
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <RegistrationInfo><Author>taskertest</Author><URI>%s</URI></RegistrationInfo>
+  <Triggers>%s</Triggers>
+  <Principals><Principal id="Author"><UserId>%s</UserId><RunLevel>%s</RunLevel></Principal></Principals>
+  <Settings><Enabled>%t</Enabled></Settings>
+  <Actions Context="Author"><Exec><Command>%s</Command>%s</Exec></Actions>
+</Task>`, xmlEscape(t.path), trigger, xmlEscape(t.user), level, t.enabled, xmlEscape(command), arguments)
+}
+
+//command parsed schtasks command line
+type command struct {
+	name    string
+	args    []string
+	hresult bool
+}
+
+func parseCommand(args []string) command {
+	cmd := command{}
+	for _, arg := range args {
+		if strings.EqualFold(arg, "/HRESULT") {
+			cmd.hresult = true
+			continue
+		}
+		cmd.args = append(cmd.args, arg)
+	}
+	if len(cmd.args) > 0 {
+		cmd.name = strings.ToUpper(cmd.args[0])
+	}
+	return cmd
+}
+
+//has reports whether a switch is present
+func (cmd command) has(name string) bool {
+	for _, arg := range cmd.args {
+		if strings.EqualFold(arg, name) {
+			return true
+		}
+	}
+	return false
+}
+
+//value returns the value of a switch, "" when missing
+func (cmd command) value(name string) string {
+	for i, arg := range cmd.args {
+		if strings.EqualFold(arg, name) && i+1 < len(cmd.args) {
+			return cmd.args[i+1]
+		}
+	}
+	return ""
+}
+
+func (cmd command) success(format string, args ...interface{}) ([]byte, error) {
+	return []byte("SUCCESS: " + fmt.Sprintf(format, args...) + "\r\n"), nil
+}
+
+//fail reports the HRESULT as exit code when requested, like schtasks
+func (cmd command) fail(hresult uint32, format string, args ...interface{}) ([]byte, error) {
+	code := 1
+	if cmd.hresult {
+		code = int(int32(hresult))
+	}
+	return []byte("ERROR: " + fmt.Sprintf(format, args...) + "\r\n"), &ExitError{code}
+}
+
+//help documented switches, read by SchTask.Capabilities
+var help = map[string]string{
+	"/CREATE": "SCHTASKS /Create [/S system [/U username [/P [password]]]] [/RU username [/RP password]] /SC schedule [/MO modifier] [/D day] [/M months] [/I idletime] /TN taskname /TR taskrun [/ST starttime] [/RI interval] [ {/ET endtime | /DU duration} [/K] [/XML xmlfile] [/V1]] [/SD startdate] [/ED enddate] [/IT | /NP] [/Z] [/F] [/HRESULT] [/?]\r\n" +
+		"/RL   level  /DELAY delaytime\r\n",
+	"/QUERY":   "SCHTASKS /Query [/S system [/U username [/P [password]]]] [/FO format | /XML [xml_type]] [/NH] [/V] [/TN taskname] [/HRESULT] [/?]\r\n",
+	"/DELETE":  "SCHTASKS /Delete [/S system [/U username [/P [password]]]] /TN taskname [/F] [/HRESULT] [/?]\r\n",
+	"/CHANGE":  "SCHTASKS /Change [/S system [/U username [/P [password]]]] /TN taskname { [/RU runasuser] [/RP runaspassword] [/TR taskrun] [/ST starttime] [/RI interval] [/SD startdate] [/ED enddate] [/ENABLE | /DISABLE] [/IT] [/Z] } [/HRESULT] [/?]\r\n",
+	"/RUN":     "SCHTASKS /Run [/S system [/U username [/P [password]]]] [/I] /TN taskname [/HRESULT] [/?]\r\n",
+	"/END":     "SCHTASKS /End [/S system [/U username [/P [password]]]] /TN taskname [/HRESULT] [/?]\r\n",
+	"/SHOWSID": "SCHTASKS /ShowSid [/S system [/U username [/P [password]]]] /TN taskname [/HRESULT] [/?]\r\n",
+}
+
+//key map key of a task path
+func key(name string) string {
+	return strings.ToLower(normalize(name))
+}
+
+//normalize returns the task path with a single leading backslash
+func normalize(name string) string {
+	return "\\" + strings.TrimLeft(strings.Replace(name, "/", "\\", -1), "\\")
+}
+
+//baseName returns the name of a task path without its folder
+func baseName(path string) string {
+	return path[strings.LastIndex(path, "\\")+1:]
+}
+
+//splitRun splits a /TR value into the program and its arguments
+func splitRun(run string) (string, string) {
+	run = strings.TrimSpace(run)
+	if strings.HasPrefix(run, "\"") {
+		if end := strings.Index(run[1:], "\""); end >= 0 {
+			return run[1 : end+1], strings.TrimSpace(run[end+2:])
+		}
+	}
+	command, arguments, _ := strings.Cut(run, " ")
+	return command, strings.TrimSpace(arguments)
+}
+
+//csvLine formats a row quoting every field like schtasks does
+func csvLine(fields []string) string {
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = "\"" + strings.Replace(field, "\"", "\"\"", -1) + "\""
+	}
+	return strings.Join(quoted, ",")
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;").Replace(s)
+}
+
+//decodeText decodes a UTF-16 file with byte order mark, as written by
+//the tasker, other files are returned as is
+func decodeText(data []byte) string {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xFE {
+		return string(data)
+	}
+	chars := make([]uint16, 0, len(data)/2)
+	for i := 2; i+1 < len(data); i += 2 {
+		chars = append(chars, binary.LittleEndian.Uint16(data[i:]))
+	}
+	return string(utf16.Decode(chars))
+}
@@ -0,0 +1,165 @@
+package taskertest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tasker "github.com/janmir/go-wintask"
+)
+
+func TestMemory(t *testing.T) {
+	memory := NewMemory(time.Date(2026, 1, 1, 8, 0, 0, 0, time.Local))
+	task := tasker.New(false).WithBackend(memory)
+
+	tc := tasker.TaskCreate{
+		Taskname:  "Backup",
+		Taskrun:   `C:\Program Files\app\app.exe`,
+		Arguments: []string{"--backup", "two words"},
+		Schedule:  tasker.Schedules.DAILY,
+		Starttime: "09:30",
+	}
+	if _, err := task.CreateTask(tc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := task.CreateTask(tc); !errors.Is(err, tasker.ErrAlreadyExists) {
+		t.Errorf("CreateTask() again = %v, want ErrAlreadyExists", err)
+	}
+	if caps := task.Capabilities(); !caps.Detected || !caps.Delay || !caps.NoHeader {
+		t.Errorf("Capabilities() = %+v", caps)
+	}
+
+	tasks := task.Query("Backup", true)
+	want := time.Date(2026, 1, 1, 9, 30, 0, 0, time.Local)
+	if len(tasks) != 1 || tasks[0].Status() != tasker.StatusReady || !tasks[0].NextRunTime().Equal(want) {
+		t.Fatalf("Query() = %+v", tasks)
+	}
+
+	memory.Advance(72 * time.Hour)
+	if runs := memory.Runs("go-wintask-Backup"); runs != 3 {
+		t.Errorf("Runs() = %d after three days, want 3", runs)
+	}
+
+	details, err := task.QueryDetail("Backup", true)
+	if err != nil || len(details) != 1 {
+		t.Fatalf("QueryDetail() = %+v, %v", details, err)
+	}
+	if d := details[0]; d.LastResult != 0 || !d.LastRunTime.Equal(want.AddDate(0, 0, 2)) || d.TaskToRun == "" {
+		t.Errorf("QueryDetail() = %+v", d)
+	}
+
+	def, err := task.GetTask("Backup", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exec := def.Actions.Exec[0]; exec.Command != tc.Taskrun || exec.Arguments != `--backup "two words"` {
+		t.Errorf("GetTask() action = %+v", exec)
+	}
+
+	if _, err := task.RunTask("Backup", true); err != nil {
+		t.Fatal(err)
+	}
+	if tasks := task.Query("Backup", true); tasks[0].Status() != tasker.StatusRunning {
+		t.Errorf("status after RunTask() = %s", tasks[0].Status())
+	}
+	if _, err := task.EndTask("Backup", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := task.EndTask("Backup", true); !errors.Is(err, tasker.ErrTaskNotRunning) {
+		t.Errorf("EndTask() again = %v, want ErrTaskNotRunning", err)
+	}
+
+	if _, err := task.DeleteTask("Backup", true, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := task.DeleteTask("Backup", true, true); !errors.Is(err, tasker.ErrNotFound) {
+		t.Errorf("DeleteTask() again = %v, want ErrNotFound", err)
+	}
+	if tasks := task.Query("*", true); len(tasks) != 0 {
+		t.Errorf("Query() after delete = %+v", tasks)
+	}
+}
+
+func TestMemoryActionXML(t *testing.T) {
+	memory := NewMemory(time.Date(2026, 1, 1, 8, 0, 0, 0, time.Local))
+	task := tasker.New(false).WithBackend(memory)
+
+	tc := tasker.TaskCreate{
+		Taskname:  "Report",
+		Taskrun:   `C:\app\report.exe`,
+		Arguments: []string{`say "hi"`},
+		Schedule:  tasker.Schedules.ONLOGON,
+		ActionXML: true,
+	}
+	if _, err := task.CreateTask(tc); err != nil {
+		t.Fatal(err)
+	}
+
+	def, err := task.GetTask("Report", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exec := def.Actions.Exec[0]; exec.Command != tc.Taskrun || exec.Arguments != `"say \"hi\""` {
+		t.Errorf("GetTask() action = %+v", exec)
+	}
+}
+
+func TestMemoryBackupRestore(t *testing.T) {
+	memory := NewMemory(time.Date(2026, 1, 1, 8, 0, 0, 0, time.Local))
+	restored := []string{}
+	task := tasker.New(false, tasker.Folder("app")).WithBackend(memory).WithHooks(tasker.Hooks{
+		OnBeforeCreate: func(taskcreate *tasker.TaskCreate) error {
+			restored = append(restored, taskcreate.Taskname)
+			return nil
+		},
+	})
+
+	tc := tasker.TaskCreate{
+		Taskname:    "Backup",
+		Taskrun:     `C:\app\backup.exe`,
+		Arguments:   []string{"--full"},
+		Schedule:    tasker.Schedules.DAILY,
+		Starttime:   "02:00",
+		Level:       tasker.Level.HIGHEST,
+		Description: "nightly backup",
+	}
+	if _, err := task.CreateTask(tc); err != nil {
+		t.Fatal(err)
+	}
+	want, err := task.GetTask("Backup", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if files, err := task.Backup(dir, tasker.Filter{Name: "*", Own: true}); err != nil || len(files) != 1 {
+		t.Fatalf("Backup() = %v, %v", files, err)
+	}
+	if _, err := task.DeleteTask("Backup", true, true); err != nil {
+		t.Fatal(err)
+	}
+
+	restored = nil
+	results, err := task.Restore(dir, false)
+	if err != nil || len(results) != 1 || results[0].Err != nil || results[0].Name != `\app\Backup` {
+		t.Fatalf("Restore() = %+v, %v", results, err)
+	}
+	if len(restored) != 1 || restored[0] != `\app\Backup` {
+		t.Errorf("Restore() hooked %v", restored)
+	}
+	got, err := task.GetTask("Backup", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.RegistrationInfo.Description != want.RegistrationInfo.Description || got.Actions.Exec[0] != want.Actions.Exec[0] ||
+		got.Principal().RunLevel != want.Principal().RunLevel {
+		t.Errorf("restored definition = %+v, want %+v", got, want)
+	}
+
+	if results, _ := task.Restore(dir, false); len(results) != 1 || !errors.Is(results[0].Err, tasker.ErrAlreadyExists) {
+		t.Errorf("Restore() over the task = %+v, want ErrAlreadyExists", results)
+	}
+	if results, _ := task.Restore(dir, true); len(results) != 1 || results[0].Err != nil {
+		t.Errorf("Restore() overwriting = %+v", results)
+	}
+}
@@ -0,0 +1,229 @@
+//Package taskertest records the schtasks invocations of a tasker into
+//fixture files and replays them, so tests run deterministically on
+//machines without a Task Scheduler.
+//
+//	func TestBackup(t *testing.T) {
+//		task := taskertest.Fixture(t, tasker.New(false), "testdata/backup.json")
+//		...
+//	}
+//
+//Fixtures are recorded against the real schtasks when the WINTASK_RECORD
+//environment variable is set and replayed otherwise. Memory emulates a
+//whole Task Scheduler instead, for tests which don't need the real one.
+package taskertest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	tasker "github.com/janmir/go-wintask"
+)
+
+const (
+	//RecordEnv environment variable switching Fixture to recording
+	RecordEnv = "WINTASK_RECORD"
+
+	redactedValue = "*****"
+
+	//xmlFileValue replaces the temporary XML file of a create, see redact
+	xmlFileValue = "task.xml"
+)
+
+var (
+	//ErrUnexpected the replayed command differs from the recorded one
+	ErrUnexpected = errors.New("taskertest: unexpected command")
+)
+
+//Interaction a single recorded schtasks invocation, passwords are
+//redacted from Args and XML files replaced by a placeholder
+type Interaction struct {
+	Args     []string
+	Output   string
+	ExitCode int    `json:",omitempty"`
+	Error    string `json:",omitempty"`
+}
+
+//ExitError replayed failure of schtasks
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+//ExitCode returns the recorded exit code
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+//Recorder tasker.Backend recording the invocations of another backend
+type Recorder struct {
+	backend tasker.Backend
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+//NewRecorder records the invocations passed on to backend, usually the
+//default one of a tasker, see SchTask.Backend
+func NewRecorder(backend tasker.Backend) *Recorder {
+	return &Recorder{backend: backend}
+}
+
+//Execute implements tasker.Backend
+func (r *Recorder) Execute(args []string, stdin io.Reader) ([]byte, error) {
+	output, err := r.backend.Execute(args, stdin)
+
+	interaction := Interaction{Args: redact(args), Output: string(output)}
+	var exit interface{ ExitCode() int }
+	switch {
+	case errors.As(err, &exit):
+		interaction.ExitCode = exit.ExitCode()
+	case err != nil:
+		interaction.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+
+	return output, err
+}
+
+//Interactions returns the invocations recorded so far
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+//Save writes the recorded invocations to a fixture file
+func (r *Recorder) Save(file string) error {
+	data, err := json.MarshalIndent(r.Interactions(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0644)
+}
+
+//Replayer tasker.Backend answering with recorded invocations, in the
+//recorded order
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	next         int
+}
+
+//NewReplayer replays interactions
+func NewReplayer(interactions []Interaction) *Replayer {
+	return &Replayer{interactions: interactions}
+}
+
+//Load replays a fixture file written by Recorder.Save
+func Load(file string) (*Replayer, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	interactions := []Interaction{}
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return NewReplayer(interactions), nil
+}
+
+//Execute implements tasker.Backend
+func (r *Replayer) Execute(args []string, stdin io.Reader) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.next >= len(r.interactions) {
+		return nil, fmt.Errorf("%w: %s, all %d recorded commands replayed", ErrUnexpected, strings.Join(args, " "), len(r.interactions))
+	}
+
+	interaction := r.interactions[r.next]
+	if !reflect.DeepEqual(redact(args), interaction.Args) {
+		return nil, fmt.Errorf("%w: %s, recorded %s", ErrUnexpected, strings.Join(redact(args), " "), strings.Join(interaction.Args, " "))
+	}
+	r.next++
+
+	output := []byte(interaction.Output)
+	switch {
+	case interaction.Error != "":
+		return output, errors.New(interaction.Error)
+	case interaction.ExitCode != 0:
+		return output, &ExitError{interaction.ExitCode}
+	}
+	return output, nil
+}
+
+//Done reports the recorded commands which were not replayed
+func (r *Replayer) Done() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.next < len(r.interactions) {
+		return fmt.Errorf("taskertest: %d of %d recorded commands not replayed, next %s", len(r.interactions)-r.next,
+			len(r.interactions), strings.Join(r.interactions[r.next].Args, " "))
+	}
+	return nil
+}
+
+//Fixture returns task executing through a fixture file: recording into it
+//when the WINTASK_RECORD environment variable is set, the file is written
+//at the end of the test, replaying it otherwise, failing the test when
+//commands were left over.
+func Fixture(t testing.TB, task tasker.SchTask, file string) tasker.SchTask {
+	t.Helper()
+
+	if os.Getenv(RecordEnv) != "" {
+		recorder := NewRecorder(task.Backend())
+		t.Cleanup(func() {
+			if err := recorder.Save(file); err != nil {
+				t.Error(err)
+			}
+		})
+		return task.WithBackend(recorder)
+	}
+
+	replayer, err := Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := replayer.Done(); err != nil && !t.Failed() {
+			t.Error(err)
+		}
+	})
+	return task.WithBackend(replayer)
+}
+
+//redact hides the passwords of a command line, the "*" of a prompted
+//password is kept. The XML file of a create is replaced by a placeholder
+//as the tasker writes a new temporary file on every run.
+func redact(args []string) []string {
+	redacted := append([]string(nil), args...)
+	create := len(redacted) > 0 && strings.EqualFold(redacted[0], "/CREATE")
+	for i := 1; i < len(redacted); i++ {
+		switch strings.ToUpper(redacted[i-1]) {
+		case "/RP", "/P":
+			if redacted[i] != "*" {
+				redacted[i] = redactedValue
+			}
+		case "/XML":
+			if create {
+				redacted[i] = xmlFileValue
+			}
+		}
+	}
+	return redacted
+}
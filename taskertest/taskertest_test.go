@@ -0,0 +1,139 @@
+package taskertest
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tasker "github.com/janmir/go-wintask"
+)
+
+//backendFunc adapts a function to tasker.Backend
+type backendFunc func(args []string) ([]byte, error)
+
+func (f backendFunc) Execute(args []string, stdin io.Reader) ([]byte, error) {
+	return f(args)
+}
+
+func TestFixture(t *testing.T) {
+	t.Setenv(RecordEnv, "")
+	task := Fixture(t, tasker.New(false), "testdata/query.json")
+
+	tasks := task.Query("Test", true)
+	if len(tasks) != 1 || tasks[0].Name() != "\\go-wintask-Test" || tasks[0].Status() != tasker.StatusReady {
+		t.Errorf("Query() = %+v", tasks)
+	}
+
+	_, err := task.RunTask("Missing", true)
+	var e *tasker.Error
+	if !errors.As(err, &e) || e.HRESULT != 0x80070002 {
+		t.Errorf("RunTask() = %v, want the recorded HRESULT", err)
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	real := backendFunc(func(args []string) ([]byte, error) {
+		if args[0] == "/DELETE" {
+			return []byte("ERROR: Access is denied.\r\n"), &ExitError{-2147024891}
+		}
+		return []byte("SUCCESS: created\r\n"), nil
+	})
+
+	recorder := NewRecorder(real)
+	task := tasker.New(false).WithBackend(recorder)
+	tc := tasker.TaskCreate{Taskname: "Test", Taskrun: "notepad.exe", Schedule: "ONLOGON", Username: "user", Password: "secret"}
+	if _, err := task.CreateTask(tc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := task.DeleteTask("Test", true, true); !errors.Is(err, tasker.ErrAccessDenied) {
+		t.Fatalf("DeleteTask() = %v, want ErrAccessDenied", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "fixture.json")
+	if err := recorder.Save(file); err != nil {
+		t.Fatal(err)
+	}
+	for _, interaction := range recorder.Interactions() {
+		for _, arg := range interaction.Args {
+			if arg == "secret" {
+				t.Errorf("password recorded: %v", interaction.Args)
+			}
+		}
+	}
+
+	replayer, err := Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task = tasker.New(false).WithBackend(replayer)
+	tc.Password = "rotated"
+	if _, err := task.CreateTask(tc); err != nil {
+		t.Errorf("replayed CreateTask() = %v", err)
+	}
+	if err := replayer.Done(); err == nil {
+		t.Error("Done() must report the pending delete")
+	}
+	if _, err := task.RunTask("Test", true); !errors.Is(err, ErrUnexpected) {
+		t.Errorf("RunTask() = %v, want ErrUnexpected", err)
+	}
+	if _, err := task.DeleteTask("Test", true, true); !errors.Is(err, tasker.ErrAccessDenied) {
+		t.Errorf("replayed DeleteTask() = %v, want ErrAccessDenied", err)
+	}
+	if err := replayer.Done(); err != nil {
+		t.Error(err)
+	}
+
+	if got := redact([]string{"/RU", "u", "/RP", "*"}); !reflect.DeepEqual(got, []string{"/RU", "u", "/RP", "*"}) {
+		t.Errorf("redact() = %v", got)
+	}
+	if got := redact([]string{"/QUERY", "/TN", "Test", "/XML", "/HRESULT"}); got[4] != "/HRESULT" {
+		t.Errorf("redact() query = %v", got)
+	}
+}
+
+const definition = `<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <RegistrationInfo>
+    <URI>\go-wintask-Test</URI>
+  </RegistrationInfo>
+  <Actions Context="Author">
+    <Exec>
+      <Command>notepad.exe</Command>
+    </Exec>
+  </Actions>
+</Task>`
+
+func TestReplayPatchedCreate(t *testing.T) {
+	real := backendFunc(func(args []string) ([]byte, error) {
+		switch {
+		case args[1] == "/?":
+			return nil, &ExitError{1}
+		case args[0] == "/QUERY":
+			return []byte(definition), nil
+		}
+		return []byte("SUCCESS: created\r\n"), nil
+	})
+
+	recorder := NewRecorder(real)
+	tc := tasker.TaskCreate{Taskname: "Test", Taskrun: "notepad.exe", Description: "patched through the XML"}
+	if _, err := tasker.New(false).WithBackend(recorder).CreateTask(tc); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "fixture.json")
+	if err := recorder.Save(file); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasker.New(false).WithBackend(replayer).CreateTask(tc); err != nil {
+		t.Errorf("replayed CreateTask() = %v", err)
+	}
+	if err := replayer.Done(); err != nil {
+		t.Error(err)
+	}
+}
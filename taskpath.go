@@ -0,0 +1,79 @@
+package tasker
+
+import "strings"
+
+//TaskPath registered task path split into its folder and name, e.g.
+//"\MyApp\Backup" is the task "Backup" of the folder "\MyApp". Every
+//method normalizes the names it is given the same way, see NormalizeName.
+type TaskPath struct {
+	//Folder holding the task, "\" for the root folder.
+	Folder string
+
+	//Name of the task within Folder.
+	Name string
+}
+
+//ParseTaskPath splits the task path into its folder and name after
+//normalizing it, see NormalizeName.
+func ParseTaskPath(path string) TaskPath {
+	path = NormalizeName(path)
+	i := strings.LastIndex(path, "\\")
+	if i == 0 {
+		return TaskPath{Folder: "\\", Name: path[1:]}
+	}
+	return TaskPath{Folder: path[:i], Name: path[i+1:]}
+}
+
+//JoinTaskPath joins folder components and a task name, whatever leading
+//or trailing separators they carry:
+//	JoinTaskPath("\\MyApp\\", "Sub", "Backup") // \MyApp\Sub\Backup
+func JoinTaskPath(elem ...string) TaskPath {
+	return ParseTaskPath(joinPath(elem...))
+}
+
+//String returns the registered path, e.g. "\MyApp\Backup"
+func (p TaskPath) String() string {
+	return joinPath(p.Folder, p.Name)
+}
+
+//joinPath joins path elements with single separators, empty elements
+//are left out
+func joinPath(elem ...string) string {
+	parts := make([]string, 0, len(elem))
+	for _, e := range elem {
+		e = strings.Trim(strings.Replace(strings.TrimSpace(e), "/", "\\", -1), "\\")
+		if e != "" {
+			parts = append(parts, e)
+		}
+	}
+	return "\\" + strings.Join(parts, "\\")
+}
+
+//Path registered path of the task split into its folder and name
+func (t Task) Path() TaskPath {
+	return ParseTaskPath(t.name)
+}
+
+//Resolve returns the path a task name is registered at, the namespace
+//applied for owned tasks, so other processes can target the task
+//without knowing the namespace rules.
+func (task SchTask) Resolve(name string, own bool) (TaskPath, error) {
+	path, err := task.resolveName(name, own)
+	if err != nil {
+		return TaskPath{}, err
+	}
+	return ParseTaskPath(path), nil
+}
+
+//CreatePath is CreateTask returning the path the task was registered at
+//instead of the schtasks output, see Resolve.
+func (task SchTask) CreatePath(taskcreate TaskCreate) (TaskPath, error) {
+	path, err := task.Resolve(taskcreate.Taskname, true)
+	if err != nil {
+		return TaskPath{}, err
+	}
+	if _, err := task.CreateTask(taskcreate); err != nil {
+		return TaskPath{}, err
+	}
+	return path, nil
+}
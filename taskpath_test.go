@@ -0,0 +1,74 @@
+package tasker
+
+import (
+	"io"
+	"testing"
+)
+
+func TestTaskPath(t *testing.T) {
+	cases := map[string]TaskPath{
+		"Backup":                  {"\\", "Backup"},
+		"\\Backup":                {"\\", "Backup"},
+		"MyApp/Backup":            {"\\MyApp", "Backup"},
+		" \\\\MyApp\\Sub\\Backup": {"\\MyApp\\Sub", "Backup"},
+	}
+	for path, want := range cases {
+		if got := ParseTaskPath(path); got != want {
+			t.Errorf("ParseTaskPath(%q) = %+v, want %+v", path, got, want)
+		}
+	}
+
+	if got := JoinTaskPath("\\MyApp\\", "/Sub/", "", "Backup").String(); got != "\\MyApp\\Sub\\Backup" {
+		t.Errorf("JoinTaskPath() = %s", got)
+	}
+	if got := (TaskPath{Name: "Backup"}).String(); got != "\\Backup" {
+		t.Errorf("String() root = %s", got)
+	}
+	if got := (Task{name: "\\MyApp\\Backup"}).Path(); got != (TaskPath{"\\MyApp", "Backup"}) {
+		t.Errorf("Path() = %+v", got)
+	}
+}
+
+func TestTaskPathConsistent(t *testing.T) {
+	var names []string
+	task := New(false).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[1] == helpSwitch {
+			return nil, exitError(1)
+		}
+		names = append(names, argValue(args, _Delete.taskname))
+		return nil, nil
+	}))
+
+	task.ShowSid("MyApp/Backup", false)
+	task.DeleteTask("MyApp/Backup", false, true)
+	task.RunTask("MyApp/Backup", false)
+	task.EndTask("MyApp/Backup", false)
+	if len(names) != 4 {
+		t.Fatalf("ran %d commands, want 4", len(names))
+	}
+	for _, name := range names {
+		if name != "\\MyApp\\Backup" {
+			t.Errorf("commands ran with names %v, want \\MyApp\\Backup", names)
+			break
+		}
+	}
+}
+
+func TestCreatePath(t *testing.T) {
+	task := New(false).WithBackend(backendFunc(func([]string, io.Reader) ([]byte, error) {
+		return nil, nil
+	}))
+
+	path, err := task.CreatePath(TaskCreate{Taskname: taskName, Taskrun: executable, Schedule: Schedules.ONLOGON})
+	if err != nil || path != (TaskPath{"\\", "go-wintask-Test"}) {
+		t.Errorf("CreatePath() = %+v, %v", path, err)
+	}
+
+	path, err = task.WithFolder("MyApp").Resolve("Sub/Backup", true)
+	if err != nil || path.String() != "\\MyApp\\Sub\\Backup" {
+		t.Errorf("Resolve() = %v, %v", path, err)
+	}
+	if _, err := task.Resolve("bad|name", false); err == nil {
+		t.Error("Resolve() of an invalid name succeeded")
+	}
+}
@@ -0,0 +1,154 @@
+package tasker
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+//Template definition shared by many tasks, e.g. the instances of a
+//multi-instance service, with text/template placeholders like {{.Port}}
+//or {{.InstallDir}} filled in by Instantiate. See NewTemplate and
+//ParseXMLTemplate.
+type Template struct {
+	//Force replaces an existing task when instantiating an XML
+	//template, like TaskCreate.Force
+	Force bool
+
+	create     TaskCreate
+	name       string
+	definition string
+}
+
+//NewTemplate returns a template of taskcreate, the placeholders may
+//appear in any of its string fields, the task name included.
+func NewTemplate(taskcreate TaskCreate) (Template, error) {
+	tmpl := Template{create: taskcreate}
+	err := eachString(reflect.ValueOf(&tmpl.create).Elem(), func(text string) (string, error) {
+		_, err := parseTemplate(text)
+		return text, err
+	})
+	return tmpl, err
+}
+
+//ParseXMLTemplate returns a template registering the task XML
+//definition as name, both may contain placeholders.
+func ParseXMLTemplate(name, definition string) (Template, error) {
+	tmpl := Template{name: name, definition: definition}
+	for _, text := range []string{name, definition} {
+		if _, err := parseTemplate(text); err != nil {
+			return Template{}, err
+		}
+	}
+	return tmpl, nil
+}
+
+//LoadXMLTemplate is ParseXMLTemplate reading the definition from file,
+//UTF-16 like the files exported by the Task Scheduler or UTF-8.
+func LoadXMLTemplate(name, file string) (Template, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Template{}, err
+	}
+	return ParseXMLTemplate(name, decodeUTF16(data))
+}
+
+//TaskCreate returns the task of a template made by NewTemplate with the
+//placeholders replaced by the fields of data.
+func (tmpl Template) TaskCreate(data interface{}) (TaskCreate, error) {
+	if tmpl.definition != "" {
+		return TaskCreate{}, fmt.Errorf("%w: XML templates have no TaskCreate", ErrInvalidValue)
+	}
+
+	taskcreate := tmpl.create
+	err := eachString(reflect.ValueOf(&taskcreate).Elem(), func(text string) (string, error) {
+		return expandTemplate(text, data)
+	})
+	return taskcreate, err
+}
+
+//Instantiate creates the task of tmpl with the placeholders replaced by
+//the fields of data, its name is owned like the one of CreateTask.
+func (task SchTask) Instantiate(tmpl Template, data interface{}) (string, error) {
+	if tmpl.definition == "" {
+		taskcreate, err := tmpl.TaskCreate(data)
+		if err != nil {
+			return "", err
+		}
+		return task.CreateTask(taskcreate)
+	}
+
+	name, err := expandTemplate(tmpl.name, data)
+	if err != nil {
+		return "", err
+	}
+	name, err = task.resolveName(name, true)
+	if err != nil {
+		return "", err
+	}
+	definition, err := expandTemplate(tmpl.definition, data)
+	if err != nil {
+		return "", err
+	}
+	root, err := parseNode([]byte(definition))
+	if err != nil {
+		return "", err
+	}
+
+	return task.create(TaskCreate{Taskname: name, Force: tmpl.Force, definition: root}, false)
+}
+
+//parseTemplate parses text failing on placeholders missing from the data
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("task").Option("missingkey=error").Parse(text)
+}
+
+//expandTemplate replaces the placeholders of text by the fields of data
+func expandTemplate(text string, data interface{}) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := parseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	var expanded strings.Builder
+	if err := tmpl.Execute(&expanded, data); err != nil {
+		return "", err
+	}
+	return expanded.String(), nil
+}
+
+//eachString replaces the exported string and string slice fields of the
+//struct value by the result of fn, slices are copied
+func eachString(value reflect.Value, fn func(string) (string, error)) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		switch {
+		case field.Kind() == reflect.String:
+			text, err := fn(field.String())
+			if err != nil {
+				return err
+			}
+			field.SetString(text)
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String && !field.IsNil():
+			texts := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+			for j := 0; j < field.Len(); j++ {
+				text, err := fn(field.Index(j).String())
+				if err != nil {
+					return err
+				}
+				texts.Index(j).SetString(text)
+			}
+			field.Set(texts)
+		}
+	}
+	return nil
+}
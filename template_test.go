@@ -0,0 +1,91 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	tmpl, err := NewTemplate(TaskCreate{
+		Taskname:  "Service-{{.Port}}",
+		Taskrun:   "{{.InstallDir}}\\svc.exe",
+		Arguments: []string{"--port", "{{.Port}}"},
+		Schedule:  "ONSTART",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := struct {
+		Port       int
+		InstallDir string
+	}{8080, "C:\\svc"}
+	taskcreate, err := tmpl.TaskCreate(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TaskCreate{Taskname: "Service-8080", Taskrun: "C:\\svc\\svc.exe", Arguments: []string{"--port", "8080"}, Schedule: "ONSTART"}
+	if !reflect.DeepEqual(taskcreate, want) {
+		t.Errorf("TaskCreate() = %+v, want %+v", taskcreate, want)
+	}
+	if tmpl.create.Arguments[1] != "{{.Port}}" {
+		t.Error("instantiating must not change the template")
+	}
+
+	if _, err := tmpl.TaskCreate(struct{ Port int }{1}); err == nil {
+		t.Error("TaskCreate() must fail on a missing placeholder")
+	}
+	if _, err := NewTemplate(TaskCreate{Taskname: "{{.Port"}); err == nil {
+		t.Error("NewTemplate() must fail on a malformed placeholder")
+	}
+}
+
+func TestXMLTemplate(t *testing.T) {
+	var created []string
+	registered := ""
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[0] == _Create.Command {
+			created = args
+			data, err := os.ReadFile(args[4])
+			if err != nil {
+				t.Fatal(err)
+			}
+			registered = decodeUTF16(data)
+		}
+		return nil, nil
+	}))
+
+	definition := strings.Replace(singletonXML, "</Task>", `<Actions><Exec><Command>svc.exe</Command><Arguments>--port {{.}}</Arguments></Exec></Actions></Task>`, 1)
+	tmpl, err := ParseXMLTemplate("Service-{{.}}", definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.Force = true
+
+	if _, err := task.Instantiate(tmpl, 8080); err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 6 || created[2] != "\\go-wintask-Service-8080" || created[5] != _Create.force {
+		t.Errorf("Instantiate() ran %v", created)
+	}
+	if !strings.Contains(registered, "--port 8080") {
+		t.Errorf("Instantiate() registered %s", registered)
+	}
+
+	hooked := ""
+	hooks := Hooks{OnBeforeCreate: func(taskcreate *TaskCreate) error {
+		hooked = taskcreate.Taskname
+		return errors.New("denied")
+	}}
+	if _, err := task.WithHooks(hooks).Instantiate(tmpl, 8081); err == nil || hooked != "\\go-wintask-Service-8081" {
+		t.Errorf("Instantiate() passed the hook as %q, %v", hooked, err)
+	}
+
+	if _, err := tmpl.TaskCreate(8080); err == nil {
+		t.Error("XML templates must not make a TaskCreate")
+	}
+}
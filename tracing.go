@@ -0,0 +1,92 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+const (
+	//hostSwitch remote system switch shared by every command
+	hostSwitch = "/S"
+)
+
+//Operation a single schtasks invocation reported to a Tracer
+type Operation struct {
+	//Name command, e.g. /CREATE
+	Name     string
+	Taskname string
+	//Host target system, "" for the local one
+	Host string
+}
+
+//Tracer observes schtasks invocations, e.g. to record them as trace spans
+//(see the tracing package). Start is called before the process runs and
+//the returned function once it exited, with the exit code or HRESULT.
+//Tracers may be called concurrently.
+type Tracer interface {
+	Start(ctx context.Context, op Operation) (end func(exitCode int, err error))
+}
+
+//WithTracer returns a copy of the tasker reporting every schtasks
+//invocation to tracer.
+func (task SchTask) WithTracer(tracer Tracer) SchTask {
+	task.tracer = tracer
+	return task
+}
+
+//WithContext returns a copy of the tasker passing ctx to its Tracer,
+//making the operations part of the trace of a request, e.g.
+//	task.WithContext(r.Context()).Run("Backup", true)
+func (task SchTask) WithContext(ctx context.Context) SchTask {
+	task.ctx = ctx
+	return task
+}
+
+//context returns the context of the operations
+func (task SchTask) context() context.Context {
+	if task.ctx == nil {
+		return context.Background()
+	}
+	return task.ctx
+}
+
+//operationOf describes a schtasks command line
+func operationOf(args []string) Operation {
+	op := Operation{
+		Taskname: argValue(args, _Create.taskname),
+		Host:     argValue(args, hostSwitch),
+	}
+	if len(args) > 0 {
+		op.Name = args[0]
+	}
+	return op
+}
+
+//argValue returns the value following a switch, "" when missing
+func argValue(args []string, name string) string {
+	for i, arg := range args {
+		if strings.EqualFold(arg, name) && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+//exitCode returns the HRESULT or exit code of a failed command, -1 when
+//the process didn't run and 0 on success
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var e *Error
+	if errors.As(err, &e) && e.HRESULT != 0 {
+		return int(int32(e.HRESULT))
+	}
+	var exit exitCoder
+	if errors.As(err, &exit) {
+		return exit.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,56 @@
+//Package tracing records the schtasks invocations of a tasker as
+//OpenTelemetry spans, see SchTask.WithTracer.
+//
+//	task := tasker.New(false).WithTracer(tracing.New(otel.GetTracerProvider()))
+//	task.WithContext(ctx).Run("Backup", true)
+package tracing
+
+import (
+	"context"
+
+	tasker "github.com/janmir/go-wintask"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentation = "github.com/janmir/go-wintask"
+)
+
+//Tracer tasker.Tracer starting a client span per invocation
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+//New creates a tracer from a provider, e.g. otel.GetTracerProvider()
+func New(provider trace.TracerProvider) Tracer {
+	return Tracer{
+		tracer: provider.Tracer(instrumentation),
+	}
+}
+
+//Start implements tasker.Tracer
+func (t Tracer) Start(ctx context.Context, op tasker.Operation) func(int, error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("wintask.operation", op.Name),
+	}
+	if op.Taskname != "" {
+		attrs = append(attrs, attribute.String("wintask.task", op.Taskname))
+	}
+	if op.Host != "" {
+		attrs = append(attrs, attribute.String("server.address", op.Host))
+	}
+
+	_, span := t.tracer.Start(ctx, "schtasks "+op.Name,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	return func(exitCode int, err error) {
+		span.SetAttributes(attribute.Int("process.exit.code", exitCode))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,25 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestOperationOf(t *testing.T) {
+	op := operationOf([]string{_Run.Command, _Run.taskname, "\\go-wintask-Test", _Run.immediate})
+	if op != (Operation{Name: "/RUN", Taskname: "\\go-wintask-Test"}) {
+		t.Errorf("operationOf() = %+v", op)
+	}
+
+	cases := map[error]int{
+		nil:                  0,
+		errors.New("failed"): -1,
+		fmt.Errorf("wrapped: %w", &Error{Command: "/RUN", HRESULT: 0x80041326, Err: ErrTaskDisabled}): -0x7FFBECDA,
+	}
+	for err, want := range cases {
+		if got := exitCode(err); got != want {
+			t.Errorf("exitCode(%v) = %d, want %d", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package tasker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+//EnsureTrigger adds trigger to the registered task unless an equivalent
+//trigger is present, so repeated installer runs don't pile up duplicate
+//triggers. Triggers are equivalent when their fields, see Trigger, are
+//equal apart from the id and Enabled, so a disabled trigger isn't added
+//again. The definition is read, edited and registered again, tasks
+//storing a password can't be edited this way as schtasks drops it.
+//added reports whether the trigger was added.
+func (task SchTask) EnsureTrigger(name string, own bool, trigger Trigger) (added bool, err error) {
+	if trigger.XMLName.Local == "" {
+		return false, fmt.Errorf("%w: trigger without kind", ErrInvalidValue)
+	}
+	want, err := canonicalTrigger(trigger)
+	if err != nil {
+		return false, err
+	}
+	node, err := parseNode([]byte(want))
+	if err != nil {
+		return false, err
+	}
+	if task.debugging() {
+		return false, nil
+	}
+
+	var patchErr error
+	_, err = task.updateDefinition(TaskCreate{Taskname: name}, own, func(root *xmlNode) bool {
+		triggers := root.ensure("Triggers")
+		for _, existing := range triggers.Nodes {
+			have, err := canonicalNode(existing)
+			if err != nil {
+				patchErr = err
+				return false
+			}
+			if have == want {
+				return false
+			}
+		}
+		triggers.Nodes = append(triggers.Nodes, node)
+		added = true
+		return true
+	})
+	if err == nil {
+		err = patchErr
+	}
+	return added && err == nil, err
+}
+
+//canonicalTrigger renders the trigger without the parts equivalent
+//triggers may differ in: the id, namespace and Enabled
+func canonicalTrigger(trigger Trigger) (string, error) {
+	trigger.XMLName.Space = ""
+	trigger.ID = ""
+	trigger.Enabled = ""
+
+	data, err := xml.Marshal(trigger)
+	return string(data), err
+}
+
+//canonicalNode renders a trigger element of a task definition like
+//canonicalTrigger
+func canonicalNode(node *xmlNode) (string, error) {
+	trigger := Trigger{}
+	if err := node.decode(&trigger); err != nil {
+		return "", err
+	}
+	return canonicalTrigger(trigger)
+}
+
+//RemoveTrigger removes the triggers of the registered task match
+//reports true for, editing its definition like EnsureTrigger. A task
+//without triggers is only run on demand. removed counts the triggers
+//removed, the task is left alone when none matched.
+func (task SchTask) RemoveTrigger(name string, own bool, match func(Trigger) bool) (removed int, err error) {
+	if task.debugging() {
+		return 0, nil
+	}
+
+	var patchErr error
+	_, err = task.updateDefinition(TaskCreate{Taskname: name}, own, func(root *xmlNode) bool {
+		triggers := root.child("Triggers")
+		if triggers == nil {
+			return false
+		}
+		kept := []*xmlNode{}
+		for _, node := range triggers.Nodes {
+			trigger := Trigger{}
+			if err := node.decode(&trigger); err != nil {
+				patchErr = err
+				return false
+			}
+			if match(trigger) {
+				removed++
+				continue
+			}
+			kept = append(kept, node)
+		}
+		triggers.Nodes = kept
+		return removed > 0
+	})
+	if err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+//IsEnabled reports whether the trigger starts the task, triggers are
+//enabled unless Enabled is false
+func (t Trigger) IsEnabled() bool {
+	return t.Enabled != "false"
+}
+
+//EnableTrigger enables the triggers of the registered task match reports
+//true for, editing its definition like EnsureTrigger. changed counts the
+//triggers that were disabled.
+func (task SchTask) EnableTrigger(name string, own bool, match func(Trigger) bool) (changed int, err error) {
+	return task.toggleTriggers(name, own, match, true)
+}
+
+//DisableTrigger disables the triggers of the registered task match
+//reports true for, e.g. to pause a seasonal schedule without losing its
+//definition. changed counts the triggers that were enabled.
+func (task SchTask) DisableTrigger(name string, own bool, match func(Trigger) bool) (changed int, err error) {
+	return task.toggleTriggers(name, own, match, false)
+}
+
+//toggleTriggers sets the Enabled element of the matching triggers, the
+//task is left alone when none changes
+func (task SchTask) toggleTriggers(name string, own bool, match func(Trigger) bool, enabled bool) (changed int, err error) {
+	if task.debugging() {
+		return 0, nil
+	}
+
+	var patchErr error
+	_, err = task.updateDefinition(TaskCreate{Taskname: name}, own, func(root *xmlNode) bool {
+		triggers := root.child("Triggers")
+		if triggers == nil {
+			return false
+		}
+		for _, node := range triggers.Nodes {
+			trigger := Trigger{}
+			if err := node.decode(&trigger); err != nil {
+				patchErr = err
+				return false
+			}
+			if !match(trigger) || trigger.IsEnabled() == enabled {
+				continue
+			}
+			node.insert("Enabled", "Repetition", "ExecutionTimeLimit", "Delay", "RandomDelay", "Subscription",
+				"ValueQueries", "UserId", "StateChange", "ScheduleByDay", "ScheduleByWeek", "ScheduleByMonth",
+				"ScheduleByMonthDayOfWeek").Text = strconv.FormatBool(enabled)
+			changed++
+		}
+		return changed > 0
+	})
+	if err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
@@ -0,0 +1,141 @@
+package tasker
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestEnsureTrigger(t *testing.T) {
+	registered, creates := calendarXML, 0
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch args[0] {
+		case _Query.Command:
+			return []byte(registered), nil
+		case _Create.Command:
+			data, err := os.ReadFile(args[4])
+			if err != nil {
+				t.Fatal(err)
+			}
+			registered = decodeUTF16(data)
+			creates++
+		}
+		return nil, nil
+	}))
+
+	//the registered daily trigger, apart from its id
+	daily := Trigger{XMLName: xml.Name{Local: "CalendarTrigger"}, ID: "Trigger1", Enabled: "true",
+		StartBoundary: "2024-06-01T03:00:00"}
+	daily.ScheduleByDay = &struct {
+		DaysInterval int `xml:"DaysInterval"`
+	}{1}
+	if added, err := task.EnsureTrigger(taskName, true, daily); added || err != nil || creates != 0 {
+		t.Errorf("EnsureTrigger() present = %v, %v, %d registrations", added, err, creates)
+	}
+
+	logon := Trigger{XMLName: xml.Name{Local: "LogonTrigger"}, UserID: "bob"}
+	if added, err := task.EnsureTrigger(taskName, true, logon); !added || err != nil || creates != 1 {
+		t.Errorf("EnsureTrigger() = %v, %v, %d registrations", added, err, creates)
+	}
+	if added, err := task.EnsureTrigger(taskName, true, logon); added || err != nil || creates != 1 {
+		t.Errorf("EnsureTrigger() again = %v, %v, %d registrations", added, err, creates)
+	}
+
+	def, err := ParseDefinition([]byte(registered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kinds := len(def.Triggers.Items); kinds != 3 || def.Triggers.Items[2].Kind() != "LogonTrigger" {
+		t.Errorf("Triggers = %+v", def.Triggers.Items)
+	}
+
+	if _, err := task.EnsureTrigger(taskName, true, Trigger{}); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("EnsureTrigger() without kind = %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestRemoveTrigger(t *testing.T) {
+	registered := calendarXML
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch args[0] {
+		case _Query.Command:
+			return []byte(registered), nil
+		case _Create.Command:
+			data, err := os.ReadFile(args[4])
+			if err != nil {
+				t.Fatal(err)
+			}
+			registered = decodeUTF16(data)
+		}
+		return nil, nil
+	}))
+
+	boot := func(trigger Trigger) bool {
+		return trigger.Kind() == "BootTrigger"
+	}
+	if removed, err := task.RemoveTrigger(taskName, true, boot); removed != 1 || err != nil {
+		t.Errorf("RemoveTrigger() = %d, %v", removed, err)
+	}
+	def, err := ParseDefinition([]byte(registered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(def.Triggers.Items) != 1 || def.Triggers.Items[0].Kind() != "CalendarTrigger" {
+		t.Errorf("Triggers = %+v", def.Triggers.Items)
+	}
+
+	if removed, err := task.RemoveTrigger(taskName, true, boot); removed != 0 || err != nil {
+		t.Errorf("RemoveTrigger() again = %d, %v", removed, err)
+	}
+}
+
+func TestDisableTrigger(t *testing.T) {
+	registered, creates := calendarXML, 0
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		switch args[0] {
+		case _Query.Command:
+			return []byte(registered), nil
+		case _Create.Command:
+			data, err := os.ReadFile(args[4])
+			if err != nil {
+				t.Fatal(err)
+			}
+			registered = decodeUTF16(data)
+			creates++
+		}
+		return nil, nil
+	}))
+
+	calendar := func(trigger Trigger) bool {
+		return trigger.Kind() == "CalendarTrigger"
+	}
+	if changed, err := task.DisableTrigger(taskName, true, calendar); changed != 1 || err != nil {
+		t.Errorf("DisableTrigger() = %d, %v", changed, err)
+	}
+	def, err := ParseDefinition([]byte(registered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := def.Triggers.Items; len(items) != 2 || items[0].IsEnabled() || !items[1].IsEnabled() {
+		t.Errorf("Triggers = %+v", items)
+	}
+
+	//a paused trigger is not added again
+	daily := def.Triggers.Items[0]
+	daily.Enabled = ""
+	if added, err := task.EnsureTrigger(taskName, true, daily); added || err != nil {
+		t.Errorf("EnsureTrigger() disabled = %v, %v", added, err)
+	}
+
+	if changed, err := task.DisableTrigger(taskName, true, calendar); changed != 0 || err != nil || creates != 1 {
+		t.Errorf("DisableTrigger() again = %d, %v, %d registrations", changed, err, creates)
+	}
+	if changed, err := task.EnableTrigger(taskName, true, calendar); changed != 1 || err != nil {
+		t.Errorf("EnableTrigger() = %d, %v", changed, err)
+	}
+	if def, _ := ParseDefinition([]byte(registered)); !def.Triggers.Items[0].IsEnabled() {
+		t.Errorf("Triggers = %+v", def.Triggers.Items)
+	}
+}
@@ -0,0 +1,173 @@
+package tasker
+
+import (
+	"io"
+	"os"
+)
+
+//updateDefinition edits the registered definition of a task and
+//registers it again unless patch reports no change. The password of
+//taskcreate is passed along as re-registering drops the stored one.
+func (task SchTask) updateDefinition(taskcreate TaskCreate, own bool, patch func(root *xmlNode) bool) ([]byte, error) {
+	name, err := task.resolveName(taskcreate.Taskname, own)
+	if err != nil {
+		return nil, err
+	}
+
+	//no other process may register the task between the query and the
+	//registration
+	task, unlock, err := task.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	output, err := task.execute(_Query.Command, _Query.taskname, name, _Query.xml)
+	if err != nil {
+		return output, err
+	}
+
+	root, err := parseNode(output)
+	if err != nil {
+		return nil, err
+	}
+	if !patch(root) {
+		return nil, nil
+	}
+
+	args := []string{_Create.force}
+	if taskcreate.Username != "" && !isServiceAccount(taskcreate.Username) {
+		args = append(args, _Create.username, taskcreate.Username)
+		switch {
+		case taskcreate.PromptPassword:
+			args = append(args, _Create.password, "*")
+		case taskcreate.Password != "":
+			args = append(args, _Create.password, taskcreate.Password)
+		}
+	}
+
+	return task.registerDefinition(name, root, taskcreate.passwordInput(), args...)
+}
+
+//createDefinition registers the task XML of taskcreate under its
+//Taskname, replacing the registered task with Force
+func (task SchTask) createDefinition(taskcreate TaskCreate, own bool) (string, error) {
+	name, err := task.resolveName(taskcreate.Taskname, own)
+	if err != nil {
+		return "", err
+	}
+	if task.debugging() {
+		return dbgMessage, nil
+	}
+
+	args := []string{}
+	if taskcreate.Force {
+		args = append(args, _Create.force)
+	}
+	output, err := task.registerDefinition(name, taskcreate.definition, nil, args...)
+	return string(output), err
+}
+
+//registerDefinition registers the task XML document as name through a
+//temporary file, args are appended to the /CREATE command
+func (task SchTask) registerDefinition(name string, root *xmlNode, stdin io.Reader, args ...string) ([]byte, error) {
+	data, err := root.marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp("", "task-*"+backupExt)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(encodeUTF16(data))
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cmds := append([]string{_Create.Command, _Create.taskname, name, _Create.xml, file.Name()}, args...)
+	return task.executeInput(stdin, cmds...)
+}
+
+//needsPatch reports whether taskcreate has parts schtasks can't express
+//on the command line, see patchDefinition
+func (taskcreate TaskCreate) needsPatch() bool {
+	return taskcreate.ActionXML || taskcreate.Email != nil || taskcreate.Message != nil ||
+		taskcreate.ComHandler != nil || taskcreate.Maintenance != nil ||
+		taskcreate.AllowHardTerminate != nil || taskcreate.StopOnIdleEnd != nil || taskcreate.Preempt > 0 ||
+		taskcreate.StartWhenAvailable != nil ||
+		taskcreate.Description != "" || len(taskcreate.Tags) > 0 || taskcreate.RandomDelay > 0 ||
+		!taskcreate.StartBoundary.IsZero() || !taskcreate.EndBoundary.IsZero() ||
+		taskcreate.LogonMode == LogonModes.ALLUSERS || taskcreate.runLimit != nil
+}
+
+//patchDefinition applies the parts of taskcreate schtasks can't express
+//on the command line to the task XML, reports whether anything changed
+func (taskcreate TaskCreate) patchDefinition(root *xmlNode) bool {
+	changed := false
+
+	if taskcreate.ActionXML {
+		run, args := taskcreate.action()
+		exec := root.ensure("Actions", "Exec")
+		exec.set(run, "Command")
+		if args != "" {
+			exec.set(args, "Arguments")
+		} else {
+			exec.remove("Arguments")
+		}
+		changed = true
+	}
+
+	if taskcreate.Email != nil || taskcreate.Message != nil {
+		actions := root.ensure("Actions")
+		actions.remove("SendEmail")
+		actions.remove("ShowMessage")
+		if taskcreate.Email != nil {
+			taskcreate.Email.patch(actions.add("SendEmail"))
+		}
+		if taskcreate.Message != nil {
+			taskcreate.Message.patch(actions.add("ShowMessage"))
+		}
+		changed = true
+	}
+
+	if taskcreate.ComHandler != nil {
+		actions := root.ensure("Actions")
+		if taskcreate.Taskrun == "" {
+			actions.remove("Exec")
+		}
+		actions.remove("ComHandler")
+		taskcreate.ComHandler.patch(actions.add("ComHandler"))
+		changed = true
+	}
+
+	if taskcreate.Maintenance != nil {
+		taskcreate.Maintenance.patch(root)
+		changed = true
+	}
+
+	if taskcreate.patchSettings(root) {
+		changed = true
+	}
+	if taskcreate.patchDescription(root) {
+		changed = true
+	}
+	if taskcreate.patchRandomDelay(root) {
+		changed = true
+	}
+	if taskcreate.patchBoundaries(root) {
+		changed = true
+	}
+	if taskcreate.patchPrincipal(root) {
+		changed = true
+	}
+	if taskcreate.patchRunLimit(root) {
+		changed = true
+	}
+
+	return changed
+}
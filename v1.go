@@ -0,0 +1,34 @@
+package tasker
+
+import (
+	"fmt"
+	"strings"
+)
+
+//ValidateV1 checks a task can be created with /V1, pre-Vista tasks live
+//in the root folder and have no run level, delay, event trigger or XML
+//only settings
+func (task SchTask) ValidateV1(taskcreate TaskCreate, own bool) error {
+	name, err := task.resolveName(taskcreate.Taskname, own)
+	if err != nil {
+		return err
+	}
+
+	var option string
+	switch {
+	case strings.Count(name, "\\") > 1:
+		option = "folder " + taskPath(name)
+	case strings.EqualFold(taskcreate.Level, Level.HIGHEST):
+		option = "run level " + taskcreate.Level
+	case taskcreate.Delay > 0:
+		option = "delay " + formatDelaytime(taskcreate.Delay)
+	case strings.EqualFold(taskcreate.Schedule, Schedules.ONEVENT) || taskcreate.ChannelName != "":
+		option = "event trigger"
+	case taskcreate.needsPatch():
+		option = "XML only settings"
+	default:
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrNotV1Compatible, option)
+}
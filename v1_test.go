@@ -0,0 +1,37 @@
+package tasker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateV1(t *testing.T) {
+	tc := TaskCreate{Taskname: taskName, Taskrun: executable, Schedule: Schedules.DAILY, V1: true}
+	if err := tasker.ValidateV1(tc, true); err != nil {
+		t.Errorf("ValidateV1() = %v", err)
+	}
+
+	cmds := strings.Join(tasker.TaskMake(tc, _Create.Command, true), " ")
+	if !strings.Contains(cmds, _Create.preVista) {
+		t.Errorf("missing /V1: %s", cmds)
+	}
+
+	invalid := []TaskCreate{
+		{Taskname: "app\\Test", Taskrun: executable},
+		{Taskname: taskName, Taskrun: executable, Level: Level.HIGHEST},
+		{Taskname: taskName, Taskrun: executable, Delay: time.Minute},
+		{Taskname: taskName, Taskrun: executable, Schedule: Schedules.ONEVENT, ChannelName: "System"},
+		{Taskname: taskName, Taskrun: executable, ActionXML: true},
+	}
+	for _, tc := range invalid {
+		if err := tasker.ValidateV1(tc, true); !errors.Is(err, ErrNotV1Compatible) {
+			t.Errorf("ValidateV1(%+v) = %v, want ErrNotV1Compatible", tc, err)
+		}
+	}
+
+	if err := tasker.WithFolder("app").ValidateV1(tc, true); !errors.Is(err, ErrNotV1Compatible) {
+		t.Errorf("ValidateV1() in a folder = %v, want ErrNotV1Compatible", err)
+	}
+}
@@ -0,0 +1,53 @@
+package tasker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//ErrMismatch the registered task differs from the requested one, see
+//WithVerify
+var ErrMismatch = errors.New("tasker: registered task differs from the request")
+
+//MismatchError registered task whose schedule, action or run level
+//differ from the requested ones, matches ErrMismatch. Old of the
+//Differences holds the registered value.
+type MismatchError struct {
+	Name        string
+	Differences []Difference
+}
+
+//Error implements error
+func (e *MismatchError) Error() string {
+	diffs := make([]string, len(e.Differences))
+	for i, d := range e.Differences {
+		diffs[i] = d.String()
+	}
+	return fmt.Sprintf("%v: %s: %s", ErrMismatch, e.Name, strings.Join(diffs, ", "))
+}
+
+//Unwrap returns ErrMismatch
+func (e *MismatchError) Unwrap() error {
+	return ErrMismatch
+}
+
+//WithVerify returns a copy of the tasker reading the task back after
+//CreateTask registered it, failing with a *MismatchError when its
+//schedule, action or run level were silently coerced by schtasks.
+func (task SchTask) WithVerify(enabled bool) SchTask {
+	task.verify = enabled
+	return task
+}
+
+//verifyCreate compares the registered task with taskcreate
+func (task SchTask) verifyCreate(taskcreate TaskCreate, own bool) error {
+	def, err := task.GetTask(taskcreate.Taskname, own)
+	if err != nil {
+		return err
+	}
+	if diffs := compareDefinition(def, taskcreate); len(diffs) > 0 {
+		return &MismatchError{task.fullName(taskcreate.Taskname, own), diffs}
+	}
+	return nil
+}
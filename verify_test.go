@@ -0,0 +1,35 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestWithVerify(t *testing.T) {
+	task := tasker.WithVerify(true).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[0] == _Query.Command {
+			return []byte(singletonXML), nil
+		}
+		return []byte("SUCCESS: The scheduled task \"go-wintask-Test\" has successfully been created.\r\n"), nil
+	}))
+
+	taskcreate := TaskCreate{Taskname: taskName, Taskrun: executable, Arguments: []string{"a", "b c"}}
+	if _, err := task.CreateTask(taskcreate); err != nil {
+		t.Errorf("CreateTask() = %v", err)
+	}
+
+	taskcreate.Level = Level.HIGHEST
+	_, err := task.CreateTask(taskcreate)
+	var mismatch *MismatchError
+	if !errors.Is(err, ErrMismatch) || !errors.As(err, &mismatch) {
+		t.Fatalf("CreateTask() = %v, want a mismatch", err)
+	}
+	if len(mismatch.Differences) != 1 || mismatch.Differences[0].Field != "Level" || mismatch.Name != "\\go-wintask-Test" {
+		t.Errorf("unexpected mismatch %+v", mismatch)
+	}
+
+	if _, err := task.WithVerify(false).CreateTask(taskcreate); err != nil {
+		t.Errorf("CreateTask() without verify = %v", err)
+	}
+}
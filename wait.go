@@ -0,0 +1,52 @@
+package tasker
+
+import (
+	"context"
+	"time"
+)
+
+//waitInterval how often WaitForRegistration and WaitForDeletion look the
+//task up
+var waitInterval = time.Second
+
+//WaitForRegistration waits until the task is registered, e.g. by Group
+//Policy or a deployment tool working asynchronously, or ctx is done.
+func (task SchTask) WaitForRegistration(ctx context.Context, name string, own bool) error {
+	return task.waitFor(ctx, name, own, true)
+}
+
+//WaitForDeletion waits until the task is no longer registered or ctx is
+//done.
+func (task SchTask) WaitForDeletion(ctx context.Context, name string, own bool) error {
+	return task.waitFor(ctx, name, own, false)
+}
+
+//waitFor polls the task until its existence is registered
+func (task SchTask) waitFor(ctx context.Context, name string, own bool, registered bool) error {
+	if _, err := task.resolveName(name, own); err != nil {
+		return err
+	}
+
+	//the cache and snapshot would hide the change
+	task.cache = nil
+	task.snapshot = nil
+
+	ticker := time.NewTicker(waitInterval)
+	defer ticker.Stop()
+
+	for {
+		exists, err := task.Exists(name, own)
+		if err != nil {
+			return err
+		}
+		if exists == registered {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWaitFor(t *testing.T) {
+	defer func(interval time.Duration) { waitInterval = interval }(waitInterval)
+	waitInterval = time.Millisecond
+
+	polls := 0
+	task := tasker.WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		polls++
+		if polls < 3 {
+			return []byte(summaryCSV), nil
+		}
+		return []byte(summaryCSV + "\"\\go-wintask-Late\",\"N/A\",\"Ready\"\r\n"), nil
+	}))
+
+	if err := task.WaitForRegistration(context.Background(), "Late", true); err != nil || polls != 3 {
+		t.Errorf("WaitForRegistration() = %v after %d polls", err, polls)
+	}
+	if err := task.WaitForDeletion(context.Background(), "Missing", true); err != nil {
+		t.Errorf("WaitForDeletion() = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := task.WaitForDeletion(ctx, "Late", true); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForDeletion() = %v, want the deadline", err)
+	}
+}
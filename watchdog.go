@@ -0,0 +1,81 @@
+package tasker
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	//maxTaskrun maximum length schtasks accepts for /TR
+	maxTaskrun = 261
+)
+
+//Watchdog heartbeat task which re-launches an executable when it is no
+//longer running, a poor-man's service recovery.
+type Watchdog struct {
+	//Taskname defaults to "watchdog-" followed by the executable name.
+	Taskname string
+
+	//Interval check frequency in minutes, defaults to 5.
+	Interval int
+
+	//Taskrun executable to watch and re-launch, defaults to the current
+	//executable.
+	Taskrun   string
+	Arguments []string
+
+	//PIDFile when set the process is looked up by the PID stored in the
+	//file (see WritePIDFile) instead of by its image name.
+	PIDFile string
+}
+
+//RegisterWatchdog registers a task running every Interval minutes
+//which starts the watched executable if it is not running, through
+//CreateTask.
+func (task SchTask) RegisterWatchdog(watchdog Watchdog) (string, error) {
+	run, args := TaskCreate{Taskrun: watchdog.Taskrun, Arguments: watchdog.Arguments}.action()
+	image := baseName(run)
+
+	start := strings.TrimSpace(fmt.Sprintf("start \"\" \"%s\" %s", run, args))
+	var script, shell string
+	if watchdog.PIDFile != "" {
+		shell = "/V:ON /C"
+		script = fmt.Sprintf("set /P PID=<\"%s\" & tasklist /NH /FI \"PID eq !PID!\" | find \"!PID!\" >NUL || %s",
+			watchdog.PIDFile, start)
+	} else {
+		shell = "/C"
+		script = fmt.Sprintf("tasklist /NH /FI \"IMAGENAME eq %s\" | find /I \"%s\" >NUL || %s",
+			image, image, start)
+	}
+
+	taskcreate := TaskCreate{
+		Taskname: watchdog.Taskname,
+		Taskrun:  "cmd.exe",
+		//cmd strips the outer quotes and runs the script as is
+		rawArguments: shell + " \"" + script + "\"",
+		Schedule:     Schedules.MINUTE,
+		Modifier:     strconv.Itoa(watchdog.Interval),
+		Force:        true,
+	}
+	if taskcreate.Taskname == "" {
+		taskcreate.Taskname = "watchdog-" + strings.TrimSuffix(image, ".exe")
+	}
+	if watchdog.Interval <= 0 {
+		taskcreate.Modifier = "5"
+	}
+
+	run, args = taskcreate.action()
+	if action := strings.TrimSpace("\"" + run + "\" " + args); len(action) > maxTaskrun {
+		return "", fmt.Errorf("%w: watchdog action exceeds %d characters: %s", ErrInvalidValue, maxTaskrun, action)
+	}
+
+	return task.CreateTask(taskcreate)
+}
+
+//WritePIDFile writes the current process id to file, used together
+//with Watchdog.PIDFile.
+func WritePIDFile(file string) error {
+	return os.WriteFile(file, []byte(strconv.Itoa(os.Getpid())), 0644)
+}
@@ -0,0 +1,40 @@
+package tasker
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRegisterWatchdog(t *testing.T) {
+	var created []string
+	var hooked *TaskCreate
+	task := New(false).WithHooks(Hooks{
+		OnBeforeCreate: func(taskcreate *TaskCreate) error {
+			hooked = taskcreate
+			return nil
+		},
+	}).WithBackend(backendFunc(func(args []string, stdin io.Reader) ([]byte, error) {
+		if args[0] == _Create.Command && args[1] != helpSwitch {
+			created = append(created, strings.Join(args, " "))
+		}
+		return nil, nil
+	}))
+
+	if _, err := task.RegisterWatchdog(Watchdog{Taskrun: "C:\\app\\app.exe"}); err != nil {
+		t.Fatal(err)
+	}
+	if hooked == nil || hooked.Taskname != "watchdog-app" {
+		t.Errorf("RegisterWatchdog() hooked %+v", hooked)
+	}
+	if len(created) != 1 || !strings.Contains(created[0], "/TN \\go-wintask-watchdog-app") || !strings.Contains(created[0], "/MO 5") {
+		t.Errorf("RegisterWatchdog() ran %v", created)
+	}
+
+	created = nil
+	long := Watchdog{Taskrun: "C:\\" + strings.Repeat("a", maxTaskrun) + ".exe"}
+	if _, err := task.RegisterWatchdog(long); !errors.Is(err, ErrInvalidValue) || len(created) != 0 {
+		t.Errorf("RegisterWatchdog() with a long action = %v, ran %v", err, created)
+	}
+}
@@ -0,0 +1,126 @@
+package tasker
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	//maxWeeksInterval largest /MO of the WEEKLY schedule
+	maxWeeksInterval = 52
+)
+
+//weekdays /D day of the weekdays
+var weekdays = map[time.Weekday]string{
+	time.Monday: Days.MON, time.Tuesday: Days.TUE, time.Wednesday: Days.WED, time.Thursday: Days.THU,
+	time.Friday: Days.FRI, time.Saturday: Days.SAT, time.Sunday: Days.SUN,
+}
+
+//WeeklyTrigger runs the task on a set of weekdays of every
+//WeeksInterval weeks, a WEEKLY schedule with a typed /MO and /D. For
+//every second Monday, Wednesday and Friday:
+//	Weekly: &WeeklyTrigger{WeeksInterval: 2, DaysOfWeek: []time.Weekday{time.Monday, time.Wednesday, time.Friday}}
+type WeeklyTrigger struct {
+	//WeeksInterval runs the task every that many weeks, 1 - 52, 0 runs
+	//it every week.
+	WeeksInterval int
+
+	//DaysOfWeek days of the week the task runs on, in any order and
+	//without duplicates. None runs it on the weekday of the start date.
+	DaysOfWeek []time.Weekday
+}
+
+//check validates the interval and days
+func (weekly WeeklyTrigger) check() error {
+	if weekly.WeeksInterval < 0 || weekly.WeeksInterval > maxWeeksInterval {
+		return fmt.Errorf("%w: weeks interval %d, /MO takes 1 - %d", ErrInvalidValue, weekly.WeeksInterval, maxWeeksInterval)
+	}
+	seen := map[time.Weekday]bool{}
+	for _, day := range weekly.DaysOfWeek {
+		if _, ok := weekdays[day]; !ok || seen[day] {
+			return fmt.Errorf("%w: day of week %d", ErrInvalidValue, day)
+		}
+		seen[day] = true
+	}
+	return nil
+}
+
+//days returns the /D days Monday first
+func (weekly WeeklyTrigger) days() []string {
+	sorted := append([]time.Weekday{}, weekly.DaysOfWeek...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return (sorted[i]+6)%7 < (sorted[j]+6)%7
+	})
+	days := []string{}
+	for _, day := range sorted {
+		days = append(days, weekdays[day])
+	}
+	return days
+}
+
+//withWeekly sets the WEEKLY schedule, /MO and /D of the weekly trigger
+func (taskcreate TaskCreate) withWeekly() TaskCreate {
+	weekly := taskcreate.Weekly
+	if weekly == nil {
+		return taskcreate
+	}
+	taskcreate.Schedule = Schedules.WEEKLY
+	if weekly.WeeksInterval > 1 {
+		taskcreate.Modifier = strconv.Itoa(weekly.WeeksInterval)
+	}
+	if len(weekly.DaysOfWeek) > 0 {
+		taskcreate.Days = weekly.days()
+	}
+	return taskcreate
+}
+
+//checkWeekly validates the weekly trigger, which replaces the schedule,
+//modifier and days, and the modifier and days of WEEKLY schedules
+func (taskcreate TaskCreate) checkWeekly() error {
+	if weekly := taskcreate.Weekly; weekly != nil {
+		switch {
+		case taskcreate.Schedule != "" && !strings.EqualFold(taskcreate.Schedule, Schedules.WEEKLY):
+			return fmt.Errorf("%w: weekly trigger with schedule %s", ErrInvalidValue, taskcreate.Schedule)
+		case taskcreate.Modifier != "" || len(taskcreate.Days) > 0 || taskcreate.Boot != nil:
+			return fmt.Errorf("%w: weekly trigger combined with a modifier, days or boot trigger", ErrInvalidValue)
+		}
+		return weekly.check()
+	}
+	if !strings.EqualFold(taskcreate.Schedule, Schedules.WEEKLY) {
+		return nil
+	}
+
+	if taskcreate.Modifier != "" {
+		weeks, err := strconv.Atoi(taskcreate.Modifier)
+		if err != nil || weeks < 1 || weeks > maxWeeksInterval {
+			return fmt.Errorf("%w: WEEKLY modifier %q, /MO takes 1 - %d weeks", ErrInvalidValue,
+				taskcreate.Modifier, maxWeeksInterval)
+		}
+	}
+	seen := map[string]bool{}
+	for _, day := range taskcreate.Days {
+		day = strings.ToUpper(strings.TrimSpace(day))
+		switch {
+		case day == Days.ALL && len(taskcreate.Days) == 1:
+		case day == Days.ALL:
+			return fmt.Errorf("%w: %q combined with other days", ErrInvalidValue, Days.ALL)
+		case !isWeekday(day) || seen[day]:
+			return fmt.Errorf("%w: WEEKLY day %q, /D takes MON - SUN once each", ErrInvalidValue, day)
+		}
+		seen[day] = true
+	}
+	return nil
+}
+
+//isWeekday reports whether day is a /D day of the week
+func isWeekday(day string) bool {
+	for _, d := range weekdays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package tasker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWeeklyTrigger(t *testing.T) {
+	tc := TaskCreate{Taskname: taskName, Taskrun: executable, Weekly: &WeeklyTrigger{WeeksInterval: 2,
+		DaysOfWeek: []time.Weekday{time.Friday, time.Sunday, time.Monday, time.Wednesday}}}
+	if err := tasker.checkCreate(tc, true); err != nil {
+		t.Fatal(err)
+	}
+	cmds := tasker.TaskMake(tc, _Create.Command, true)
+	if !containsPair(cmds, _Create.schedule, Schedules.WEEKLY) || !containsPair(cmds, _Create.modifier, "2") ||
+		!containsPair(cmds, _Create.days, "MON,WED,FRI,SUN") {
+		t.Errorf("TaskMake() = %v", cmds)
+	}
+
+	//every week on the weekday of the start date
+	cmds = tasker.TaskMake(TaskCreate{Taskname: taskName, Taskrun: executable, Weekly: &WeeklyTrigger{}}, _Create.Command, true)
+	if !containsPair(cmds, _Create.schedule, Schedules.WEEKLY) || argValue(cmds, _Create.modifier) != "" || argValue(cmds, _Create.days) != "" {
+		t.Errorf("TaskMake() = %v", cmds)
+	}
+}
+
+func TestCheckWeekly(t *testing.T) {
+	cases := []struct {
+		taskcreate TaskCreate
+		ok         bool
+	}{
+		{TaskCreate{Schedule: Schedules.WEEKLY, Modifier: "2", Days: []string{"mon", "WED", "FRI"}}, true},
+		{TaskCreate{Schedule: Schedules.WEEKLY, Days: []string{Days.ALL}}, true},
+		{TaskCreate{Schedule: Schedules.WEEKLY}, true},
+		{TaskCreate{Schedule: Schedules.WEEKLY, Modifier: "53"}, false},
+		{TaskCreate{Schedule: Schedules.WEEKLY, Modifier: "LAST"}, false},
+		{TaskCreate{Schedule: Schedules.WEEKLY, Days: []string{"MON", "MON"}}, false},
+		{TaskCreate{Schedule: Schedules.WEEKLY, Days: []string{"15"}}, false},
+		{TaskCreate{Schedule: Schedules.WEEKLY, Days: []string{Days.ALL, "MON"}}, false},
+		{TaskCreate{Schedule: Schedules.MONTHLY, Modifier: "LAST", Days: []string{"SUN"}}, true},
+		{TaskCreate{Weekly: &WeeklyTrigger{WeeksInterval: 52}}, true},
+		{TaskCreate{Schedule: "weekly", Weekly: &WeeklyTrigger{}}, true},
+		{TaskCreate{Schedule: Schedules.DAILY, Weekly: &WeeklyTrigger{}}, false},
+		{TaskCreate{Days: []string{"MON"}, Weekly: &WeeklyTrigger{}}, false},
+		{TaskCreate{Weekly: &WeeklyTrigger{WeeksInterval: -1}}, false},
+		{TaskCreate{Weekly: &WeeklyTrigger{DaysOfWeek: []time.Weekday{time.Monday, time.Monday}}}, false},
+		{TaskCreate{Weekly: &WeeklyTrigger{DaysOfWeek: []time.Weekday{7}}}, false},
+	}
+	for _, c := range cases {
+		err := c.taskcreate.checkWeekly()
+		if c.ok && err != nil || !c.ok && !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("checkWeekly(%+v) = %v", c.taskcreate, err)
+		}
+	}
+}
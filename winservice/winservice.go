@@ -0,0 +1,85 @@
+//go:build windows
+
+//Package winservice registers the maintenance tasks of an application
+//running as a Windows service together with the service itself, so
+//installing the service adds its companion tasks and removing it cleans
+//them up.
+package winservice
+
+import (
+	"errors"
+	"fmt"
+
+	tasker "github.com/janmir/go-wintask"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+//Companion maintenance tasks belonging to a service
+type Companion struct {
+	task  tasker.SchTask
+	tasks []tasker.TaskCreate
+}
+
+//New creates the companion tasks of a service, they are owned tasks of
+//task and usually run as SYSTEM, see TaskCreate.AsSystem.
+func New(task tasker.SchTask, tasks ...tasker.TaskCreate) Companion {
+	return Companion{
+		task:  task,
+		tasks: tasks,
+	}
+}
+
+//Install creates the service like mgr.Mgr.CreateService and registers
+//the companion tasks, the service is deleted again when a task can't be
+//registered.
+func (c Companion) Install(m *mgr.Mgr, name, exepath string, config mgr.Config, args ...string) (*mgr.Service, error) {
+	s, err := m.CreateService(name, exepath, config, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Register(); err != nil {
+		s.Delete()
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+//Remove deletes the companion tasks and marks the service for deletion
+func (c Companion) Remove(s *mgr.Service) error {
+	if err := c.Unregister(); err != nil {
+		return err
+	}
+	return s.Delete()
+}
+
+//Register creates or replaces the companion tasks, for services installed
+//by other means. Tasks registered before a failure are removed again.
+func (c Companion) Register() error {
+	for i, taskcreate := range c.tasks {
+		taskcreate.Force = true
+		if _, err := c.task.CreateTask(taskcreate); err != nil {
+			c.unregister(c.tasks[:i])
+			return fmt.Errorf("registering %s: %w", taskcreate.Taskname, err)
+		}
+	}
+	return nil
+}
+
+//Unregister deletes the companion tasks, missing tasks are skipped
+func (c Companion) Unregister() error {
+	return c.unregister(c.tasks)
+}
+
+func (c Companion) unregister(tasks []tasker.TaskCreate) error {
+	errs := []error{}
+	for _, taskcreate := range tasks {
+		_, err := c.task.DeleteTask(taskcreate.Taskname, true, true)
+		if err != nil && !errors.Is(err, tasker.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("deleting %s: %w", taskcreate.Taskname, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,177 @@
+package tasker
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+const (
+	xmlHeader = "<?xml version=\"1.0\" encoding=\"UTF-16\"?>\n"
+)
+
+//xmlNode generic element of a task XML document, unlike TaskDefinition
+//it keeps every element in its original order so a definition can be
+//edited and registered again without losing anything
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Text    string     `xml:",chardata"`
+	Nodes   []*xmlNode `xml:",any"`
+}
+
+//parseNode parses a task XML document
+func parseNode(data []byte) (*xmlNode, error) {
+	root := &xmlNode{}
+
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	if err := decoder.Decode(root); err != nil {
+		return nil, err
+	}
+
+	root.clean(true)
+	return root, nil
+}
+
+//clean replaces the element namespaces by a single declaration on the
+//root element and drops the indentation of container elements
+func (n *xmlNode) clean(root bool) {
+	attrs := []xml.Attr{}
+	if root && n.XMLName.Space != "" {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: n.XMLName.Space})
+	}
+	for _, attr := range n.Attrs {
+		if attr.Name.Local != "xmlns" && attr.Name.Space != "xmlns" {
+			attrs = append(attrs, attr)
+		}
+	}
+	n.Attrs = attrs
+
+	n.XMLName.Space = ""
+	if len(n.Nodes) > 0 && strings.TrimSpace(n.Text) == "" {
+		n.Text = ""
+	}
+	for _, child := range n.Nodes {
+		child.clean(false)
+	}
+}
+
+//child returns the first child element named name, nil when missing
+func (n *xmlNode) child(name string) *xmlNode {
+	for _, child := range n.Nodes {
+		if child.XMLName.Local == name {
+			return child
+		}
+	}
+	return nil
+}
+
+//children returns the child elements named name
+func (n *xmlNode) children(name string) []*xmlNode {
+	nodes := []*xmlNode{}
+	for _, child := range n.Nodes {
+		if child.XMLName.Local == name {
+			nodes = append(nodes, child)
+		}
+	}
+	return nodes
+}
+
+//ensure returns the descendant at path, creating missing elements
+func (n *xmlNode) ensure(path ...string) *xmlNode {
+	node := n
+	for _, name := range path {
+		next := node.child(name)
+		if next == nil {
+			next = &xmlNode{XMLName: xml.Name{Local: name}}
+			node.Nodes = append(node.Nodes, next)
+		}
+		node = next
+	}
+	return node
+}
+
+//add appends a new child element named name
+func (n *xmlNode) add(name string) *xmlNode {
+	child := &xmlNode{XMLName: xml.Name{Local: name}}
+	n.Nodes = append(n.Nodes, child)
+	return child
+}
+
+//insert returns the child element named name, creating it in front of
+//the first existing element of before to keep the schema order
+func (n *xmlNode) insert(name string, before ...string) *xmlNode {
+	if child := n.child(name); child != nil {
+		return child
+	}
+	child := &xmlNode{XMLName: xml.Name{Local: name}}
+	for i, node := range n.Nodes {
+		for _, b := range before {
+			if node.XMLName.Local == b {
+				n.Nodes = append(n.Nodes[:i], append([]*xmlNode{child}, n.Nodes[i:]...)...)
+				return child
+			}
+		}
+	}
+	n.Nodes = append(n.Nodes, child)
+	return child
+}
+
+//set sets the text of the descendant at path, creating it if needed
+func (n *xmlNode) set(value string, path ...string) {
+	n.ensure(path...).Text = value
+}
+
+//get returns the text of the descendant at path, "" when missing
+func (n *xmlNode) get(path ...string) string {
+	node := n
+	for _, name := range path {
+		if node = node.child(name); node == nil {
+			return ""
+		}
+	}
+	return node.Text
+}
+
+//attr returns the value of the attribute named name, "" when missing
+func (n *xmlNode) attr(name string) string {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+//remove deletes the child elements named name
+func (n *xmlNode) remove(name string) {
+	nodes := []*xmlNode{}
+	for _, child := range n.Nodes {
+		if child.XMLName.Local != name {
+			nodes = append(nodes, child)
+		}
+	}
+	n.Nodes = nodes
+}
+
+//decode unmarshals the element into v, e.g. a Trigger
+func (n *xmlNode) decode(v interface{}) error {
+	data, err := xml.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(data, v)
+}
+
+//marshal renders the document including the XML declaration
+func (n *xmlNode) marshal() (string, error) {
+	data, err := xml.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xmlHeader + string(data), nil
+}